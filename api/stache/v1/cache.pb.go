@@ -9,6 +9,8 @@ package stachev1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,11 +24,19 @@ const (
 )
 
 type SetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
-	Value         []byte                 `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
-	Ttl           *int64                 `protobuf:"varint,3,opt,name=ttl" json:"ttl,omitempty"`
-	ContentType   *string                `protobuf:"bytes,4,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value []byte                 `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	// Legacy TTL in whole seconds, used only if neither ttl_duration
+	// nor expires_at is set.
+	Ttl         *int64               `protobuf:"varint,3,opt,name=ttl" json:"ttl,omitempty"`
+	ContentType *string              `protobuf:"bytes,4,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	Sliding     *bool                `protobuf:"varint,5,opt,name=sliding" json:"sliding,omitempty"`
+	TtlDuration *durationpb.Duration `protobuf:"bytes,6,opt,name=ttl_duration,json=ttlDuration" json:"ttl_duration,omitempty"`
+	// Absolute expiry; takes precedence over the TTL fields, which then
+	// only set the sliding window.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	Tags          []string               `protobuf:"bytes,8,rep,name=tags" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +99,34 @@ func (x *SetRequest) GetContentType() string {
 	return ""
 }
 
+func (x *SetRequest) GetSliding() bool {
+	if x != nil && x.Sliding != nil {
+		return *x.Sliding
+	}
+	return false
+}
+
+func (x *SetRequest) GetTtlDuration() *durationpb.Duration {
+	if x != nil {
+		return x.TtlDuration
+	}
+	return nil
+}
+
+func (x *SetRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *SetRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type SetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -126,8 +164,11 @@ func (*SetResponse) Descriptor() ([]byte, []int) {
 }
 
 type GetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// Id from an open Invalidations stream; if set, the server sends an
+	// invalidation on that stream the next time the key changes.
+	TrackingId    *uint64 `protobuf:"varint,2,opt,name=tracking_id,json=trackingId" json:"tracking_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,11 +210,23 @@ func (x *GetRequest) GetKey() string {
 	return ""
 }
 
+func (x *GetRequest) GetTrackingId() uint64 {
+	if x != nil && x.TrackingId != nil {
+		return *x.TrackingId
+	}
+	return 0
+}
+
 type GetResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         []byte                 `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
-	ContentType   *string                `protobuf:"bytes,2,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
-	ExpiresAtMs   *int64                 `protobuf:"varint,3,opt,name=expires_at_ms,json=expiresAtMs" json:"expires_at_ms,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Value       []byte                 `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	ContentType *string                `protobuf:"bytes,2,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	ExpiresAtMs *int64                 `protobuf:"varint,3,opt,name=expires_at_ms,json=expiresAtMs" json:"expires_at_ms,omitempty"`
+	Version     *uint64                `protobuf:"varint,4,opt,name=version" json:"version,omitempty"`
+	// Whether the key is now tracked for the request's tracking_id. Keys
+	// read through another node in cluster mode, and entries with sliding
+	// expiry, are not.
+	Tracked       *bool `protobuf:"varint,5,opt,name=tracked" json:"tracked,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -229,6 +282,20 @@ func (x *GetResponse) GetExpiresAtMs() int64 {
 	return 0
 }
 
+func (x *GetResponse) GetVersion() uint64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *GetResponse) GetTracked() bool {
+	if x != nil && x.Tracked != nil {
+		return *x.Tracked
+	}
+	return false
+}
+
 type DeleteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
@@ -317,30 +384,32 @@ func (x *DeleteResponse) GetDeleted() bool {
 	return false
 }
 
-type EntryInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
-	Size          *uint32                `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
-	ContentType   *string                `protobuf:"bytes,3,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
-	ExpiresAtMs   *int64                 `protobuf:"varint,4,opt,name=expires_at_ms,json=expiresAtMs" json:"expires_at_ms,omitempty"`
+type TouchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// Legacy TTL in whole seconds, used only if neither ttl_duration
+	// nor expires_at is set.
+	Ttl           *int64                 `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+	TtlDuration   *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl_duration,json=ttlDuration" json:"ttl_duration,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EntryInfo) Reset() {
-	*x = EntryInfo{}
+func (x *TouchRequest) Reset() {
+	*x = TouchRequest{}
 	mi := &file_stache_v1_cache_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EntryInfo) String() string {
+func (x *TouchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EntryInfo) ProtoMessage() {}
+func (*TouchRequest) ProtoMessage() {}
 
-func (x *EntryInfo) ProtoReflect() protoreflect.Message {
+func (x *TouchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_stache_v1_cache_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -352,59 +421,60 @@ func (x *EntryInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EntryInfo.ProtoReflect.Descriptor instead.
-func (*EntryInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use TouchRequest.ProtoReflect.Descriptor instead.
+func (*TouchRequest) Descriptor() ([]byte, []int) {
 	return file_stache_v1_cache_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *EntryInfo) GetKey() string {
+func (x *TouchRequest) GetKey() string {
 	if x != nil && x.Key != nil {
 		return *x.Key
 	}
 	return ""
 }
 
-func (x *EntryInfo) GetSize() uint32 {
-	if x != nil && x.Size != nil {
-		return *x.Size
+func (x *TouchRequest) GetTtl() int64 {
+	if x != nil && x.Ttl != nil {
+		return *x.Ttl
 	}
 	return 0
 }
 
-func (x *EntryInfo) GetContentType() string {
-	if x != nil && x.ContentType != nil {
-		return *x.ContentType
+func (x *TouchRequest) GetTtlDuration() *durationpb.Duration {
+	if x != nil {
+		return x.TtlDuration
 	}
-	return ""
+	return nil
 }
 
-func (x *EntryInfo) GetExpiresAtMs() int64 {
-	if x != nil && x.ExpiresAtMs != nil {
-		return *x.ExpiresAtMs
+func (x *TouchRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
 	}
-	return 0
+	return nil
 }
 
-type ListEntriesRequest struct {
+type TouchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpiresAtMs   *int64                 `protobuf:"varint,1,opt,name=expires_at_ms,json=expiresAtMs" json:"expires_at_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListEntriesRequest) Reset() {
-	*x = ListEntriesRequest{}
+func (x *TouchResponse) Reset() {
+	*x = TouchResponse{}
 	mi := &file_stache_v1_cache_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListEntriesRequest) String() string {
+func (x *TouchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEntriesRequest) ProtoMessage() {}
+func (*TouchResponse) ProtoMessage() {}
 
-func (x *ListEntriesRequest) ProtoReflect() protoreflect.Message {
+func (x *TouchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_stache_v1_cache_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -416,32 +486,39 @@ func (x *ListEntriesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEntriesRequest.ProtoReflect.Descriptor instead.
-func (*ListEntriesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TouchResponse.ProtoReflect.Descriptor instead.
+func (*TouchResponse) Descriptor() ([]byte, []int) {
 	return file_stache_v1_cache_proto_rawDescGZIP(), []int{7}
 }
 
-type ListEntriesResponse struct {
+func (x *TouchResponse) GetExpiresAtMs() int64 {
+	if x != nil && x.ExpiresAtMs != nil {
+		return *x.ExpiresAtMs
+	}
+	return 0
+}
+
+type GetTTLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entries       []*EntryInfo           `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListEntriesResponse) Reset() {
-	*x = ListEntriesResponse{}
+func (x *GetTTLRequest) Reset() {
+	*x = GetTTLRequest{}
 	mi := &file_stache_v1_cache_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListEntriesResponse) String() string {
+func (x *GetTTLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEntriesResponse) ProtoMessage() {}
+func (*GetTTLRequest) ProtoMessage() {}
 
-func (x *ListEntriesResponse) ProtoReflect() protoreflect.Message {
+func (x *GetTTLRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_stache_v1_cache_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -453,39 +530,40 @@ func (x *ListEntriesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEntriesResponse.ProtoReflect.Descriptor instead.
-func (*ListEntriesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTTLRequest.ProtoReflect.Descriptor instead.
+func (*GetTTLRequest) Descriptor() ([]byte, []int) {
 	return file_stache_v1_cache_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListEntriesResponse) GetEntries() []*EntryInfo {
-	if x != nil {
-		return x.Entries
+func (x *GetTTLRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
 	}
-	return nil
+	return ""
 }
 
-type BatchGetRequest struct {
+type GetTTLResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Keys          []string               `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+	TtlMs         *int64                 `protobuf:"varint,1,opt,name=ttl_ms,json=ttlMs" json:"ttl_ms,omitempty"`
+	ExpiresAtMs   *int64                 `protobuf:"varint,2,opt,name=expires_at_ms,json=expiresAtMs" json:"expires_at_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchGetRequest) Reset() {
-	*x = BatchGetRequest{}
+func (x *GetTTLResponse) Reset() {
+	*x = GetTTLResponse{}
 	mi := &file_stache_v1_cache_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchGetRequest) String() string {
+func (x *GetTTLResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchGetRequest) ProtoMessage() {}
+func (*GetTTLResponse) ProtoMessage() {}
 
-func (x *BatchGetRequest) ProtoReflect() protoreflect.Message {
+func (x *GetTTLResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_stache_v1_cache_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -497,39 +575,46 @@ func (x *BatchGetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchGetRequest.ProtoReflect.Descriptor instead.
-func (*BatchGetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTTLResponse.ProtoReflect.Descriptor instead.
+func (*GetTTLResponse) Descriptor() ([]byte, []int) {
 	return file_stache_v1_cache_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *BatchGetRequest) GetKeys() []string {
-	if x != nil {
-		return x.Keys
+func (x *GetTTLResponse) GetTtlMs() int64 {
+	if x != nil && x.TtlMs != nil {
+		return *x.TtlMs
 	}
-	return nil
+	return 0
 }
 
-type BatchGetResponse struct {
+func (x *GetTTLResponse) GetExpiresAtMs() int64 {
+	if x != nil && x.ExpiresAtMs != nil {
+		return *x.ExpiresAtMs
+	}
+	return 0
+}
+
+type InvalidateTagsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*GetResponseItem     `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchGetResponse) Reset() {
-	*x = BatchGetResponse{}
+func (x *InvalidateTagsRequest) Reset() {
+	*x = InvalidateTagsRequest{}
 	mi := &file_stache_v1_cache_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchGetResponse) String() string {
+func (x *InvalidateTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchGetResponse) ProtoMessage() {}
+func (*InvalidateTagsRequest) ProtoMessage() {}
 
-func (x *BatchGetResponse) ProtoReflect() protoreflect.Message {
+func (x *InvalidateTagsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_stache_v1_cache_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -541,43 +626,39 @@ func (x *BatchGetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchGetResponse.ProtoReflect.Descriptor instead.
-func (*BatchGetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use InvalidateTagsRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateTagsRequest) Descriptor() ([]byte, []int) {
 	return file_stache_v1_cache_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *BatchGetResponse) GetItems() []*GetResponseItem {
+func (x *InvalidateTagsRequest) GetTags() []string {
 	if x != nil {
-		return x.Items
+		return x.Tags
 	}
 	return nil
 }
 
-type GetResponseItem struct {
+type InvalidateTagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
-	Value         []byte                 `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
-	ContentType   *string                `protobuf:"bytes,3,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
-	ExpiresAtMs   *int64                 `protobuf:"varint,4,opt,name=expires_at_ms,json=expiresAtMs" json:"expires_at_ms,omitempty"`
-	Found         *bool                  `protobuf:"varint,5,opt,name=found" json:"found,omitempty"`
+	Removed       *uint32                `protobuf:"varint,1,opt,name=removed" json:"removed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetResponseItem) Reset() {
-	*x = GetResponseItem{}
+func (x *InvalidateTagsResponse) Reset() {
+	*x = InvalidateTagsResponse{}
 	mi := &file_stache_v1_cache_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetResponseItem) String() string {
+func (x *InvalidateTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResponseItem) ProtoMessage() {}
+func (*InvalidateTagsResponse) ProtoMessage() {}
 
-func (x *GetResponseItem) ProtoReflect() protoreflect.Message {
+func (x *InvalidateTagsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_stache_v1_cache_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -589,139 +670,5595 @@ func (x *GetResponseItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResponseItem.ProtoReflect.Descriptor instead.
-func (*GetResponseItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use InvalidateTagsResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateTagsResponse) Descriptor() ([]byte, []int) {
 	return file_stache_v1_cache_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetResponseItem) GetKey() string {
-	if x != nil && x.Key != nil {
-		return *x.Key
+func (x *InvalidateTagsResponse) GetRemoved() uint32 {
+	if x != nil && x.Removed != nil {
+		return *x.Removed
 	}
-	return ""
+	return 0
 }
 
-func (x *GetResponseItem) GetValue() []byte {
+type HashField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         *string                `protobuf:"bytes,1,opt,name=field" json:"field,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashField) Reset() {
+	*x = HashField{}
+	mi := &file_stache_v1_cache_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashField) ProtoMessage() {}
+
+func (x *HashField) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[12]
 	if x != nil {
-		return x.Value
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetResponseItem) GetContentType() string {
-	if x != nil && x.ContentType != nil {
-		return *x.ContentType
+// Deprecated: Use HashField.ProtoReflect.Descriptor instead.
+func (*HashField) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HashField) GetField() string {
+	if x != nil && x.Field != nil {
+		return *x.Field
 	}
 	return ""
 }
 
-func (x *GetResponseItem) GetExpiresAtMs() int64 {
-	if x != nil && x.ExpiresAtMs != nil {
-		return *x.ExpiresAtMs
+func (x *HashField) GetValue() []byte {
+	if x != nil {
+		return x.Value
 	}
-	return 0
+	return nil
 }
 
-func (x *GetResponseItem) GetFound() bool {
-	if x != nil && x.Found != nil {
-		return *x.Found
-	}
-	return false
+type HSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Field         *string                `protobuf:"bytes,2,opt,name=field" json:"field,omitempty"`
+	Value         []byte                 `protobuf:"bytes,3,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-var File_stache_v1_cache_proto protoreflect.FileDescriptor
+func (x *HSetRequest) Reset() {
+	*x = HSetRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-const file_stache_v1_cache_proto_rawDesc = "" +
-	"\n" +
-	"\x15stache/v1/cache.proto\x12\tstache.v1\"i\n" +
-	"\n" +
-	"SetRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value\x12\x10\n" +
-	"\x03ttl\x18\x03 \x01(\x03R\x03ttl\x12!\n" +
-	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\"\r\n" +
-	"\vSetResponse\"\x1e\n" +
-	"\n" +
-	"GetRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\"j\n" +
-	"\vGetResponse\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\fR\x05value\x12!\n" +
-	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\"\n" +
-	"\rexpires_at_ms\x18\x03 \x01(\x03R\vexpiresAtMs\"!\n" +
-	"\rDeleteRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\"*\n" +
-	"\x0eDeleteResponse\x12\x18\n" +
-	"\adeleted\x18\x01 \x01(\bR\adeleted\"x\n" +
-	"\tEntryInfo\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\rR\x04size\x12!\n" +
-	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\"\n" +
-	"\rexpires_at_ms\x18\x04 \x01(\x03R\vexpiresAtMs\"\x14\n" +
-	"\x12ListEntriesRequest\"E\n" +
-	"\x13ListEntriesResponse\x12.\n" +
-	"\aentries\x18\x01 \x03(\v2\x14.stache.v1.EntryInfoR\aentries\"%\n" +
-	"\x0fBatchGetRequest\x12\x12\n" +
-	"\x04keys\x18\x01 \x03(\tR\x04keys\"D\n" +
-	"\x10BatchGetResponse\x120\n" +
-	"\x05items\x18\x01 \x03(\v2\x1a.stache.v1.GetResponseItemR\x05items\"\x96\x01\n" +
-	"\x0fGetResponseItem\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value\x12!\n" +
-	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\"\n" +
-	"\rexpires_at_ms\x18\x04 \x01(\x03R\vexpiresAtMs\x12\x14\n" +
-	"\x05found\x18\x05 \x01(\bR\x05found2\xcc\x02\n" +
-	"\fCacheService\x124\n" +
-	"\x03Set\x12\x15.stache.v1.SetRequest\x1a\x16.stache.v1.SetResponse\x124\n" +
-	"\x03Get\x12\x15.stache.v1.GetRequest\x1a\x16.stache.v1.GetResponse\x12=\n" +
-	"\x06Delete\x12\x18.stache.v1.DeleteRequest\x1a\x19.stache.v1.DeleteResponse\x12L\n" +
-	"\vListEntries\x12\x1d.stache.v1.ListEntriesRequest\x1a\x1e.stache.v1.ListEntriesResponse\x12C\n" +
-	"\bBatchGet\x12\x1a.stache.v1.BatchGetRequest\x1a\x1b.stache.v1.BatchGetResponseB4Z2github.com/byytelope/stache/api/stache/v1;stachev1b\beditionsp\xe8\a"
+func (x *HSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-var (
-	file_stache_v1_cache_proto_rawDescOnce sync.Once
-	file_stache_v1_cache_proto_rawDescData []byte
-)
+func (*HSetRequest) ProtoMessage() {}
 
-func file_stache_v1_cache_proto_rawDescGZIP() []byte {
-	file_stache_v1_cache_proto_rawDescOnce.Do(func() {
-		file_stache_v1_cache_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stache_v1_cache_proto_rawDesc), len(file_stache_v1_cache_proto_rawDesc)))
-	})
-	return file_stache_v1_cache_proto_rawDescData
+func (x *HSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_stache_v1_cache_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
-var file_stache_v1_cache_proto_goTypes = []any{
-	(*SetRequest)(nil),          // 0: stache.v1.SetRequest
-	(*SetResponse)(nil),         // 1: stache.v1.SetResponse
-	(*GetRequest)(nil),          // 2: stache.v1.GetRequest
-	(*GetResponse)(nil),         // 3: stache.v1.GetResponse
-	(*DeleteRequest)(nil),       // 4: stache.v1.DeleteRequest
-	(*DeleteResponse)(nil),      // 5: stache.v1.DeleteResponse
-	(*EntryInfo)(nil),           // 6: stache.v1.EntryInfo
-	(*ListEntriesRequest)(nil),  // 7: stache.v1.ListEntriesRequest
-	(*ListEntriesResponse)(nil), // 8: stache.v1.ListEntriesResponse
-	(*BatchGetRequest)(nil),     // 9: stache.v1.BatchGetRequest
-	(*BatchGetResponse)(nil),    // 10: stache.v1.BatchGetResponse
-	(*GetResponseItem)(nil),     // 11: stache.v1.GetResponseItem
+// Deprecated: Use HSetRequest.ProtoReflect.Descriptor instead.
+func (*HSetRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{13}
 }
-var file_stache_v1_cache_proto_depIdxs = []int32{
-	6,  // 0: stache.v1.ListEntriesResponse.entries:type_name -> stache.v1.EntryInfo
-	11, // 1: stache.v1.BatchGetResponse.items:type_name -> stache.v1.GetResponseItem
-	0,  // 2: stache.v1.CacheService.Set:input_type -> stache.v1.SetRequest
-	2,  // 3: stache.v1.CacheService.Get:input_type -> stache.v1.GetRequest
-	4,  // 4: stache.v1.CacheService.Delete:input_type -> stache.v1.DeleteRequest
-	7,  // 5: stache.v1.CacheService.ListEntries:input_type -> stache.v1.ListEntriesRequest
-	9,  // 6: stache.v1.CacheService.BatchGet:input_type -> stache.v1.BatchGetRequest
-	1,  // 7: stache.v1.CacheService.Set:output_type -> stache.v1.SetResponse
-	3,  // 8: stache.v1.CacheService.Get:output_type -> stache.v1.GetResponse
-	5,  // 9: stache.v1.CacheService.Delete:output_type -> stache.v1.DeleteResponse
-	8,  // 10: stache.v1.CacheService.ListEntries:output_type -> stache.v1.ListEntriesResponse
-	10, // 11: stache.v1.CacheService.BatchGet:output_type -> stache.v1.BatchGetResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+
+func (x *HSetRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *HSetRequest) GetField() string {
+	if x != nil && x.Field != nil {
+		return *x.Field
+	}
+	return ""
+}
+
+func (x *HSetRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type HSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       *bool                  `protobuf:"varint,1,opt,name=created" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HSetResponse) Reset() {
+	*x = HSetResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HSetResponse) ProtoMessage() {}
+
+func (x *HSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HSetResponse.ProtoReflect.Descriptor instead.
+func (*HSetResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HSetResponse) GetCreated() bool {
+	if x != nil && x.Created != nil {
+		return *x.Created
+	}
+	return false
+}
+
+type HGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Field         *string                `protobuf:"bytes,2,opt,name=field" json:"field,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HGetRequest) Reset() {
+	*x = HGetRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HGetRequest) ProtoMessage() {}
+
+func (x *HGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HGetRequest.ProtoReflect.Descriptor instead.
+func (*HGetRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HGetRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *HGetRequest) GetField() string {
+	if x != nil && x.Field != nil {
+		return *x.Field
+	}
+	return ""
+}
+
+type HGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HGetResponse) Reset() {
+	*x = HGetResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HGetResponse) ProtoMessage() {}
+
+func (x *HGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HGetResponse.ProtoReflect.Descriptor instead.
+func (*HGetResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *HGetResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type HDelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Fields        []string               `protobuf:"bytes,2,rep,name=fields" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HDelRequest) Reset() {
+	*x = HDelRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HDelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HDelRequest) ProtoMessage() {}
+
+func (x *HDelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HDelRequest.ProtoReflect.Descriptor instead.
+func (*HDelRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HDelRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *HDelRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type HDelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       *uint32                `protobuf:"varint,1,opt,name=removed" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HDelResponse) Reset() {
+	*x = HDelResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HDelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HDelResponse) ProtoMessage() {}
+
+func (x *HDelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HDelResponse.ProtoReflect.Descriptor instead.
+func (*HDelResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *HDelResponse) GetRemoved() uint32 {
+	if x != nil && x.Removed != nil {
+		return *x.Removed
+	}
+	return 0
+}
+
+type HGetAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HGetAllRequest) Reset() {
+	*x = HGetAllRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HGetAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HGetAllRequest) ProtoMessage() {}
+
+func (x *HGetAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HGetAllRequest.ProtoReflect.Descriptor instead.
+func (*HGetAllRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *HGetAllRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+type HGetAllResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        []*HashField           `protobuf:"bytes,1,rep,name=fields" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HGetAllResponse) Reset() {
+	*x = HGetAllResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HGetAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HGetAllResponse) ProtoMessage() {}
+
+func (x *HGetAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HGetAllResponse.ProtoReflect.Descriptor instead.
+func (*HGetAllResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *HGetAllResponse) GetFields() []*HashField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type HIncrByRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Field         *string                `protobuf:"bytes,2,opt,name=field" json:"field,omitempty"`
+	Delta         *int64                 `protobuf:"varint,3,opt,name=delta" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HIncrByRequest) Reset() {
+	*x = HIncrByRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HIncrByRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HIncrByRequest) ProtoMessage() {}
+
+func (x *HIncrByRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HIncrByRequest.ProtoReflect.Descriptor instead.
+func (*HIncrByRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *HIncrByRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *HIncrByRequest) GetField() string {
+	if x != nil && x.Field != nil {
+		return *x.Field
+	}
+	return ""
+}
+
+func (x *HIncrByRequest) GetDelta() int64 {
+	if x != nil && x.Delta != nil {
+		return *x.Delta
+	}
+	return 0
+}
+
+type HIncrByResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         *int64                 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HIncrByResponse) Reset() {
+	*x = HIncrByResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HIncrByResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HIncrByResponse) ProtoMessage() {}
+
+func (x *HIncrByResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HIncrByResponse.ProtoReflect.Descriptor instead.
+func (*HIncrByResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HIncrByResponse) GetValue() int64 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+type LPushRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Values        [][]byte               `protobuf:"bytes,2,rep,name=values" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LPushRequest) Reset() {
+	*x = LPushRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LPushRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LPushRequest) ProtoMessage() {}
+
+func (x *LPushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LPushRequest.ProtoReflect.Descriptor instead.
+func (*LPushRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LPushRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *LPushRequest) GetValues() [][]byte {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type LPushResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        *uint32                `protobuf:"varint,1,opt,name=length" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LPushResponse) Reset() {
+	*x = LPushResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LPushResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LPushResponse) ProtoMessage() {}
+
+func (x *LPushResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LPushResponse.ProtoReflect.Descriptor instead.
+func (*LPushResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LPushResponse) GetLength() uint32 {
+	if x != nil && x.Length != nil {
+		return *x.Length
+	}
+	return 0
+}
+
+type RPushRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Values        [][]byte               `protobuf:"bytes,2,rep,name=values" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RPushRequest) Reset() {
+	*x = RPushRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RPushRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPushRequest) ProtoMessage() {}
+
+func (x *RPushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPushRequest.ProtoReflect.Descriptor instead.
+func (*RPushRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RPushRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *RPushRequest) GetValues() [][]byte {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type RPushResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        *uint32                `protobuf:"varint,1,opt,name=length" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RPushResponse) Reset() {
+	*x = RPushResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RPushResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPushResponse) ProtoMessage() {}
+
+func (x *RPushResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPushResponse.ProtoReflect.Descriptor instead.
+func (*RPushResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RPushResponse) GetLength() uint32 {
+	if x != nil && x.Length != nil {
+		return *x.Length
+	}
+	return 0
+}
+
+type LPopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LPopRequest) Reset() {
+	*x = LPopRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LPopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LPopRequest) ProtoMessage() {}
+
+func (x *LPopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LPopRequest.ProtoReflect.Descriptor instead.
+func (*LPopRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LPopRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+type LPopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LPopResponse) Reset() {
+	*x = LPopResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LPopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LPopResponse) ProtoMessage() {}
+
+func (x *LPopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LPopResponse.ProtoReflect.Descriptor instead.
+func (*LPopResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LPopResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type RPopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RPopRequest) Reset() {
+	*x = RPopRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RPopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPopRequest) ProtoMessage() {}
+
+func (x *RPopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPopRequest.ProtoReflect.Descriptor instead.
+func (*RPopRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RPopRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+type RPopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RPopResponse) Reset() {
+	*x = RPopResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RPopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPopResponse) ProtoMessage() {}
+
+func (x *RPopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPopResponse.ProtoReflect.Descriptor instead.
+func (*RPopResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RPopResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type LRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Start         *int64                 `protobuf:"varint,2,opt,name=start" json:"start,omitempty"`
+	Stop          *int64                 `protobuf:"varint,3,opt,name=stop" json:"stop,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LRangeRequest) Reset() {
+	*x = LRangeRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LRangeRequest) ProtoMessage() {}
+
+func (x *LRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LRangeRequest.ProtoReflect.Descriptor instead.
+func (*LRangeRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LRangeRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *LRangeRequest) GetStart() int64 {
+	if x != nil && x.Start != nil {
+		return *x.Start
+	}
+	return 0
+}
+
+func (x *LRangeRequest) GetStop() int64 {
+	if x != nil && x.Stop != nil {
+		return *x.Stop
+	}
+	return 0
+}
+
+type LRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        [][]byte               `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LRangeResponse) Reset() {
+	*x = LRangeResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LRangeResponse) ProtoMessage() {}
+
+func (x *LRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LRangeResponse.ProtoReflect.Descriptor instead.
+func (*LRangeResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LRangeResponse) GetValues() [][]byte {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type LLenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LLenRequest) Reset() {
+	*x = LLenRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LLenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLenRequest) ProtoMessage() {}
+
+func (x *LLenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLenRequest.ProtoReflect.Descriptor instead.
+func (*LLenRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LLenRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+type LLenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        *uint32                `protobuf:"varint,1,opt,name=length" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LLenResponse) Reset() {
+	*x = LLenResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LLenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLenResponse) ProtoMessage() {}
+
+func (x *LLenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLenResponse.ProtoReflect.Descriptor instead.
+func (*LLenResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LLenResponse) GetLength() uint32 {
+	if x != nil && x.Length != nil {
+		return *x.Length
+	}
+	return 0
+}
+
+type BPopRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// How long to wait for a value. If unset, the wait is bounded only by
+	// the call's deadline.
+	Timeout       *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BPopRequest) Reset() {
+	*x = BPopRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BPopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BPopRequest) ProtoMessage() {}
+
+func (x *BPopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BPopRequest.ProtoReflect.Descriptor instead.
+func (*BPopRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BPopRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *BPopRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type BPopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BPopResponse) Reset() {
+	*x = BPopResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BPopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BPopResponse) ProtoMessage() {}
+
+func (x *BPopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BPopResponse.ProtoReflect.Descriptor instead.
+func (*BPopResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BPopResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ZMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *string                `protobuf:"bytes,1,opt,name=member" json:"member,omitempty"`
+	Score         *float64               `protobuf:"fixed64,2,opt,name=score" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZMember) Reset() {
+	*x = ZMember{}
+	mi := &file_stache_v1_cache_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZMember) ProtoMessage() {}
+
+func (x *ZMember) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZMember.ProtoReflect.Descriptor instead.
+func (*ZMember) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ZMember) GetMember() string {
+	if x != nil && x.Member != nil {
+		return *x.Member
+	}
+	return ""
+}
+
+func (x *ZMember) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+type ZAddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Member        *string                `protobuf:"bytes,2,opt,name=member" json:"member,omitempty"`
+	Score         *float64               `protobuf:"fixed64,3,opt,name=score" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZAddRequest) Reset() {
+	*x = ZAddRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZAddRequest) ProtoMessage() {}
+
+func (x *ZAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZAddRequest.ProtoReflect.Descriptor instead.
+func (*ZAddRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ZAddRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *ZAddRequest) GetMember() string {
+	if x != nil && x.Member != nil {
+		return *x.Member
+	}
+	return ""
+}
+
+func (x *ZAddRequest) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+type ZAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         *bool                  `protobuf:"varint,1,opt,name=added" json:"added,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZAddResponse) Reset() {
+	*x = ZAddResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZAddResponse) ProtoMessage() {}
+
+func (x *ZAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZAddResponse.ProtoReflect.Descriptor instead.
+func (*ZAddResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ZAddResponse) GetAdded() bool {
+	if x != nil && x.Added != nil {
+		return *x.Added
+	}
+	return false
+}
+
+type ZRemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZRemRequest) Reset() {
+	*x = ZRemRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZRemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRemRequest) ProtoMessage() {}
+
+func (x *ZRemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRemRequest.ProtoReflect.Descriptor instead.
+func (*ZRemRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ZRemRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *ZRemRequest) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ZRemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       *uint32                `protobuf:"varint,1,opt,name=removed" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZRemResponse) Reset() {
+	*x = ZRemResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZRemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRemResponse) ProtoMessage() {}
+
+func (x *ZRemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRemResponse.ProtoReflect.Descriptor instead.
+func (*ZRemResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ZRemResponse) GetRemoved() uint32 {
+	if x != nil && x.Removed != nil {
+		return *x.Removed
+	}
+	return 0
+}
+
+type ZScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Member        *string                `protobuf:"bytes,2,opt,name=member" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZScoreRequest) Reset() {
+	*x = ZScoreRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZScoreRequest) ProtoMessage() {}
+
+func (x *ZScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZScoreRequest.ProtoReflect.Descriptor instead.
+func (*ZScoreRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ZScoreRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *ZScoreRequest) GetMember() string {
+	if x != nil && x.Member != nil {
+		return *x.Member
+	}
+	return ""
+}
+
+type ZScoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Score         *float64               `protobuf:"fixed64,1,opt,name=score" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZScoreResponse) Reset() {
+	*x = ZScoreResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZScoreResponse) ProtoMessage() {}
+
+func (x *ZScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZScoreResponse.ProtoReflect.Descriptor instead.
+func (*ZScoreResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ZScoreResponse) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+type ZRankRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Member        *string                `protobuf:"bytes,2,opt,name=member" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZRankRequest) Reset() {
+	*x = ZRankRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZRankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRankRequest) ProtoMessage() {}
+
+func (x *ZRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRankRequest.ProtoReflect.Descriptor instead.
+func (*ZRankRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ZRankRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *ZRankRequest) GetMember() string {
+	if x != nil && x.Member != nil {
+		return *x.Member
+	}
+	return ""
+}
+
+type ZRankResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          *uint64                `protobuf:"varint,1,opt,name=rank" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZRankResponse) Reset() {
+	*x = ZRankResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZRankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRankResponse) ProtoMessage() {}
+
+func (x *ZRankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRankResponse.ProtoReflect.Descriptor instead.
+func (*ZRankResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ZRankResponse) GetRank() uint64 {
+	if x != nil && x.Rank != nil {
+		return *x.Rank
+	}
+	return 0
+}
+
+type ZRangeByScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Min           *float64               `protobuf:"fixed64,2,opt,name=min" json:"min,omitempty"`
+	Max           *float64               `protobuf:"fixed64,3,opt,name=max" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZRangeByScoreRequest) Reset() {
+	*x = ZRangeByScoreRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZRangeByScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRangeByScoreRequest) ProtoMessage() {}
+
+func (x *ZRangeByScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRangeByScoreRequest.ProtoReflect.Descriptor instead.
+func (*ZRangeByScoreRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ZRangeByScoreRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *ZRangeByScoreRequest) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *ZRangeByScoreRequest) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+type ZRangeByScoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*ZMember             `protobuf:"bytes,1,rep,name=members" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZRangeByScoreResponse) Reset() {
+	*x = ZRangeByScoreResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZRangeByScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRangeByScoreResponse) ProtoMessage() {}
+
+func (x *ZRangeByScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRangeByScoreResponse.ProtoReflect.Descriptor instead.
+func (*ZRangeByScoreResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ZRangeByScoreResponse) GetMembers() []*ZMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ZRangeByRankRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Start         *int64                 `protobuf:"varint,2,opt,name=start" json:"start,omitempty"`
+	Stop          *int64                 `protobuf:"varint,3,opt,name=stop" json:"stop,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZRangeByRankRequest) Reset() {
+	*x = ZRangeByRankRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZRangeByRankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRangeByRankRequest) ProtoMessage() {}
+
+func (x *ZRangeByRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRangeByRankRequest.ProtoReflect.Descriptor instead.
+func (*ZRangeByRankRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ZRangeByRankRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *ZRangeByRankRequest) GetStart() int64 {
+	if x != nil && x.Start != nil {
+		return *x.Start
+	}
+	return 0
+}
+
+func (x *ZRangeByRankRequest) GetStop() int64 {
+	if x != nil && x.Stop != nil {
+		return *x.Stop
+	}
+	return 0
+}
+
+type ZRangeByRankResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*ZMember             `protobuf:"bytes,1,rep,name=members" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZRangeByRankResponse) Reset() {
+	*x = ZRangeByRankResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZRangeByRankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRangeByRankResponse) ProtoMessage() {}
+
+func (x *ZRangeByRankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRangeByRankResponse.ProtoReflect.Descriptor instead.
+func (*ZRangeByRankResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ZRangeByRankResponse) GetMembers() []*ZMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type SAddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SAddRequest) Reset() {
+	*x = SAddRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAddRequest) ProtoMessage() {}
+
+func (x *SAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAddRequest.ProtoReflect.Descriptor instead.
+func (*SAddRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SAddRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *SAddRequest) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type SAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         *uint32                `protobuf:"varint,1,opt,name=added" json:"added,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SAddResponse) Reset() {
+	*x = SAddResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAddResponse) ProtoMessage() {}
+
+func (x *SAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAddResponse.ProtoReflect.Descriptor instead.
+func (*SAddResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SAddResponse) GetAdded() uint32 {
+	if x != nil && x.Added != nil {
+		return *x.Added
+	}
+	return 0
+}
+
+type SRemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SRemRequest) Reset() {
+	*x = SRemRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SRemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SRemRequest) ProtoMessage() {}
+
+func (x *SRemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SRemRequest.ProtoReflect.Descriptor instead.
+func (*SRemRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SRemRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *SRemRequest) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type SRemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       *uint32                `protobuf:"varint,1,opt,name=removed" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SRemResponse) Reset() {
+	*x = SRemResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SRemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SRemResponse) ProtoMessage() {}
+
+func (x *SRemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SRemResponse.ProtoReflect.Descriptor instead.
+func (*SRemResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SRemResponse) GetRemoved() uint32 {
+	if x != nil && x.Removed != nil {
+		return *x.Removed
+	}
+	return 0
+}
+
+type SIsMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Member        *string                `protobuf:"bytes,2,opt,name=member" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SIsMemberRequest) Reset() {
+	*x = SIsMemberRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SIsMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SIsMemberRequest) ProtoMessage() {}
+
+func (x *SIsMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SIsMemberRequest.ProtoReflect.Descriptor instead.
+func (*SIsMemberRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SIsMemberRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *SIsMemberRequest) GetMember() string {
+	if x != nil && x.Member != nil {
+		return *x.Member
+	}
+	return ""
+}
+
+type SIsMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsMember      *bool                  `protobuf:"varint,1,opt,name=is_member,json=isMember" json:"is_member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SIsMemberResponse) Reset() {
+	*x = SIsMemberResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SIsMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SIsMemberResponse) ProtoMessage() {}
+
+func (x *SIsMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SIsMemberResponse.ProtoReflect.Descriptor instead.
+func (*SIsMemberResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SIsMemberResponse) GetIsMember() bool {
+	if x != nil && x.IsMember != nil {
+		return *x.IsMember
+	}
+	return false
+}
+
+type SMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SMembersRequest) Reset() {
+	*x = SMembersRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMembersRequest) ProtoMessage() {}
+
+func (x *SMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMembersRequest.ProtoReflect.Descriptor instead.
+func (*SMembersRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SMembersRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+type SMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []string               `protobuf:"bytes,1,rep,name=members" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SMembersResponse) Reset() {
+	*x = SMembersResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMembersResponse) ProtoMessage() {}
+
+func (x *SMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMembersResponse.ProtoReflect.Descriptor instead.
+func (*SMembersResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SMembersResponse) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type SCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCardRequest) Reset() {
+	*x = SCardRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCardRequest) ProtoMessage() {}
+
+func (x *SCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCardRequest.ProtoReflect.Descriptor instead.
+func (*SCardRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SCardRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+type SCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         *uint32                `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCardResponse) Reset() {
+	*x = SCardResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCardResponse) ProtoMessage() {}
+
+func (x *SCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCardResponse.ProtoReflect.Descriptor instead.
+func (*SCardResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SCardResponse) GetCount() uint32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+// Request for SUnion, SInter and SDiff. Missing keys count as empty sets.
+type SetAlgebraRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Keys  []string               `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+	// If set, the result is stored as a set under this key instead of
+	// being returned; an empty result removes it.
+	Destination   *string `protobuf:"bytes,2,opt,name=destination" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAlgebraRequest) Reset() {
+	*x = SetAlgebraRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAlgebraRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAlgebraRequest) ProtoMessage() {}
+
+func (x *SetAlgebraRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAlgebraRequest.ProtoReflect.Descriptor instead.
+func (*SetAlgebraRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SetAlgebraRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *SetAlgebraRequest) GetDestination() string {
+	if x != nil && x.Destination != nil {
+		return *x.Destination
+	}
+	return ""
+}
+
+type SetAlgebraResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []string               `protobuf:"bytes,1,rep,name=members" json:"members,omitempty"`
+	Count         *uint32                `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAlgebraResponse) Reset() {
+	*x = SetAlgebraResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAlgebraResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAlgebraResponse) ProtoMessage() {}
+
+func (x *SetAlgebraResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAlgebraResponse.ProtoReflect.Descriptor instead.
+func (*SetAlgebraResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SetAlgebraResponse) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *SetAlgebraResponse) GetCount() uint32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+type TxnSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	ContentType   *string                `protobuf:"bytes,2,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl" json:"ttl,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	Sliding       *bool                  `protobuf:"varint,5,opt,name=sliding" json:"sliding,omitempty"`
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnSet) Reset() {
+	*x = TxnSet{}
+	mi := &file_stache_v1_cache_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnSet) ProtoMessage() {}
+
+func (x *TxnSet) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnSet.ProtoReflect.Descriptor instead.
+func (*TxnSet) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TxnSet) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TxnSet) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
+}
+
+func (x *TxnSet) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *TxnSet) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *TxnSet) GetSliding() bool {
+	if x != nil && x.Sliding != nil {
+		return *x.Sliding
+	}
+	return false
+}
+
+func (x *TxnSet) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type TxnDelete struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnDelete) Reset() {
+	*x = TxnDelete{}
+	mi := &file_stache_v1_cache_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnDelete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnDelete) ProtoMessage() {}
+
+func (x *TxnDelete) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnDelete.ProtoReflect.Descriptor instead.
+func (*TxnDelete) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{63}
+}
+
+// A guarded operation on one key. If no action is set, the op is a pure
+// precondition.
+type TxnOp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// If set, the transaction only commits if the key is at this version;
+	// 0 requires the key not to exist.
+	ExpectedVersion *uint64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion" json:"expected_version,omitempty"`
+	// Types that are valid to be assigned to Action:
+	//
+	//	*TxnOp_Set
+	//	*TxnOp_Delete
+	Action        isTxnOp_Action `protobuf_oneof:"action"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnOp) Reset() {
+	*x = TxnOp{}
+	mi := &file_stache_v1_cache_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnOp) ProtoMessage() {}
+
+func (x *TxnOp) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnOp.ProtoReflect.Descriptor instead.
+func (*TxnOp) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *TxnOp) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *TxnOp) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *TxnOp) GetAction() isTxnOp_Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *TxnOp) GetSet() *TxnSet {
+	if x != nil {
+		if x, ok := x.Action.(*TxnOp_Set); ok {
+			return x.Set
+		}
+	}
+	return nil
+}
+
+func (x *TxnOp) GetDelete() *TxnDelete {
+	if x != nil {
+		if x, ok := x.Action.(*TxnOp_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+type isTxnOp_Action interface {
+	isTxnOp_Action()
+}
+
+type TxnOp_Set struct {
+	Set *TxnSet `protobuf:"bytes,3,opt,name=set,oneof"`
+}
+
+type TxnOp_Delete struct {
+	Delete *TxnDelete `protobuf:"bytes,4,opt,name=delete,oneof"`
+}
+
+func (*TxnOp_Set) isTxnOp_Action() {}
+
+func (*TxnOp_Delete) isTxnOp_Action() {}
+
+type TxnOpResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// Whether the op's precondition held.
+	Ok *bool `protobuf:"varint,2,opt,name=ok" json:"ok,omitempty"`
+	// Version of the key after the transaction, 0 if it does not exist.
+	Version       *uint64 `protobuf:"varint,3,opt,name=version" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnOpResult) Reset() {
+	*x = TxnOpResult{}
+	mi := &file_stache_v1_cache_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnOpResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnOpResult) ProtoMessage() {}
+
+func (x *TxnOpResult) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnOpResult.ProtoReflect.Descriptor instead.
+func (*TxnOpResult) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *TxnOpResult) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *TxnOpResult) GetOk() bool {
+	if x != nil && x.Ok != nil {
+		return *x.Ok
+	}
+	return false
+}
+
+func (x *TxnOpResult) GetVersion() uint64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type TransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ops           []*TxnOp               `protobuf:"bytes,1,rep,name=ops" json:"ops,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionRequest) Reset() {
+	*x = TransactionRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRequest) ProtoMessage() {}
+
+func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRequest.ProtoReflect.Descriptor instead.
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *TransactionRequest) GetOps() []*TxnOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+type TransactionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// All ops are applied if every precondition holds, otherwise none are.
+	Committed     *bool          `protobuf:"varint,1,opt,name=committed" json:"committed,omitempty"`
+	Results       []*TxnOpResult `protobuf:"bytes,2,rep,name=results" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionResponse) Reset() {
+	*x = TransactionResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResponse) ProtoMessage() {}
+
+func (x *TransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResponse.ProtoReflect.Descriptor instead.
+func (*TransactionResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *TransactionResponse) GetCommitted() bool {
+	if x != nil && x.Committed != nil {
+		return *x.Committed
+	}
+	return false
+}
+
+func (x *TransactionResponse) GetResults() []*TxnOpResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type EntryInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Size          *uint32                `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	ContentType   *string                `protobuf:"bytes,3,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	ExpiresAtMs   *int64                 `protobuf:"varint,4,opt,name=expires_at_ms,json=expiresAtMs" json:"expires_at_ms,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags" json:"tags,omitempty"`
+	Version       *uint64                `protobuf:"varint,6,opt,name=version" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryInfo) Reset() {
+	*x = EntryInfo{}
+	mi := &file_stache_v1_cache_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryInfo) ProtoMessage() {}
+
+func (x *EntryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryInfo.ProtoReflect.Descriptor instead.
+func (*EntryInfo) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *EntryInfo) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *EntryInfo) GetSize() uint32 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *EntryInfo) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
+}
+
+func (x *EntryInfo) GetExpiresAtMs() int64 {
+	if x != nil && x.ExpiresAtMs != nil {
+		return *x.ExpiresAtMs
+	}
+	return 0
+}
+
+func (x *EntryInfo) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *EntryInfo) GetVersion() uint64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type ListEntriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only entries whose key contains search are listed.
+	Search *string `protobuf:"bytes,1,opt,name=search" json:"search,omitempty"`
+	// Entries are sorted by key when paging; 0 means no limit.
+	Limit         *uint32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	Offset        *uint32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntriesRequest) Reset() {
+	*x = ListEntriesRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntriesRequest) ProtoMessage() {}
+
+func (x *ListEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListEntriesRequest) GetSearch() string {
+	if x != nil && x.Search != nil {
+		return *x.Search
+	}
+	return ""
+}
+
+func (x *ListEntriesRequest) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListEntriesRequest) GetOffset() uint32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+type ListEntriesResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*EntryInfo           `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	// Number of matching entries before paging.
+	Total         *uint32 `protobuf:"varint,2,opt,name=total" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntriesResponse) Reset() {
+	*x = ListEntriesResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntriesResponse) ProtoMessage() {}
+
+func (x *ListEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListEntriesResponse) GetEntries() []*EntryInfo {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListEntriesResponse) GetTotal() uint32 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+type BatchGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetRequest) Reset() {
+	*x = BatchGetRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetRequest) ProtoMessage() {}
+
+func (x *BatchGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *BatchGetRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type BatchGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*GetResponseItem     `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetResponse) Reset() {
+	*x = BatchGetResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetResponse) ProtoMessage() {}
+
+func (x *BatchGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *BatchGetResponse) GetItems() []*GetResponseItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetResponseItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	ContentType   *string                `protobuf:"bytes,3,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	ExpiresAtMs   *int64                 `protobuf:"varint,4,opt,name=expires_at_ms,json=expiresAtMs" json:"expires_at_ms,omitempty"`
+	Found         *bool                  `protobuf:"varint,5,opt,name=found" json:"found,omitempty"`
+	Version       *uint64                `protobuf:"varint,6,opt,name=version" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponseItem) Reset() {
+	*x = GetResponseItem{}
+	mi := &file_stache_v1_cache_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponseItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponseItem) ProtoMessage() {}
+
+func (x *GetResponseItem) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponseItem.ProtoReflect.Descriptor instead.
+func (*GetResponseItem) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetResponseItem) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *GetResponseItem) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *GetResponseItem) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
+}
+
+func (x *GetResponseItem) GetExpiresAtMs() int64 {
+	if x != nil && x.ExpiresAtMs != nil {
+		return *x.ExpiresAtMs
+	}
+	return 0
+}
+
+func (x *GetResponseItem) GetFound() bool {
+	if x != nil && x.Found != nil {
+		return *x.Found
+	}
+	return false
+}
+
+func (x *GetResponseItem) GetVersion() uint64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type BatchSetItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	ContentType   *string                `protobuf:"bytes,3,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,4,opt,name=ttl" json:"ttl,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	Sliding       *bool                  `protobuf:"varint,6,opt,name=sliding" json:"sliding,omitempty"`
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSetItem) Reset() {
+	*x = BatchSetItem{}
+	mi := &file_stache_v1_cache_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSetItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetItem) ProtoMessage() {}
+
+func (x *BatchSetItem) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetItem.ProtoReflect.Descriptor instead.
+func (*BatchSetItem) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *BatchSetItem) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *BatchSetItem) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *BatchSetItem) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
+}
+
+func (x *BatchSetItem) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *BatchSetItem) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *BatchSetItem) GetSliding() bool {
+	if x != nil && x.Sliding != nil {
+		return *x.Sliding
+	}
+	return false
+}
+
+func (x *BatchSetItem) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type BatchSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*BatchSetItem        `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSetRequest) Reset() {
+	*x = BatchSetRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetRequest) ProtoMessage() {}
+
+func (x *BatchSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetRequest.ProtoReflect.Descriptor instead.
+func (*BatchSetRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *BatchSetRequest) GetItems() []*BatchSetItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type BatchSetResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Ok    *bool                  `protobuf:"varint,2,opt,name=ok" json:"ok,omitempty"`
+	// Why the item was rejected, if not ok.
+	Error         *string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSetResult) Reset() {
+	*x = BatchSetResult{}
+	mi := &file_stache_v1_cache_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetResult) ProtoMessage() {}
+
+func (x *BatchSetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetResult.ProtoReflect.Descriptor instead.
+func (*BatchSetResult) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *BatchSetResult) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *BatchSetResult) GetOk() bool {
+	if x != nil && x.Ok != nil {
+		return *x.Ok
+	}
+	return false
+}
+
+func (x *BatchSetResult) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type BatchSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchSetResult      `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSetResponse) Reset() {
+	*x = BatchSetResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetResponse) ProtoMessage() {}
+
+func (x *BatchSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetResponse.ProtoReflect.Descriptor instead.
+func (*BatchSetResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *BatchSetResponse) GetResults() []*BatchSetResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteRequest) Reset() {
+	*x = BatchDeleteRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteRequest) ProtoMessage() {}
+
+func (x *BatchDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *BatchDeleteRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type BatchDeleteResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Deleted       *bool                  `protobuf:"varint,2,opt,name=deleted" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteResult) Reset() {
+	*x = BatchDeleteResult{}
+	mi := &file_stache_v1_cache_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteResult) ProtoMessage() {}
+
+func (x *BatchDeleteResult) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteResult.ProtoReflect.Descriptor instead.
+func (*BatchDeleteResult) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *BatchDeleteResult) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *BatchDeleteResult) GetDeleted() bool {
+	if x != nil && x.Deleted != nil {
+		return *x.Deleted
+	}
+	return false
+}
+
+type BatchDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchDeleteResult   `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteResponse) Reset() {
+	*x = BatchDeleteResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteResponse) ProtoMessage() {}
+
+func (x *BatchDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteResponse.ProtoReflect.Descriptor instead.
+func (*BatchDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *BatchDeleteResponse) GetResults() []*BatchDeleteResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// A complete entry as streamed by Export and accepted by Import.
+type EntryRecord struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Key         *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value       []byte                 `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	ContentType *string                `protobuf:"bytes,3,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	// Absolute expiry; unset if the entry never expires.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	// Sliding window; unset unless the entry slides.
+	Sliding       *durationpb.Duration `protobuf:"bytes,5,opt,name=sliding" json:"sliding,omitempty"`
+	Tags          []string             `protobuf:"bytes,6,rep,name=tags" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryRecord) Reset() {
+	*x = EntryRecord{}
+	mi := &file_stache_v1_cache_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryRecord) ProtoMessage() {}
+
+func (x *EntryRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryRecord.ProtoReflect.Descriptor instead.
+func (*EntryRecord) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *EntryRecord) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *EntryRecord) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *EntryRecord) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
+}
+
+func (x *EntryRecord) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *EntryRecord) GetSliding() *durationpb.Duration {
+	if x != nil {
+		return x.Sliding
+	}
+	return nil
+}
+
+func (x *EntryRecord) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ExportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only entries whose key starts with prefix are exported.
+	Prefix        *string `protobuf:"bytes,1,opt,name=prefix" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ExportRequest) GetPrefix() string {
+	if x != nil && x.Prefix != nil {
+		return *x.Prefix
+	}
+	return ""
+}
+
+type ImportResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Imported *uint64                `protobuf:"varint,1,opt,name=imported" json:"imported,omitempty"`
+	// Records skipped because they had already expired or were invalid.
+	Skipped       *uint64 `protobuf:"varint,2,opt,name=skipped" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportResponse) Reset() {
+	*x = ImportResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResponse) ProtoMessage() {}
+
+func (x *ImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResponse.ProtoReflect.Descriptor instead.
+func (*ImportResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ImportResponse) GetImported() uint64 {
+	if x != nil && x.Imported != nil {
+		return *x.Imported
+	}
+	return 0
+}
+
+func (x *ImportResponse) GetSkipped() uint64 {
+	if x != nil && x.Skipped != nil {
+		return *x.Skipped
+	}
+	return 0
+}
+
+type StatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{84}
+}
+
+type StatsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries *uint64                `protobuf:"varint,1,opt,name=entries" json:"entries,omitempty"`
+	Bytes   *uint64                `protobuf:"varint,2,opt,name=bytes" json:"bytes,omitempty"`
+	// Value reads that found a live entry, and those that did not.
+	Hits          *uint64              `protobuf:"varint,3,opt,name=hits" json:"hits,omitempty"`
+	Misses        *uint64              `protobuf:"varint,4,opt,name=misses" json:"misses,omitempty"`
+	Uptime        *durationpb.Duration `protobuf:"bytes,5,opt,name=uptime" json:"uptime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *StatsResponse) GetEntries() uint64 {
+	if x != nil && x.Entries != nil {
+		return *x.Entries
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetBytes() uint64 {
+	if x != nil && x.Bytes != nil {
+		return *x.Bytes
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetHits() uint64 {
+	if x != nil && x.Hits != nil {
+		return *x.Hits
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetMisses() uint64 {
+	if x != nil && x.Misses != nil {
+		return *x.Misses
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+type ClusterInfoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If set, the response names the node that owns this key.
+	Key           *string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterInfoRequest) Reset() {
+	*x = ClusterInfoRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterInfoRequest) ProtoMessage() {}
+
+func (x *ClusterInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterInfoRequest.ProtoReflect.Descriptor instead.
+func (*ClusterInfoRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ClusterInfoRequest) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+type ClusterNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base URL the node is reached at.
+	Address *string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Self    *bool   `protobuf:"varint,2,opt,name=self" json:"self,omitempty"`
+	// Fraction of the hash ring the node owns.
+	Share         *float64 `protobuf:"fixed64,3,opt,name=share" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterNode) Reset() {
+	*x = ClusterNode{}
+	mi := &file_stache_v1_cache_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterNode) ProtoMessage() {}
+
+func (x *ClusterNode) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterNode.ProtoReflect.Descriptor instead.
+func (*ClusterNode) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ClusterNode) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *ClusterNode) GetSelf() bool {
+	if x != nil && x.Self != nil {
+		return *x.Self
+	}
+	return false
+}
+
+func (x *ClusterNode) GetShare() float64 {
+	if x != nil && x.Share != nil {
+		return *x.Share
+	}
+	return 0
+}
+
+type ClusterInfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False for a standalone node, which owns every key.
+	Enabled       *bool          `protobuf:"varint,1,opt,name=enabled" json:"enabled,omitempty"`
+	Self          *string        `protobuf:"bytes,2,opt,name=self" json:"self,omitempty"`
+	Nodes         []*ClusterNode `protobuf:"bytes,3,rep,name=nodes" json:"nodes,omitempty"`
+	Vnodes        *uint32        `protobuf:"varint,4,opt,name=vnodes" json:"vnodes,omitempty"`
+	Owner         *string        `protobuf:"bytes,5,opt,name=owner" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterInfoResponse) Reset() {
+	*x = ClusterInfoResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterInfoResponse) ProtoMessage() {}
+
+func (x *ClusterInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterInfoResponse.ProtoReflect.Descriptor instead.
+func (*ClusterInfoResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ClusterInfoResponse) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *ClusterInfoResponse) GetSelf() string {
+	if x != nil && x.Self != nil {
+		return *x.Self
+	}
+	return ""
+}
+
+func (x *ClusterInfoResponse) GetNodes() []*ClusterNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *ClusterInfoResponse) GetVnodes() uint32 {
+	if x != nil && x.Vnodes != nil {
+		return *x.Vnodes
+	}
+	return 0
+}
+
+func (x *ClusterInfoResponse) GetOwner() string {
+	if x != nil && x.Owner != nil {
+		return *x.Owner
+	}
+	return ""
+}
+
+type ReplicateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicateRequest) Reset() {
+	*x = ReplicateRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicateRequest) ProtoMessage() {}
+
+func (x *ReplicateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicateRequest.ProtoReflect.Descriptor instead.
+func (*ReplicateRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{89}
+}
+
+type ReplicationExpire struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// Unset for an entry that no longer expires.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	Sliding       *durationpb.Duration   `protobuf:"bytes,3,opt,name=sliding" json:"sliding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationExpire) Reset() {
+	*x = ReplicationExpire{}
+	mi := &file_stache_v1_cache_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationExpire) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationExpire) ProtoMessage() {}
+
+func (x *ReplicationExpire) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationExpire.ProtoReflect.Descriptor instead.
+func (*ReplicationExpire) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ReplicationExpire) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *ReplicationExpire) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ReplicationExpire) GetSliding() *durationpb.Duration {
+	if x != nil {
+		return x.Sliding
+	}
+	return nil
+}
+
+type ReplicationSnapshotEnd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       *uint64                `protobuf:"varint,1,opt,name=entries" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationSnapshotEnd) Reset() {
+	*x = ReplicationSnapshotEnd{}
+	mi := &file_stache_v1_cache_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationSnapshotEnd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationSnapshotEnd) ProtoMessage() {}
+
+func (x *ReplicationSnapshotEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationSnapshotEnd.ProtoReflect.Descriptor instead.
+func (*ReplicationSnapshotEnd) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *ReplicationSnapshotEnd) GetEntries() uint64 {
+	if x != nil && x.Entries != nil {
+		return *x.Entries
+	}
+	return 0
+}
+
+type ReplicationHeartbeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationHeartbeat) Reset() {
+	*x = ReplicationHeartbeat{}
+	mi := &file_stache_v1_cache_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationHeartbeat) ProtoMessage() {}
+
+func (x *ReplicationHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationHeartbeat.ProtoReflect.Descriptor instead.
+func (*ReplicationHeartbeat) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{92}
+}
+
+// ReplicationEvent is one message of the Replicate stream: first a `set` per
+// entry of the snapshot, then `snapshot_end`, then every change as it
+// happens, with heartbeats while idle.
+type ReplicationEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the change in the primary's change stream; 0 for
+	// snapshot entries.
+	Sequence *uint64 `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+	// When the primary made the change, or sent the heartbeat.
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time" json:"time,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*ReplicationEvent_Set
+	//	*ReplicationEvent_Delete
+	//	*ReplicationEvent_Expire
+	//	*ReplicationEvent_Clear
+	//	*ReplicationEvent_SnapshotEnd
+	//	*ReplicationEvent_Heartbeat
+	Event         isReplicationEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationEvent) Reset() {
+	*x = ReplicationEvent{}
+	mi := &file_stache_v1_cache_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationEvent) ProtoMessage() {}
+
+func (x *ReplicationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationEvent.ProtoReflect.Descriptor instead.
+func (*ReplicationEvent) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ReplicationEvent) GetSequence() uint64 {
+	if x != nil && x.Sequence != nil {
+		return *x.Sequence
+	}
+	return 0
+}
+
+func (x *ReplicationEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ReplicationEvent) GetEvent() isReplicationEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ReplicationEvent) GetSet() *EntryRecord {
+	if x != nil {
+		if x, ok := x.Event.(*ReplicationEvent_Set); ok {
+			return x.Set
+		}
+	}
+	return nil
+}
+
+func (x *ReplicationEvent) GetDelete() string {
+	if x != nil {
+		if x, ok := x.Event.(*ReplicationEvent_Delete); ok {
+			return x.Delete
+		}
+	}
+	return ""
+}
+
+func (x *ReplicationEvent) GetExpire() *ReplicationExpire {
+	if x != nil {
+		if x, ok := x.Event.(*ReplicationEvent_Expire); ok {
+			return x.Expire
+		}
+	}
+	return nil
+}
+
+func (x *ReplicationEvent) GetClear() bool {
+	if x != nil {
+		if x, ok := x.Event.(*ReplicationEvent_Clear); ok {
+			return x.Clear
+		}
+	}
+	return false
+}
+
+func (x *ReplicationEvent) GetSnapshotEnd() *ReplicationSnapshotEnd {
+	if x != nil {
+		if x, ok := x.Event.(*ReplicationEvent_SnapshotEnd); ok {
+			return x.SnapshotEnd
+		}
+	}
+	return nil
+}
+
+func (x *ReplicationEvent) GetHeartbeat() *ReplicationHeartbeat {
+	if x != nil {
+		if x, ok := x.Event.(*ReplicationEvent_Heartbeat); ok {
+			return x.Heartbeat
+		}
+	}
+	return nil
+}
+
+type isReplicationEvent_Event interface {
+	isReplicationEvent_Event()
+}
+
+type ReplicationEvent_Set struct {
+	Set *EntryRecord `protobuf:"bytes,3,opt,name=set,oneof"`
+}
+
+type ReplicationEvent_Delete struct {
+	Delete string `protobuf:"bytes,4,opt,name=delete,oneof"`
+}
+
+type ReplicationEvent_Expire struct {
+	Expire *ReplicationExpire `protobuf:"bytes,5,opt,name=expire,oneof"`
+}
+
+type ReplicationEvent_Clear struct {
+	Clear bool `protobuf:"varint,6,opt,name=clear,oneof"`
+}
+
+type ReplicationEvent_SnapshotEnd struct {
+	SnapshotEnd *ReplicationSnapshotEnd `protobuf:"bytes,7,opt,name=snapshot_end,json=snapshotEnd,oneof"`
+}
+
+type ReplicationEvent_Heartbeat struct {
+	Heartbeat *ReplicationHeartbeat `protobuf:"bytes,8,opt,name=heartbeat,oneof"`
+}
+
+func (*ReplicationEvent_Set) isReplicationEvent_Event() {}
+
+func (*ReplicationEvent_Delete) isReplicationEvent_Event() {}
+
+func (*ReplicationEvent_Expire) isReplicationEvent_Event() {}
+
+func (*ReplicationEvent_Clear) isReplicationEvent_Event() {}
+
+func (*ReplicationEvent_SnapshotEnd) isReplicationEvent_Event() {}
+
+func (*ReplicationEvent_Heartbeat) isReplicationEvent_Event() {}
+
+type ReplicationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationStatusRequest) Reset() {
+	*x = ReplicationStatusRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationStatusRequest) ProtoMessage() {}
+
+func (x *ReplicationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationStatusRequest.ProtoReflect.Descriptor instead.
+func (*ReplicationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{94}
+}
+
+type ReplicationStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "primary" or "replica".
+	Role *string `protobuf:"bytes,1,opt,name=role" json:"role,omitempty"`
+	// Replica only: the primary's URL, whether the stream is up and the
+	// initial sync is done.
+	Primary         *string `protobuf:"bytes,2,opt,name=primary" json:"primary,omitempty"`
+	Connected       *bool   `protobuf:"varint,3,opt,name=connected" json:"connected,omitempty"`
+	Synced          *bool   `protobuf:"varint,4,opt,name=synced" json:"synced,omitempty"`
+	AppliedSequence *uint64 `protobuf:"varint,5,opt,name=applied_sequence,json=appliedSequence" json:"applied_sequence,omitempty"`
+	// Replica only: how far behind the primary the applied changes are.
+	Lag *durationpb.Duration `protobuf:"bytes,6,opt,name=lag" json:"lag,omitempty"`
+	// Primary only: replicas currently streaming.
+	Replicas      *uint32 `protobuf:"varint,7,opt,name=replicas" json:"replicas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationStatusResponse) Reset() {
+	*x = ReplicationStatusResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationStatusResponse) ProtoMessage() {}
+
+func (x *ReplicationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationStatusResponse.ProtoReflect.Descriptor instead.
+func (*ReplicationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ReplicationStatusResponse) GetRole() string {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return ""
+}
+
+func (x *ReplicationStatusResponse) GetPrimary() string {
+	if x != nil && x.Primary != nil {
+		return *x.Primary
+	}
+	return ""
+}
+
+func (x *ReplicationStatusResponse) GetConnected() bool {
+	if x != nil && x.Connected != nil {
+		return *x.Connected
+	}
+	return false
+}
+
+func (x *ReplicationStatusResponse) GetSynced() bool {
+	if x != nil && x.Synced != nil {
+		return *x.Synced
+	}
+	return false
+}
+
+func (x *ReplicationStatusResponse) GetAppliedSequence() uint64 {
+	if x != nil && x.AppliedSequence != nil {
+		return *x.AppliedSequence
+	}
+	return 0
+}
+
+func (x *ReplicationStatusResponse) GetLag() *durationpb.Duration {
+	if x != nil {
+		return x.Lag
+	}
+	return nil
+}
+
+func (x *ReplicationStatusResponse) GetReplicas() uint32 {
+	if x != nil && x.Replicas != nil {
+		return *x.Replicas
+	}
+	return 0
+}
+
+type RaftStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RaftStatusRequest) Reset() {
+	*x = RaftStatusRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RaftStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftStatusRequest) ProtoMessage() {}
+
+func (x *RaftStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftStatusRequest.ProtoReflect.Descriptor instead.
+func (*RaftStatusRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{96}
+}
+
+type RaftStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False unless stached runs with -raft-peers; the other fields are unset.
+	Enabled *bool   `protobuf:"varint,1,opt,name=enabled" json:"enabled,omitempty"`
+	Id      *string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	// "follower", "candidate" or "leader".
+	State         *string  `protobuf:"bytes,3,opt,name=state" json:"state,omitempty"`
+	Term          *uint64  `protobuf:"varint,4,opt,name=term" json:"term,omitempty"`
+	Leader        *string  `protobuf:"bytes,5,opt,name=leader" json:"leader,omitempty"`
+	Peers         []string `protobuf:"bytes,6,rep,name=peers" json:"peers,omitempty"`
+	LastIndex     *uint64  `protobuf:"varint,7,opt,name=last_index,json=lastIndex" json:"last_index,omitempty"`
+	CommitIndex   *uint64  `protobuf:"varint,8,opt,name=commit_index,json=commitIndex" json:"commit_index,omitempty"`
+	AppliedIndex  *uint64  `protobuf:"varint,9,opt,name=applied_index,json=appliedIndex" json:"applied_index,omitempty"`
+	SnapshotIndex *uint64  `protobuf:"varint,10,opt,name=snapshot_index,json=snapshotIndex" json:"snapshot_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RaftStatusResponse) Reset() {
+	*x = RaftStatusResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RaftStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftStatusResponse) ProtoMessage() {}
+
+func (x *RaftStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftStatusResponse.ProtoReflect.Descriptor instead.
+func (*RaftStatusResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *RaftStatusResponse) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *RaftStatusResponse) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *RaftStatusResponse) GetState() string {
+	if x != nil && x.State != nil {
+		return *x.State
+	}
+	return ""
+}
+
+func (x *RaftStatusResponse) GetTerm() uint64 {
+	if x != nil && x.Term != nil {
+		return *x.Term
+	}
+	return 0
+}
+
+func (x *RaftStatusResponse) GetLeader() string {
+	if x != nil && x.Leader != nil {
+		return *x.Leader
+	}
+	return ""
+}
+
+func (x *RaftStatusResponse) GetPeers() []string {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *RaftStatusResponse) GetLastIndex() uint64 {
+	if x != nil && x.LastIndex != nil {
+		return *x.LastIndex
+	}
+	return 0
+}
+
+func (x *RaftStatusResponse) GetCommitIndex() uint64 {
+	if x != nil && x.CommitIndex != nil {
+		return *x.CommitIndex
+	}
+	return 0
+}
+
+func (x *RaftStatusResponse) GetAppliedIndex() uint64 {
+	if x != nil && x.AppliedIndex != nil {
+		return *x.AppliedIndex
+	}
+	return 0
+}
+
+func (x *RaftStatusResponse) GetSnapshotIndex() uint64 {
+	if x != nil && x.SnapshotIndex != nil {
+		return *x.SnapshotIndex
+	}
+	return 0
+}
+
+type MembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembersRequest) Reset() {
+	*x = MembersRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembersRequest) ProtoMessage() {}
+
+func (x *MembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembersRequest.ProtoReflect.Descriptor instead.
+func (*MembersRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{98}
+}
+
+type Member struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base URL the member is reached at.
+	Address *string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	// "alive", "suspect", "dead" or "left".
+	State         *string `protobuf:"bytes,2,opt,name=state" json:"state,omitempty"`
+	Incarnation   *uint64 `protobuf:"varint,3,opt,name=incarnation" json:"incarnation,omitempty"`
+	Self          *bool   `protobuf:"varint,4,opt,name=self" json:"self,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_stache_v1_cache_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *Member) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *Member) GetState() string {
+	if x != nil && x.State != nil {
+		return *x.State
+	}
+	return ""
+}
+
+func (x *Member) GetIncarnation() uint64 {
+	if x != nil && x.Incarnation != nil {
+		return *x.Incarnation
+	}
+	return 0
+}
+
+func (x *Member) GetSelf() bool {
+	if x != nil && x.Self != nil {
+		return *x.Self
+	}
+	return false
+}
+
+type MembersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False unless stached runs with -seeds; the other fields are unset.
+	Enabled       *bool     `protobuf:"varint,1,opt,name=enabled" json:"enabled,omitempty"`
+	Self          *string   `protobuf:"bytes,2,opt,name=self" json:"self,omitempty"`
+	Members       []*Member `protobuf:"bytes,3,rep,name=members" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembersResponse) Reset() {
+	*x = MembersResponse{}
+	mi := &file_stache_v1_cache_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembersResponse) ProtoMessage() {}
+
+func (x *MembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembersResponse.ProtoReflect.Descriptor instead.
+func (*MembersResponse) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *MembersResponse) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *MembersResponse) GetSelf() string {
+	if x != nil && x.Self != nil {
+		return *x.Self
+	}
+	return ""
+}
+
+func (x *MembersResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type InvalidationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidationsRequest) Reset() {
+	*x = InvalidationsRequest{}
+	mi := &file_stache_v1_cache_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidationsRequest) ProtoMessage() {}
+
+func (x *InvalidationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidationsRequest.ProtoReflect.Descriptor instead.
+func (*InvalidationsRequest) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{101}
+}
+
+// InvalidationEvent is one message of the Invalidations stream: first the
+// tracking id, then the keys that changed since they were read with it.
+// An event with no fields is a heartbeat.
+type InvalidationEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TrackingId *uint64                `protobuf:"varint,1,opt,name=tracking_id,json=trackingId" json:"tracking_id,omitempty"`
+	// Each key is invalidated once; read it again to keep tracking it.
+	Keys []string `protobuf:"bytes,2,rep,name=keys" json:"keys,omitempty"`
+	// Every tracked key is invalidated, after a Clear or when the client
+	// fell behind.
+	Flush         *bool `protobuf:"varint,3,opt,name=flush" json:"flush,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidationEvent) Reset() {
+	*x = InvalidationEvent{}
+	mi := &file_stache_v1_cache_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidationEvent) ProtoMessage() {}
+
+func (x *InvalidationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_stache_v1_cache_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidationEvent.ProtoReflect.Descriptor instead.
+func (*InvalidationEvent) Descriptor() ([]byte, []int) {
+	return file_stache_v1_cache_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *InvalidationEvent) GetTrackingId() uint64 {
+	if x != nil && x.TrackingId != nil {
+		return *x.TrackingId
+	}
+	return 0
+}
+
+func (x *InvalidationEvent) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *InvalidationEvent) GetFlush() bool {
+	if x != nil && x.Flush != nil {
+		return *x.Flush
+	}
+	return false
+}
+
+var File_stache_v1_cache_proto protoreflect.FileDescriptor
+
+const file_stache_v1_cache_proto_rawDesc = "" +
+	"\n" +
+	"\x15stache/v1/cache.proto\x12\tstache.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x90\x02\n" +
+	"\n" +
+	"SetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12\x10\n" +
+	"\x03ttl\x18\x03 \x01(\x03R\x03ttl\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x18\n" +
+	"\asliding\x18\x05 \x01(\bR\asliding\x12<\n" +
+	"\fttl_duration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\vttlDuration\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\"\r\n" +
+	"\vSetResponse\"?\n" +
+	"\n" +
+	"GetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1f\n" +
+	"\vtracking_id\x18\x02 \x01(\x04R\n" +
+	"trackingId\"\x9e\x01\n" +
+	"\vGetResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\"\n" +
+	"\rexpires_at_ms\x18\x03 \x01(\x03R\vexpiresAtMs\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x04R\aversion\x12\x18\n" +
+	"\atracked\x18\x05 \x01(\bR\atracked\"!\n" +
+	"\rDeleteRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"*\n" +
+	"\x0eDeleteResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\xab\x01\n" +
+	"\fTouchRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x10\n" +
+	"\x03ttl\x18\x02 \x01(\x03R\x03ttl\x12<\n" +
+	"\fttl_duration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vttlDuration\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"3\n" +
+	"\rTouchResponse\x12\"\n" +
+	"\rexpires_at_ms\x18\x01 \x01(\x03R\vexpiresAtMs\"!\n" +
+	"\rGetTTLRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"K\n" +
+	"\x0eGetTTLResponse\x12\x15\n" +
+	"\x06ttl_ms\x18\x01 \x01(\x03R\x05ttlMs\x12\"\n" +
+	"\rexpires_at_ms\x18\x02 \x01(\x03R\vexpiresAtMs\"+\n" +
+	"\x15InvalidateTagsRequest\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"2\n" +
+	"\x16InvalidateTagsResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\rR\aremoved\"7\n" +
+	"\tHashField\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\"K\n" +
+	"\vHSetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\"(\n" +
+	"\fHSetResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\bR\acreated\"5\n" +
+	"\vHGetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\"$\n" +
+	"\fHGetResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\"7\n" +
+	"\vHDelRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06fields\x18\x02 \x03(\tR\x06fields\"(\n" +
+	"\fHDelResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\rR\aremoved\"\"\n" +
+	"\x0eHGetAllRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"?\n" +
+	"\x0fHGetAllResponse\x12,\n" +
+	"\x06fields\x18\x01 \x03(\v2\x14.stache.v1.HashFieldR\x06fields\"N\n" +
+	"\x0eHIncrByRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x03R\x05delta\"'\n" +
+	"\x0fHIncrByResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\"8\n" +
+	"\fLPushRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\fR\x06values\"'\n" +
+	"\rLPushResponse\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\rR\x06length\"8\n" +
+	"\fRPushRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\fR\x06values\"'\n" +
+	"\rRPushResponse\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\rR\x06length\"\x1f\n" +
+	"\vLPopRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"$\n" +
+	"\fLPopResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\"\x1f\n" +
+	"\vRPopRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"$\n" +
+	"\fRPopResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\"K\n" +
+	"\rLRangeRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x03R\x05start\x12\x12\n" +
+	"\x04stop\x18\x03 \x01(\x03R\x04stop\"(\n" +
+	"\x0eLRangeResponse\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\fR\x06values\"\x1f\n" +
+	"\vLLenRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"&\n" +
+	"\fLLenResponse\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\rR\x06length\"T\n" +
+	"\vBPopRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"$\n" +
+	"\fBPopResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\"7\n" +
+	"\aZMember\x12\x16\n" +
+	"\x06member\x18\x01 \x01(\tR\x06member\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"M\n" +
+	"\vZAddRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"$\n" +
+	"\fZAddResponse\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\bR\x05added\"9\n" +
+	"\vZRemRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"(\n" +
+	"\fZRemResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\rR\aremoved\"9\n" +
+	"\rZScoreRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\"&\n" +
+	"\x0eZScoreResponse\x12\x14\n" +
+	"\x05score\x18\x01 \x01(\x01R\x05score\"8\n" +
+	"\fZRankRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\"#\n" +
+	"\rZRankResponse\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x04R\x04rank\"L\n" +
+	"\x14ZRangeByScoreRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x10\n" +
+	"\x03min\x18\x02 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x03 \x01(\x01R\x03max\"E\n" +
+	"\x15ZRangeByScoreResponse\x12,\n" +
+	"\amembers\x18\x01 \x03(\v2\x12.stache.v1.ZMemberR\amembers\"Q\n" +
+	"\x13ZRangeByRankRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x03R\x05start\x12\x12\n" +
+	"\x04stop\x18\x03 \x01(\x03R\x04stop\"D\n" +
+	"\x14ZRangeByRankResponse\x12,\n" +
+	"\amembers\x18\x01 \x03(\v2\x12.stache.v1.ZMemberR\amembers\"9\n" +
+	"\vSAddRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"$\n" +
+	"\fSAddResponse\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\rR\x05added\"9\n" +
+	"\vSRemRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"(\n" +
+	"\fSRemResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\rR\aremoved\"<\n" +
+	"\x10SIsMemberRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\"0\n" +
+	"\x11SIsMemberResponse\x12\x1b\n" +
+	"\tis_member\x18\x01 \x01(\bR\bisMember\"#\n" +
+	"\x0fSMembersRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\",\n" +
+	"\x10SMembersResponse\x12\x18\n" +
+	"\amembers\x18\x01 \x03(\tR\amembers\" \n" +
+	"\fSCardRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"%\n" +
+	"\rSCardResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\rR\x05count\"I\n" +
+	"\x11SetAlgebraRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\"D\n" +
+	"\x12SetAlgebraResponse\x12\x18\n" +
+	"\amembers\x18\x01 \x03(\tR\amembers\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\"\xd7\x01\n" +
+	"\x06TxnSet\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\asliding\x18\x05 \x01(\bR\asliding\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\"\v\n" +
+	"\tTxnDelete\"\xa5\x01\n" +
+	"\x05TxnOp\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x04R\x0fexpectedVersion\x12%\n" +
+	"\x03set\x18\x03 \x01(\v2\x11.stache.v1.TxnSetH\x00R\x03set\x12.\n" +
+	"\x06delete\x18\x04 \x01(\v2\x14.stache.v1.TxnDeleteH\x00R\x06deleteB\b\n" +
+	"\x06action\"I\n" +
+	"\vTxnOpResult\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x04R\aversion\"8\n" +
+	"\x12TransactionRequest\x12\"\n" +
+	"\x03ops\x18\x01 \x03(\v2\x10.stache.v1.TxnOpR\x03ops\"e\n" +
+	"\x13TransactionResponse\x12\x1c\n" +
+	"\tcommitted\x18\x01 \x01(\bR\tcommitted\x120\n" +
+	"\aresults\x18\x02 \x03(\v2\x16.stache.v1.TxnOpResultR\aresults\"\xa6\x01\n" +
+	"\tEntryInfo\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\rR\x04size\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\"\n" +
+	"\rexpires_at_ms\x18\x04 \x01(\x03R\vexpiresAtMs\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x04R\aversion\"Z\n" +
+	"\x12ListEntriesRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\rR\x06offset\"[\n" +
+	"\x13ListEntriesResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.stache.v1.EntryInfoR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\rR\x05total\"%\n" +
+	"\x0fBatchGetRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\"D\n" +
+	"\x10BatchGetResponse\x120\n" +
+	"\x05items\x18\x01 \x03(\v2\x1a.stache.v1.GetResponseItemR\x05items\"\xb0\x01\n" +
+	"\x0fGetResponseItem\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\"\n" +
+	"\rexpires_at_ms\x18\x04 \x01(\x03R\vexpiresAtMs\x12\x14\n" +
+	"\x05found\x18\x05 \x01(\bR\x05found\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x04R\aversion\"\xef\x01\n" +
+	"\fBatchSetItem\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12+\n" +
+	"\x03ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\asliding\x18\x06 \x01(\bR\asliding\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\"@\n" +
+	"\x0fBatchSetRequest\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.stache.v1.BatchSetItemR\x05items\"H\n" +
+	"\x0eBatchSetResult\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"G\n" +
+	"\x10BatchSetResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.stache.v1.BatchSetResultR\aresults\"(\n" +
+	"\x12BatchDeleteRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\"?\n" +
+	"\x11BatchDeleteResult\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\bR\adeleted\"M\n" +
+	"\x13BatchDeleteResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.stache.v1.BatchDeleteResultR\aresults\"\xdc\x01\n" +
+	"\vEntryRecord\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x123\n" +
+	"\asliding\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\asliding\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\"'\n" +
+	"\rExportRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\"F\n" +
+	"\x0eImportResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x04R\bimported\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x04R\askipped\"\x0e\n" +
+	"\fStatsRequest\"\x9e\x01\n" +
+	"\rStatsResponse\x12\x18\n" +
+	"\aentries\x18\x01 \x01(\x04R\aentries\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x04R\x05bytes\x12\x12\n" +
+	"\x04hits\x18\x03 \x01(\x04R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x04 \x01(\x04R\x06misses\x121\n" +
+	"\x06uptime\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06uptime\"&\n" +
+	"\x12ClusterInfoRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"Q\n" +
+	"\vClusterNode\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04self\x18\x02 \x01(\bR\x04self\x12\x14\n" +
+	"\x05share\x18\x03 \x01(\x01R\x05share\"\x9f\x01\n" +
+	"\x13ClusterInfoResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04self\x18\x02 \x01(\tR\x04self\x12,\n" +
+	"\x05nodes\x18\x03 \x03(\v2\x16.stache.v1.ClusterNodeR\x05nodes\x12\x16\n" +
+	"\x06vnodes\x18\x04 \x01(\rR\x06vnodes\x12\x14\n" +
+	"\x05owner\x18\x05 \x01(\tR\x05owner\"\x12\n" +
+	"\x10ReplicateRequest\"\x95\x01\n" +
+	"\x11ReplicationExpire\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x123\n" +
+	"\asliding\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\asliding\"2\n" +
+	"\x16ReplicationSnapshotEnd\x12\x18\n" +
+	"\aentries\x18\x01 \x01(\x04R\aentries\"\x16\n" +
+	"\x14ReplicationHeartbeat\"\x86\x03\n" +
+	"\x10ReplicationEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12*\n" +
+	"\x03set\x18\x03 \x01(\v2\x16.stache.v1.EntryRecordH\x00R\x03set\x12\x18\n" +
+	"\x06delete\x18\x04 \x01(\tH\x00R\x06delete\x126\n" +
+	"\x06expire\x18\x05 \x01(\v2\x1c.stache.v1.ReplicationExpireH\x00R\x06expire\x12\x16\n" +
+	"\x05clear\x18\x06 \x01(\bH\x00R\x05clear\x12F\n" +
+	"\fsnapshot_end\x18\a \x01(\v2!.stache.v1.ReplicationSnapshotEndH\x00R\vsnapshotEnd\x12?\n" +
+	"\theartbeat\x18\b \x01(\v2\x1f.stache.v1.ReplicationHeartbeatH\x00R\theartbeatB\a\n" +
+	"\x05event\"\x1a\n" +
+	"\x18ReplicationStatusRequest\"\xf3\x01\n" +
+	"\x19ReplicationStatusResponse\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\aprimary\x18\x02 \x01(\tR\aprimary\x12\x1c\n" +
+	"\tconnected\x18\x03 \x01(\bR\tconnected\x12\x16\n" +
+	"\x06synced\x18\x04 \x01(\bR\x06synced\x12)\n" +
+	"\x10applied_sequence\x18\x05 \x01(\x04R\x0fappliedSequence\x12+\n" +
+	"\x03lag\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x03lag\x12\x1a\n" +
+	"\breplicas\x18\a \x01(\rR\breplicas\"\x13\n" +
+	"\x11RaftStatusRequest\"\xa4\x02\n" +
+	"\x12RaftStatusResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x12\n" +
+	"\x04term\x18\x04 \x01(\x04R\x04term\x12\x16\n" +
+	"\x06leader\x18\x05 \x01(\tR\x06leader\x12\x14\n" +
+	"\x05peers\x18\x06 \x03(\tR\x05peers\x12\x1d\n" +
+	"\n" +
+	"last_index\x18\a \x01(\x04R\tlastIndex\x12!\n" +
+	"\fcommit_index\x18\b \x01(\x04R\vcommitIndex\x12#\n" +
+	"\rapplied_index\x18\t \x01(\x04R\fappliedIndex\x12%\n" +
+	"\x0esnapshot_index\x18\n" +
+	" \x01(\x04R\rsnapshotIndex\"\x10\n" +
+	"\x0eMembersRequest\"n\n" +
+	"\x06Member\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12 \n" +
+	"\vincarnation\x18\x03 \x01(\x04R\vincarnation\x12\x12\n" +
+	"\x04self\x18\x04 \x01(\bR\x04self\"l\n" +
+	"\x0fMembersResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04self\x18\x02 \x01(\tR\x04self\x12+\n" +
+	"\amembers\x18\x03 \x03(\v2\x11.stache.v1.MemberR\amembers\"\x16\n" +
+	"\x14InvalidationsRequest\"^\n" +
+	"\x11InvalidationEvent\x12\x1f\n" +
+	"\vtracking_id\x18\x01 \x01(\x04R\n" +
+	"trackingId\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\x12\x14\n" +
+	"\x05flush\x18\x03 \x01(\bR\x05flush2\xf9\x17\n" +
+	"\fCacheService\x124\n" +
+	"\x03Set\x12\x15.stache.v1.SetRequest\x1a\x16.stache.v1.SetResponse\x124\n" +
+	"\x03Get\x12\x15.stache.v1.GetRequest\x1a\x16.stache.v1.GetResponse\x12=\n" +
+	"\x06Delete\x12\x18.stache.v1.DeleteRequest\x1a\x19.stache.v1.DeleteResponse\x12L\n" +
+	"\vListEntries\x12\x1d.stache.v1.ListEntriesRequest\x1a\x1e.stache.v1.ListEntriesResponse\x12C\n" +
+	"\bBatchGet\x12\x1a.stache.v1.BatchGetRequest\x1a\x1b.stache.v1.BatchGetResponse\x12:\n" +
+	"\x05Touch\x12\x17.stache.v1.TouchRequest\x1a\x18.stache.v1.TouchResponse\x12=\n" +
+	"\x06GetTTL\x12\x18.stache.v1.GetTTLRequest\x1a\x19.stache.v1.GetTTLResponse\x12U\n" +
+	"\x0eInvalidateTags\x12 .stache.v1.InvalidateTagsRequest\x1a!.stache.v1.InvalidateTagsResponse\x127\n" +
+	"\x04HSet\x12\x16.stache.v1.HSetRequest\x1a\x17.stache.v1.HSetResponse\x127\n" +
+	"\x04HGet\x12\x16.stache.v1.HGetRequest\x1a\x17.stache.v1.HGetResponse\x127\n" +
+	"\x04HDel\x12\x16.stache.v1.HDelRequest\x1a\x17.stache.v1.HDelResponse\x12@\n" +
+	"\aHGetAll\x12\x19.stache.v1.HGetAllRequest\x1a\x1a.stache.v1.HGetAllResponse\x12@\n" +
+	"\aHIncrBy\x12\x19.stache.v1.HIncrByRequest\x1a\x1a.stache.v1.HIncrByResponse\x12:\n" +
+	"\x05LPush\x12\x17.stache.v1.LPushRequest\x1a\x18.stache.v1.LPushResponse\x12:\n" +
+	"\x05RPush\x12\x17.stache.v1.RPushRequest\x1a\x18.stache.v1.RPushResponse\x127\n" +
+	"\x04LPop\x12\x16.stache.v1.LPopRequest\x1a\x17.stache.v1.LPopResponse\x127\n" +
+	"\x04RPop\x12\x16.stache.v1.RPopRequest\x1a\x17.stache.v1.RPopResponse\x12=\n" +
+	"\x06LRange\x12\x18.stache.v1.LRangeRequest\x1a\x19.stache.v1.LRangeResponse\x127\n" +
+	"\x04LLen\x12\x16.stache.v1.LLenRequest\x1a\x17.stache.v1.LLenResponse\x127\n" +
+	"\x04BPop\x12\x16.stache.v1.BPopRequest\x1a\x17.stache.v1.BPopResponse\x127\n" +
+	"\x04ZAdd\x12\x16.stache.v1.ZAddRequest\x1a\x17.stache.v1.ZAddResponse\x127\n" +
+	"\x04ZRem\x12\x16.stache.v1.ZRemRequest\x1a\x17.stache.v1.ZRemResponse\x12=\n" +
+	"\x06ZScore\x12\x18.stache.v1.ZScoreRequest\x1a\x19.stache.v1.ZScoreResponse\x12:\n" +
+	"\x05ZRank\x12\x17.stache.v1.ZRankRequest\x1a\x18.stache.v1.ZRankResponse\x12R\n" +
+	"\rZRangeByScore\x12\x1f.stache.v1.ZRangeByScoreRequest\x1a .stache.v1.ZRangeByScoreResponse\x12O\n" +
+	"\fZRangeByRank\x12\x1e.stache.v1.ZRangeByRankRequest\x1a\x1f.stache.v1.ZRangeByRankResponse\x127\n" +
+	"\x04SAdd\x12\x16.stache.v1.SAddRequest\x1a\x17.stache.v1.SAddResponse\x127\n" +
+	"\x04SRem\x12\x16.stache.v1.SRemRequest\x1a\x17.stache.v1.SRemResponse\x12F\n" +
+	"\tSIsMember\x12\x1b.stache.v1.SIsMemberRequest\x1a\x1c.stache.v1.SIsMemberResponse\x12C\n" +
+	"\bSMembers\x12\x1a.stache.v1.SMembersRequest\x1a\x1b.stache.v1.SMembersResponse\x12:\n" +
+	"\x05SCard\x12\x17.stache.v1.SCardRequest\x1a\x18.stache.v1.SCardResponse\x12E\n" +
+	"\x06SUnion\x12\x1c.stache.v1.SetAlgebraRequest\x1a\x1d.stache.v1.SetAlgebraResponse\x12E\n" +
+	"\x06SInter\x12\x1c.stache.v1.SetAlgebraRequest\x1a\x1d.stache.v1.SetAlgebraResponse\x12D\n" +
+	"\x05SDiff\x12\x1c.stache.v1.SetAlgebraRequest\x1a\x1d.stache.v1.SetAlgebraResponse\x12L\n" +
+	"\vTransaction\x12\x1d.stache.v1.TransactionRequest\x1a\x1e.stache.v1.TransactionResponse\x12C\n" +
+	"\bBatchSet\x12\x1a.stache.v1.BatchSetRequest\x1a\x1b.stache.v1.BatchSetResponse\x12L\n" +
+	"\vBatchDelete\x12\x1d.stache.v1.BatchDeleteRequest\x1a\x1e.stache.v1.BatchDeleteResponse\x12<\n" +
+	"\x06Export\x12\x18.stache.v1.ExportRequest\x1a\x16.stache.v1.EntryRecord0\x01\x12=\n" +
+	"\x06Import\x12\x16.stache.v1.EntryRecord\x1a\x19.stache.v1.ImportResponse(\x01\x12:\n" +
+	"\x05Stats\x12\x17.stache.v1.StatsRequest\x1a\x18.stache.v1.StatsResponse\x12L\n" +
+	"\vClusterInfo\x12\x1d.stache.v1.ClusterInfoRequest\x1a\x1e.stache.v1.ClusterInfoResponse\x12G\n" +
+	"\tReplicate\x12\x1b.stache.v1.ReplicateRequest\x1a\x1b.stache.v1.ReplicationEvent0\x01\x12^\n" +
+	"\x11ReplicationStatus\x12#.stache.v1.ReplicationStatusRequest\x1a$.stache.v1.ReplicationStatusResponse\x12I\n" +
+	"\n" +
+	"RaftStatus\x12\x1c.stache.v1.RaftStatusRequest\x1a\x1d.stache.v1.RaftStatusResponse\x12@\n" +
+	"\aMembers\x12\x19.stache.v1.MembersRequest\x1a\x1a.stache.v1.MembersResponse\x12P\n" +
+	"\rInvalidations\x12\x1f.stache.v1.InvalidationsRequest\x1a\x1c.stache.v1.InvalidationEvent0\x01B4Z2github.com/byytelope/stache/api/stache/v1;stachev1b\beditionsp\xe8\a"
+
+var (
+	file_stache_v1_cache_proto_rawDescOnce sync.Once
+	file_stache_v1_cache_proto_rawDescData []byte
+)
+
+func file_stache_v1_cache_proto_rawDescGZIP() []byte {
+	file_stache_v1_cache_proto_rawDescOnce.Do(func() {
+		file_stache_v1_cache_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stache_v1_cache_proto_rawDesc), len(file_stache_v1_cache_proto_rawDesc)))
+	})
+	return file_stache_v1_cache_proto_rawDescData
+}
+
+var file_stache_v1_cache_proto_msgTypes = make([]protoimpl.MessageInfo, 103)
+var file_stache_v1_cache_proto_goTypes = []any{
+	(*SetRequest)(nil),                // 0: stache.v1.SetRequest
+	(*SetResponse)(nil),               // 1: stache.v1.SetResponse
+	(*GetRequest)(nil),                // 2: stache.v1.GetRequest
+	(*GetResponse)(nil),               // 3: stache.v1.GetResponse
+	(*DeleteRequest)(nil),             // 4: stache.v1.DeleteRequest
+	(*DeleteResponse)(nil),            // 5: stache.v1.DeleteResponse
+	(*TouchRequest)(nil),              // 6: stache.v1.TouchRequest
+	(*TouchResponse)(nil),             // 7: stache.v1.TouchResponse
+	(*GetTTLRequest)(nil),             // 8: stache.v1.GetTTLRequest
+	(*GetTTLResponse)(nil),            // 9: stache.v1.GetTTLResponse
+	(*InvalidateTagsRequest)(nil),     // 10: stache.v1.InvalidateTagsRequest
+	(*InvalidateTagsResponse)(nil),    // 11: stache.v1.InvalidateTagsResponse
+	(*HashField)(nil),                 // 12: stache.v1.HashField
+	(*HSetRequest)(nil),               // 13: stache.v1.HSetRequest
+	(*HSetResponse)(nil),              // 14: stache.v1.HSetResponse
+	(*HGetRequest)(nil),               // 15: stache.v1.HGetRequest
+	(*HGetResponse)(nil),              // 16: stache.v1.HGetResponse
+	(*HDelRequest)(nil),               // 17: stache.v1.HDelRequest
+	(*HDelResponse)(nil),              // 18: stache.v1.HDelResponse
+	(*HGetAllRequest)(nil),            // 19: stache.v1.HGetAllRequest
+	(*HGetAllResponse)(nil),           // 20: stache.v1.HGetAllResponse
+	(*HIncrByRequest)(nil),            // 21: stache.v1.HIncrByRequest
+	(*HIncrByResponse)(nil),           // 22: stache.v1.HIncrByResponse
+	(*LPushRequest)(nil),              // 23: stache.v1.LPushRequest
+	(*LPushResponse)(nil),             // 24: stache.v1.LPushResponse
+	(*RPushRequest)(nil),              // 25: stache.v1.RPushRequest
+	(*RPushResponse)(nil),             // 26: stache.v1.RPushResponse
+	(*LPopRequest)(nil),               // 27: stache.v1.LPopRequest
+	(*LPopResponse)(nil),              // 28: stache.v1.LPopResponse
+	(*RPopRequest)(nil),               // 29: stache.v1.RPopRequest
+	(*RPopResponse)(nil),              // 30: stache.v1.RPopResponse
+	(*LRangeRequest)(nil),             // 31: stache.v1.LRangeRequest
+	(*LRangeResponse)(nil),            // 32: stache.v1.LRangeResponse
+	(*LLenRequest)(nil),               // 33: stache.v1.LLenRequest
+	(*LLenResponse)(nil),              // 34: stache.v1.LLenResponse
+	(*BPopRequest)(nil),               // 35: stache.v1.BPopRequest
+	(*BPopResponse)(nil),              // 36: stache.v1.BPopResponse
+	(*ZMember)(nil),                   // 37: stache.v1.ZMember
+	(*ZAddRequest)(nil),               // 38: stache.v1.ZAddRequest
+	(*ZAddResponse)(nil),              // 39: stache.v1.ZAddResponse
+	(*ZRemRequest)(nil),               // 40: stache.v1.ZRemRequest
+	(*ZRemResponse)(nil),              // 41: stache.v1.ZRemResponse
+	(*ZScoreRequest)(nil),             // 42: stache.v1.ZScoreRequest
+	(*ZScoreResponse)(nil),            // 43: stache.v1.ZScoreResponse
+	(*ZRankRequest)(nil),              // 44: stache.v1.ZRankRequest
+	(*ZRankResponse)(nil),             // 45: stache.v1.ZRankResponse
+	(*ZRangeByScoreRequest)(nil),      // 46: stache.v1.ZRangeByScoreRequest
+	(*ZRangeByScoreResponse)(nil),     // 47: stache.v1.ZRangeByScoreResponse
+	(*ZRangeByRankRequest)(nil),       // 48: stache.v1.ZRangeByRankRequest
+	(*ZRangeByRankResponse)(nil),      // 49: stache.v1.ZRangeByRankResponse
+	(*SAddRequest)(nil),               // 50: stache.v1.SAddRequest
+	(*SAddResponse)(nil),              // 51: stache.v1.SAddResponse
+	(*SRemRequest)(nil),               // 52: stache.v1.SRemRequest
+	(*SRemResponse)(nil),              // 53: stache.v1.SRemResponse
+	(*SIsMemberRequest)(nil),          // 54: stache.v1.SIsMemberRequest
+	(*SIsMemberResponse)(nil),         // 55: stache.v1.SIsMemberResponse
+	(*SMembersRequest)(nil),           // 56: stache.v1.SMembersRequest
+	(*SMembersResponse)(nil),          // 57: stache.v1.SMembersResponse
+	(*SCardRequest)(nil),              // 58: stache.v1.SCardRequest
+	(*SCardResponse)(nil),             // 59: stache.v1.SCardResponse
+	(*SetAlgebraRequest)(nil),         // 60: stache.v1.SetAlgebraRequest
+	(*SetAlgebraResponse)(nil),        // 61: stache.v1.SetAlgebraResponse
+	(*TxnSet)(nil),                    // 62: stache.v1.TxnSet
+	(*TxnDelete)(nil),                 // 63: stache.v1.TxnDelete
+	(*TxnOp)(nil),                     // 64: stache.v1.TxnOp
+	(*TxnOpResult)(nil),               // 65: stache.v1.TxnOpResult
+	(*TransactionRequest)(nil),        // 66: stache.v1.TransactionRequest
+	(*TransactionResponse)(nil),       // 67: stache.v1.TransactionResponse
+	(*EntryInfo)(nil),                 // 68: stache.v1.EntryInfo
+	(*ListEntriesRequest)(nil),        // 69: stache.v1.ListEntriesRequest
+	(*ListEntriesResponse)(nil),       // 70: stache.v1.ListEntriesResponse
+	(*BatchGetRequest)(nil),           // 71: stache.v1.BatchGetRequest
+	(*BatchGetResponse)(nil),          // 72: stache.v1.BatchGetResponse
+	(*GetResponseItem)(nil),           // 73: stache.v1.GetResponseItem
+	(*BatchSetItem)(nil),              // 74: stache.v1.BatchSetItem
+	(*BatchSetRequest)(nil),           // 75: stache.v1.BatchSetRequest
+	(*BatchSetResult)(nil),            // 76: stache.v1.BatchSetResult
+	(*BatchSetResponse)(nil),          // 77: stache.v1.BatchSetResponse
+	(*BatchDeleteRequest)(nil),        // 78: stache.v1.BatchDeleteRequest
+	(*BatchDeleteResult)(nil),         // 79: stache.v1.BatchDeleteResult
+	(*BatchDeleteResponse)(nil),       // 80: stache.v1.BatchDeleteResponse
+	(*EntryRecord)(nil),               // 81: stache.v1.EntryRecord
+	(*ExportRequest)(nil),             // 82: stache.v1.ExportRequest
+	(*ImportResponse)(nil),            // 83: stache.v1.ImportResponse
+	(*StatsRequest)(nil),              // 84: stache.v1.StatsRequest
+	(*StatsResponse)(nil),             // 85: stache.v1.StatsResponse
+	(*ClusterInfoRequest)(nil),        // 86: stache.v1.ClusterInfoRequest
+	(*ClusterNode)(nil),               // 87: stache.v1.ClusterNode
+	(*ClusterInfoResponse)(nil),       // 88: stache.v1.ClusterInfoResponse
+	(*ReplicateRequest)(nil),          // 89: stache.v1.ReplicateRequest
+	(*ReplicationExpire)(nil),         // 90: stache.v1.ReplicationExpire
+	(*ReplicationSnapshotEnd)(nil),    // 91: stache.v1.ReplicationSnapshotEnd
+	(*ReplicationHeartbeat)(nil),      // 92: stache.v1.ReplicationHeartbeat
+	(*ReplicationEvent)(nil),          // 93: stache.v1.ReplicationEvent
+	(*ReplicationStatusRequest)(nil),  // 94: stache.v1.ReplicationStatusRequest
+	(*ReplicationStatusResponse)(nil), // 95: stache.v1.ReplicationStatusResponse
+	(*RaftStatusRequest)(nil),         // 96: stache.v1.RaftStatusRequest
+	(*RaftStatusResponse)(nil),        // 97: stache.v1.RaftStatusResponse
+	(*MembersRequest)(nil),            // 98: stache.v1.MembersRequest
+	(*Member)(nil),                    // 99: stache.v1.Member
+	(*MembersResponse)(nil),           // 100: stache.v1.MembersResponse
+	(*InvalidationsRequest)(nil),      // 101: stache.v1.InvalidationsRequest
+	(*InvalidationEvent)(nil),         // 102: stache.v1.InvalidationEvent
+	(*durationpb.Duration)(nil),       // 103: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),     // 104: google.protobuf.Timestamp
+}
+var file_stache_v1_cache_proto_depIdxs = []int32{
+	103, // 0: stache.v1.SetRequest.ttl_duration:type_name -> google.protobuf.Duration
+	104, // 1: stache.v1.SetRequest.expires_at:type_name -> google.protobuf.Timestamp
+	103, // 2: stache.v1.TouchRequest.ttl_duration:type_name -> google.protobuf.Duration
+	104, // 3: stache.v1.TouchRequest.expires_at:type_name -> google.protobuf.Timestamp
+	12,  // 4: stache.v1.HGetAllResponse.fields:type_name -> stache.v1.HashField
+	103, // 5: stache.v1.BPopRequest.timeout:type_name -> google.protobuf.Duration
+	37,  // 6: stache.v1.ZRangeByScoreResponse.members:type_name -> stache.v1.ZMember
+	37,  // 7: stache.v1.ZRangeByRankResponse.members:type_name -> stache.v1.ZMember
+	103, // 8: stache.v1.TxnSet.ttl:type_name -> google.protobuf.Duration
+	104, // 9: stache.v1.TxnSet.expires_at:type_name -> google.protobuf.Timestamp
+	62,  // 10: stache.v1.TxnOp.set:type_name -> stache.v1.TxnSet
+	63,  // 11: stache.v1.TxnOp.delete:type_name -> stache.v1.TxnDelete
+	64,  // 12: stache.v1.TransactionRequest.ops:type_name -> stache.v1.TxnOp
+	65,  // 13: stache.v1.TransactionResponse.results:type_name -> stache.v1.TxnOpResult
+	68,  // 14: stache.v1.ListEntriesResponse.entries:type_name -> stache.v1.EntryInfo
+	73,  // 15: stache.v1.BatchGetResponse.items:type_name -> stache.v1.GetResponseItem
+	103, // 16: stache.v1.BatchSetItem.ttl:type_name -> google.protobuf.Duration
+	104, // 17: stache.v1.BatchSetItem.expires_at:type_name -> google.protobuf.Timestamp
+	74,  // 18: stache.v1.BatchSetRequest.items:type_name -> stache.v1.BatchSetItem
+	76,  // 19: stache.v1.BatchSetResponse.results:type_name -> stache.v1.BatchSetResult
+	79,  // 20: stache.v1.BatchDeleteResponse.results:type_name -> stache.v1.BatchDeleteResult
+	104, // 21: stache.v1.EntryRecord.expires_at:type_name -> google.protobuf.Timestamp
+	103, // 22: stache.v1.EntryRecord.sliding:type_name -> google.protobuf.Duration
+	103, // 23: stache.v1.StatsResponse.uptime:type_name -> google.protobuf.Duration
+	87,  // 24: stache.v1.ClusterInfoResponse.nodes:type_name -> stache.v1.ClusterNode
+	104, // 25: stache.v1.ReplicationExpire.expires_at:type_name -> google.protobuf.Timestamp
+	103, // 26: stache.v1.ReplicationExpire.sliding:type_name -> google.protobuf.Duration
+	104, // 27: stache.v1.ReplicationEvent.time:type_name -> google.protobuf.Timestamp
+	81,  // 28: stache.v1.ReplicationEvent.set:type_name -> stache.v1.EntryRecord
+	90,  // 29: stache.v1.ReplicationEvent.expire:type_name -> stache.v1.ReplicationExpire
+	91,  // 30: stache.v1.ReplicationEvent.snapshot_end:type_name -> stache.v1.ReplicationSnapshotEnd
+	92,  // 31: stache.v1.ReplicationEvent.heartbeat:type_name -> stache.v1.ReplicationHeartbeat
+	103, // 32: stache.v1.ReplicationStatusResponse.lag:type_name -> google.protobuf.Duration
+	99,  // 33: stache.v1.MembersResponse.members:type_name -> stache.v1.Member
+	0,   // 34: stache.v1.CacheService.Set:input_type -> stache.v1.SetRequest
+	2,   // 35: stache.v1.CacheService.Get:input_type -> stache.v1.GetRequest
+	4,   // 36: stache.v1.CacheService.Delete:input_type -> stache.v1.DeleteRequest
+	69,  // 37: stache.v1.CacheService.ListEntries:input_type -> stache.v1.ListEntriesRequest
+	71,  // 38: stache.v1.CacheService.BatchGet:input_type -> stache.v1.BatchGetRequest
+	6,   // 39: stache.v1.CacheService.Touch:input_type -> stache.v1.TouchRequest
+	8,   // 40: stache.v1.CacheService.GetTTL:input_type -> stache.v1.GetTTLRequest
+	10,  // 41: stache.v1.CacheService.InvalidateTags:input_type -> stache.v1.InvalidateTagsRequest
+	13,  // 42: stache.v1.CacheService.HSet:input_type -> stache.v1.HSetRequest
+	15,  // 43: stache.v1.CacheService.HGet:input_type -> stache.v1.HGetRequest
+	17,  // 44: stache.v1.CacheService.HDel:input_type -> stache.v1.HDelRequest
+	19,  // 45: stache.v1.CacheService.HGetAll:input_type -> stache.v1.HGetAllRequest
+	21,  // 46: stache.v1.CacheService.HIncrBy:input_type -> stache.v1.HIncrByRequest
+	23,  // 47: stache.v1.CacheService.LPush:input_type -> stache.v1.LPushRequest
+	25,  // 48: stache.v1.CacheService.RPush:input_type -> stache.v1.RPushRequest
+	27,  // 49: stache.v1.CacheService.LPop:input_type -> stache.v1.LPopRequest
+	29,  // 50: stache.v1.CacheService.RPop:input_type -> stache.v1.RPopRequest
+	31,  // 51: stache.v1.CacheService.LRange:input_type -> stache.v1.LRangeRequest
+	33,  // 52: stache.v1.CacheService.LLen:input_type -> stache.v1.LLenRequest
+	35,  // 53: stache.v1.CacheService.BPop:input_type -> stache.v1.BPopRequest
+	38,  // 54: stache.v1.CacheService.ZAdd:input_type -> stache.v1.ZAddRequest
+	40,  // 55: stache.v1.CacheService.ZRem:input_type -> stache.v1.ZRemRequest
+	42,  // 56: stache.v1.CacheService.ZScore:input_type -> stache.v1.ZScoreRequest
+	44,  // 57: stache.v1.CacheService.ZRank:input_type -> stache.v1.ZRankRequest
+	46,  // 58: stache.v1.CacheService.ZRangeByScore:input_type -> stache.v1.ZRangeByScoreRequest
+	48,  // 59: stache.v1.CacheService.ZRangeByRank:input_type -> stache.v1.ZRangeByRankRequest
+	50,  // 60: stache.v1.CacheService.SAdd:input_type -> stache.v1.SAddRequest
+	52,  // 61: stache.v1.CacheService.SRem:input_type -> stache.v1.SRemRequest
+	54,  // 62: stache.v1.CacheService.SIsMember:input_type -> stache.v1.SIsMemberRequest
+	56,  // 63: stache.v1.CacheService.SMembers:input_type -> stache.v1.SMembersRequest
+	58,  // 64: stache.v1.CacheService.SCard:input_type -> stache.v1.SCardRequest
+	60,  // 65: stache.v1.CacheService.SUnion:input_type -> stache.v1.SetAlgebraRequest
+	60,  // 66: stache.v1.CacheService.SInter:input_type -> stache.v1.SetAlgebraRequest
+	60,  // 67: stache.v1.CacheService.SDiff:input_type -> stache.v1.SetAlgebraRequest
+	66,  // 68: stache.v1.CacheService.Transaction:input_type -> stache.v1.TransactionRequest
+	75,  // 69: stache.v1.CacheService.BatchSet:input_type -> stache.v1.BatchSetRequest
+	78,  // 70: stache.v1.CacheService.BatchDelete:input_type -> stache.v1.BatchDeleteRequest
+	82,  // 71: stache.v1.CacheService.Export:input_type -> stache.v1.ExportRequest
+	81,  // 72: stache.v1.CacheService.Import:input_type -> stache.v1.EntryRecord
+	84,  // 73: stache.v1.CacheService.Stats:input_type -> stache.v1.StatsRequest
+	86,  // 74: stache.v1.CacheService.ClusterInfo:input_type -> stache.v1.ClusterInfoRequest
+	89,  // 75: stache.v1.CacheService.Replicate:input_type -> stache.v1.ReplicateRequest
+	94,  // 76: stache.v1.CacheService.ReplicationStatus:input_type -> stache.v1.ReplicationStatusRequest
+	96,  // 77: stache.v1.CacheService.RaftStatus:input_type -> stache.v1.RaftStatusRequest
+	98,  // 78: stache.v1.CacheService.Members:input_type -> stache.v1.MembersRequest
+	101, // 79: stache.v1.CacheService.Invalidations:input_type -> stache.v1.InvalidationsRequest
+	1,   // 80: stache.v1.CacheService.Set:output_type -> stache.v1.SetResponse
+	3,   // 81: stache.v1.CacheService.Get:output_type -> stache.v1.GetResponse
+	5,   // 82: stache.v1.CacheService.Delete:output_type -> stache.v1.DeleteResponse
+	70,  // 83: stache.v1.CacheService.ListEntries:output_type -> stache.v1.ListEntriesResponse
+	72,  // 84: stache.v1.CacheService.BatchGet:output_type -> stache.v1.BatchGetResponse
+	7,   // 85: stache.v1.CacheService.Touch:output_type -> stache.v1.TouchResponse
+	9,   // 86: stache.v1.CacheService.GetTTL:output_type -> stache.v1.GetTTLResponse
+	11,  // 87: stache.v1.CacheService.InvalidateTags:output_type -> stache.v1.InvalidateTagsResponse
+	14,  // 88: stache.v1.CacheService.HSet:output_type -> stache.v1.HSetResponse
+	16,  // 89: stache.v1.CacheService.HGet:output_type -> stache.v1.HGetResponse
+	18,  // 90: stache.v1.CacheService.HDel:output_type -> stache.v1.HDelResponse
+	20,  // 91: stache.v1.CacheService.HGetAll:output_type -> stache.v1.HGetAllResponse
+	22,  // 92: stache.v1.CacheService.HIncrBy:output_type -> stache.v1.HIncrByResponse
+	24,  // 93: stache.v1.CacheService.LPush:output_type -> stache.v1.LPushResponse
+	26,  // 94: stache.v1.CacheService.RPush:output_type -> stache.v1.RPushResponse
+	28,  // 95: stache.v1.CacheService.LPop:output_type -> stache.v1.LPopResponse
+	30,  // 96: stache.v1.CacheService.RPop:output_type -> stache.v1.RPopResponse
+	32,  // 97: stache.v1.CacheService.LRange:output_type -> stache.v1.LRangeResponse
+	34,  // 98: stache.v1.CacheService.LLen:output_type -> stache.v1.LLenResponse
+	36,  // 99: stache.v1.CacheService.BPop:output_type -> stache.v1.BPopResponse
+	39,  // 100: stache.v1.CacheService.ZAdd:output_type -> stache.v1.ZAddResponse
+	41,  // 101: stache.v1.CacheService.ZRem:output_type -> stache.v1.ZRemResponse
+	43,  // 102: stache.v1.CacheService.ZScore:output_type -> stache.v1.ZScoreResponse
+	45,  // 103: stache.v1.CacheService.ZRank:output_type -> stache.v1.ZRankResponse
+	47,  // 104: stache.v1.CacheService.ZRangeByScore:output_type -> stache.v1.ZRangeByScoreResponse
+	49,  // 105: stache.v1.CacheService.ZRangeByRank:output_type -> stache.v1.ZRangeByRankResponse
+	51,  // 106: stache.v1.CacheService.SAdd:output_type -> stache.v1.SAddResponse
+	53,  // 107: stache.v1.CacheService.SRem:output_type -> stache.v1.SRemResponse
+	55,  // 108: stache.v1.CacheService.SIsMember:output_type -> stache.v1.SIsMemberResponse
+	57,  // 109: stache.v1.CacheService.SMembers:output_type -> stache.v1.SMembersResponse
+	59,  // 110: stache.v1.CacheService.SCard:output_type -> stache.v1.SCardResponse
+	61,  // 111: stache.v1.CacheService.SUnion:output_type -> stache.v1.SetAlgebraResponse
+	61,  // 112: stache.v1.CacheService.SInter:output_type -> stache.v1.SetAlgebraResponse
+	61,  // 113: stache.v1.CacheService.SDiff:output_type -> stache.v1.SetAlgebraResponse
+	67,  // 114: stache.v1.CacheService.Transaction:output_type -> stache.v1.TransactionResponse
+	77,  // 115: stache.v1.CacheService.BatchSet:output_type -> stache.v1.BatchSetResponse
+	80,  // 116: stache.v1.CacheService.BatchDelete:output_type -> stache.v1.BatchDeleteResponse
+	81,  // 117: stache.v1.CacheService.Export:output_type -> stache.v1.EntryRecord
+	83,  // 118: stache.v1.CacheService.Import:output_type -> stache.v1.ImportResponse
+	85,  // 119: stache.v1.CacheService.Stats:output_type -> stache.v1.StatsResponse
+	88,  // 120: stache.v1.CacheService.ClusterInfo:output_type -> stache.v1.ClusterInfoResponse
+	93,  // 121: stache.v1.CacheService.Replicate:output_type -> stache.v1.ReplicationEvent
+	95,  // 122: stache.v1.CacheService.ReplicationStatus:output_type -> stache.v1.ReplicationStatusResponse
+	97,  // 123: stache.v1.CacheService.RaftStatus:output_type -> stache.v1.RaftStatusResponse
+	100, // 124: stache.v1.CacheService.Members:output_type -> stache.v1.MembersResponse
+	102, // 125: stache.v1.CacheService.Invalidations:output_type -> stache.v1.InvalidationEvent
+	80,  // [80:126] is the sub-list for method output_type
+	34,  // [34:80] is the sub-list for method input_type
+	34,  // [34:34] is the sub-list for extension type_name
+	34,  // [34:34] is the sub-list for extension extendee
+	0,   // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_stache_v1_cache_proto_init() }
@@ -729,13 +6266,25 @@ func file_stache_v1_cache_proto_init() {
 	if File_stache_v1_cache_proto != nil {
 		return
 	}
+	file_stache_v1_cache_proto_msgTypes[64].OneofWrappers = []any{
+		(*TxnOp_Set)(nil),
+		(*TxnOp_Delete)(nil),
+	}
+	file_stache_v1_cache_proto_msgTypes[93].OneofWrappers = []any{
+		(*ReplicationEvent_Set)(nil),
+		(*ReplicationEvent_Delete)(nil),
+		(*ReplicationEvent_Expire)(nil),
+		(*ReplicationEvent_Clear)(nil),
+		(*ReplicationEvent_SnapshotEnd)(nil),
+		(*ReplicationEvent_Heartbeat)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stache_v1_cache_proto_rawDesc), len(file_stache_v1_cache_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   103,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -44,6 +44,96 @@ const (
 	CacheServiceListEntriesProcedure = "/stache.v1.CacheService/ListEntries"
 	// CacheServiceBatchGetProcedure is the fully-qualified name of the CacheService's BatchGet RPC.
 	CacheServiceBatchGetProcedure = "/stache.v1.CacheService/BatchGet"
+	// CacheServiceTouchProcedure is the fully-qualified name of the CacheService's Touch RPC.
+	CacheServiceTouchProcedure = "/stache.v1.CacheService/Touch"
+	// CacheServiceGetTTLProcedure is the fully-qualified name of the CacheService's GetTTL RPC.
+	CacheServiceGetTTLProcedure = "/stache.v1.CacheService/GetTTL"
+	// CacheServiceInvalidateTagsProcedure is the fully-qualified name of the CacheService's
+	// InvalidateTags RPC.
+	CacheServiceInvalidateTagsProcedure = "/stache.v1.CacheService/InvalidateTags"
+	// CacheServiceHSetProcedure is the fully-qualified name of the CacheService's HSet RPC.
+	CacheServiceHSetProcedure = "/stache.v1.CacheService/HSet"
+	// CacheServiceHGetProcedure is the fully-qualified name of the CacheService's HGet RPC.
+	CacheServiceHGetProcedure = "/stache.v1.CacheService/HGet"
+	// CacheServiceHDelProcedure is the fully-qualified name of the CacheService's HDel RPC.
+	CacheServiceHDelProcedure = "/stache.v1.CacheService/HDel"
+	// CacheServiceHGetAllProcedure is the fully-qualified name of the CacheService's HGetAll RPC.
+	CacheServiceHGetAllProcedure = "/stache.v1.CacheService/HGetAll"
+	// CacheServiceHIncrByProcedure is the fully-qualified name of the CacheService's HIncrBy RPC.
+	CacheServiceHIncrByProcedure = "/stache.v1.CacheService/HIncrBy"
+	// CacheServiceLPushProcedure is the fully-qualified name of the CacheService's LPush RPC.
+	CacheServiceLPushProcedure = "/stache.v1.CacheService/LPush"
+	// CacheServiceRPushProcedure is the fully-qualified name of the CacheService's RPush RPC.
+	CacheServiceRPushProcedure = "/stache.v1.CacheService/RPush"
+	// CacheServiceLPopProcedure is the fully-qualified name of the CacheService's LPop RPC.
+	CacheServiceLPopProcedure = "/stache.v1.CacheService/LPop"
+	// CacheServiceRPopProcedure is the fully-qualified name of the CacheService's RPop RPC.
+	CacheServiceRPopProcedure = "/stache.v1.CacheService/RPop"
+	// CacheServiceLRangeProcedure is the fully-qualified name of the CacheService's LRange RPC.
+	CacheServiceLRangeProcedure = "/stache.v1.CacheService/LRange"
+	// CacheServiceLLenProcedure is the fully-qualified name of the CacheService's LLen RPC.
+	CacheServiceLLenProcedure = "/stache.v1.CacheService/LLen"
+	// CacheServiceBPopProcedure is the fully-qualified name of the CacheService's BPop RPC.
+	CacheServiceBPopProcedure = "/stache.v1.CacheService/BPop"
+	// CacheServiceZAddProcedure is the fully-qualified name of the CacheService's ZAdd RPC.
+	CacheServiceZAddProcedure = "/stache.v1.CacheService/ZAdd"
+	// CacheServiceZRemProcedure is the fully-qualified name of the CacheService's ZRem RPC.
+	CacheServiceZRemProcedure = "/stache.v1.CacheService/ZRem"
+	// CacheServiceZScoreProcedure is the fully-qualified name of the CacheService's ZScore RPC.
+	CacheServiceZScoreProcedure = "/stache.v1.CacheService/ZScore"
+	// CacheServiceZRankProcedure is the fully-qualified name of the CacheService's ZRank RPC.
+	CacheServiceZRankProcedure = "/stache.v1.CacheService/ZRank"
+	// CacheServiceZRangeByScoreProcedure is the fully-qualified name of the CacheService's
+	// ZRangeByScore RPC.
+	CacheServiceZRangeByScoreProcedure = "/stache.v1.CacheService/ZRangeByScore"
+	// CacheServiceZRangeByRankProcedure is the fully-qualified name of the CacheService's ZRangeByRank
+	// RPC.
+	CacheServiceZRangeByRankProcedure = "/stache.v1.CacheService/ZRangeByRank"
+	// CacheServiceSAddProcedure is the fully-qualified name of the CacheService's SAdd RPC.
+	CacheServiceSAddProcedure = "/stache.v1.CacheService/SAdd"
+	// CacheServiceSRemProcedure is the fully-qualified name of the CacheService's SRem RPC.
+	CacheServiceSRemProcedure = "/stache.v1.CacheService/SRem"
+	// CacheServiceSIsMemberProcedure is the fully-qualified name of the CacheService's SIsMember RPC.
+	CacheServiceSIsMemberProcedure = "/stache.v1.CacheService/SIsMember"
+	// CacheServiceSMembersProcedure is the fully-qualified name of the CacheService's SMembers RPC.
+	CacheServiceSMembersProcedure = "/stache.v1.CacheService/SMembers"
+	// CacheServiceSCardProcedure is the fully-qualified name of the CacheService's SCard RPC.
+	CacheServiceSCardProcedure = "/stache.v1.CacheService/SCard"
+	// CacheServiceSUnionProcedure is the fully-qualified name of the CacheService's SUnion RPC.
+	CacheServiceSUnionProcedure = "/stache.v1.CacheService/SUnion"
+	// CacheServiceSInterProcedure is the fully-qualified name of the CacheService's SInter RPC.
+	CacheServiceSInterProcedure = "/stache.v1.CacheService/SInter"
+	// CacheServiceSDiffProcedure is the fully-qualified name of the CacheService's SDiff RPC.
+	CacheServiceSDiffProcedure = "/stache.v1.CacheService/SDiff"
+	// CacheServiceTransactionProcedure is the fully-qualified name of the CacheService's Transaction
+	// RPC.
+	CacheServiceTransactionProcedure = "/stache.v1.CacheService/Transaction"
+	// CacheServiceBatchSetProcedure is the fully-qualified name of the CacheService's BatchSet RPC.
+	CacheServiceBatchSetProcedure = "/stache.v1.CacheService/BatchSet"
+	// CacheServiceBatchDeleteProcedure is the fully-qualified name of the CacheService's BatchDelete
+	// RPC.
+	CacheServiceBatchDeleteProcedure = "/stache.v1.CacheService/BatchDelete"
+	// CacheServiceExportProcedure is the fully-qualified name of the CacheService's Export RPC.
+	CacheServiceExportProcedure = "/stache.v1.CacheService/Export"
+	// CacheServiceImportProcedure is the fully-qualified name of the CacheService's Import RPC.
+	CacheServiceImportProcedure = "/stache.v1.CacheService/Import"
+	// CacheServiceStatsProcedure is the fully-qualified name of the CacheService's Stats RPC.
+	CacheServiceStatsProcedure = "/stache.v1.CacheService/Stats"
+	// CacheServiceClusterInfoProcedure is the fully-qualified name of the CacheService's ClusterInfo
+	// RPC.
+	CacheServiceClusterInfoProcedure = "/stache.v1.CacheService/ClusterInfo"
+	// CacheServiceReplicateProcedure is the fully-qualified name of the CacheService's Replicate RPC.
+	CacheServiceReplicateProcedure = "/stache.v1.CacheService/Replicate"
+	// CacheServiceReplicationStatusProcedure is the fully-qualified name of the CacheService's
+	// ReplicationStatus RPC.
+	CacheServiceReplicationStatusProcedure = "/stache.v1.CacheService/ReplicationStatus"
+	// CacheServiceRaftStatusProcedure is the fully-qualified name of the CacheService's RaftStatus RPC.
+	CacheServiceRaftStatusProcedure = "/stache.v1.CacheService/RaftStatus"
+	// CacheServiceMembersProcedure is the fully-qualified name of the CacheService's Members RPC.
+	CacheServiceMembersProcedure = "/stache.v1.CacheService/Members"
+	// CacheServiceInvalidationsProcedure is the fully-qualified name of the CacheService's
+	// Invalidations RPC.
+	CacheServiceInvalidationsProcedure = "/stache.v1.CacheService/Invalidations"
 )
 
 // CacheServiceClient is a client for the stache.v1.CacheService service.
@@ -53,6 +143,47 @@ type CacheServiceClient interface {
 	Delete(context.Context, *connect.Request[v1.DeleteRequest]) (*connect.Response[v1.DeleteResponse], error)
 	ListEntries(context.Context, *connect.Request[v1.ListEntriesRequest]) (*connect.Response[v1.ListEntriesResponse], error)
 	BatchGet(context.Context, *connect.Request[v1.BatchGetRequest]) (*connect.Response[v1.BatchGetResponse], error)
+	Touch(context.Context, *connect.Request[v1.TouchRequest]) (*connect.Response[v1.TouchResponse], error)
+	GetTTL(context.Context, *connect.Request[v1.GetTTLRequest]) (*connect.Response[v1.GetTTLResponse], error)
+	InvalidateTags(context.Context, *connect.Request[v1.InvalidateTagsRequest]) (*connect.Response[v1.InvalidateTagsResponse], error)
+	HSet(context.Context, *connect.Request[v1.HSetRequest]) (*connect.Response[v1.HSetResponse], error)
+	HGet(context.Context, *connect.Request[v1.HGetRequest]) (*connect.Response[v1.HGetResponse], error)
+	HDel(context.Context, *connect.Request[v1.HDelRequest]) (*connect.Response[v1.HDelResponse], error)
+	HGetAll(context.Context, *connect.Request[v1.HGetAllRequest]) (*connect.Response[v1.HGetAllResponse], error)
+	HIncrBy(context.Context, *connect.Request[v1.HIncrByRequest]) (*connect.Response[v1.HIncrByResponse], error)
+	LPush(context.Context, *connect.Request[v1.LPushRequest]) (*connect.Response[v1.LPushResponse], error)
+	RPush(context.Context, *connect.Request[v1.RPushRequest]) (*connect.Response[v1.RPushResponse], error)
+	LPop(context.Context, *connect.Request[v1.LPopRequest]) (*connect.Response[v1.LPopResponse], error)
+	RPop(context.Context, *connect.Request[v1.RPopRequest]) (*connect.Response[v1.RPopResponse], error)
+	LRange(context.Context, *connect.Request[v1.LRangeRequest]) (*connect.Response[v1.LRangeResponse], error)
+	LLen(context.Context, *connect.Request[v1.LLenRequest]) (*connect.Response[v1.LLenResponse], error)
+	BPop(context.Context, *connect.Request[v1.BPopRequest]) (*connect.Response[v1.BPopResponse], error)
+	ZAdd(context.Context, *connect.Request[v1.ZAddRequest]) (*connect.Response[v1.ZAddResponse], error)
+	ZRem(context.Context, *connect.Request[v1.ZRemRequest]) (*connect.Response[v1.ZRemResponse], error)
+	ZScore(context.Context, *connect.Request[v1.ZScoreRequest]) (*connect.Response[v1.ZScoreResponse], error)
+	ZRank(context.Context, *connect.Request[v1.ZRankRequest]) (*connect.Response[v1.ZRankResponse], error)
+	ZRangeByScore(context.Context, *connect.Request[v1.ZRangeByScoreRequest]) (*connect.Response[v1.ZRangeByScoreResponse], error)
+	ZRangeByRank(context.Context, *connect.Request[v1.ZRangeByRankRequest]) (*connect.Response[v1.ZRangeByRankResponse], error)
+	SAdd(context.Context, *connect.Request[v1.SAddRequest]) (*connect.Response[v1.SAddResponse], error)
+	SRem(context.Context, *connect.Request[v1.SRemRequest]) (*connect.Response[v1.SRemResponse], error)
+	SIsMember(context.Context, *connect.Request[v1.SIsMemberRequest]) (*connect.Response[v1.SIsMemberResponse], error)
+	SMembers(context.Context, *connect.Request[v1.SMembersRequest]) (*connect.Response[v1.SMembersResponse], error)
+	SCard(context.Context, *connect.Request[v1.SCardRequest]) (*connect.Response[v1.SCardResponse], error)
+	SUnion(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error)
+	SInter(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error)
+	SDiff(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error)
+	Transaction(context.Context, *connect.Request[v1.TransactionRequest]) (*connect.Response[v1.TransactionResponse], error)
+	BatchSet(context.Context, *connect.Request[v1.BatchSetRequest]) (*connect.Response[v1.BatchSetResponse], error)
+	BatchDelete(context.Context, *connect.Request[v1.BatchDeleteRequest]) (*connect.Response[v1.BatchDeleteResponse], error)
+	Export(context.Context, *connect.Request[v1.ExportRequest]) (*connect.ServerStreamForClient[v1.EntryRecord], error)
+	Import(context.Context) *connect.ClientStreamForClient[v1.EntryRecord, v1.ImportResponse]
+	Stats(context.Context, *connect.Request[v1.StatsRequest]) (*connect.Response[v1.StatsResponse], error)
+	ClusterInfo(context.Context, *connect.Request[v1.ClusterInfoRequest]) (*connect.Response[v1.ClusterInfoResponse], error)
+	Replicate(context.Context, *connect.Request[v1.ReplicateRequest]) (*connect.ServerStreamForClient[v1.ReplicationEvent], error)
+	ReplicationStatus(context.Context, *connect.Request[v1.ReplicationStatusRequest]) (*connect.Response[v1.ReplicationStatusResponse], error)
+	RaftStatus(context.Context, *connect.Request[v1.RaftStatusRequest]) (*connect.Response[v1.RaftStatusResponse], error)
+	Members(context.Context, *connect.Request[v1.MembersRequest]) (*connect.Response[v1.MembersResponse], error)
+	Invalidations(context.Context, *connect.Request[v1.InvalidationsRequest]) (*connect.ServerStreamForClient[v1.InvalidationEvent], error)
 }
 
 // NewCacheServiceClient constructs a client for the stache.v1.CacheService service. By default, it
@@ -96,16 +227,303 @@ func NewCacheServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(cacheServiceMethods.ByName("BatchGet")),
 			connect.WithClientOptions(opts...),
 		),
+		touch: connect.NewClient[v1.TouchRequest, v1.TouchResponse](
+			httpClient,
+			baseURL+CacheServiceTouchProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("Touch")),
+			connect.WithClientOptions(opts...),
+		),
+		getTTL: connect.NewClient[v1.GetTTLRequest, v1.GetTTLResponse](
+			httpClient,
+			baseURL+CacheServiceGetTTLProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("GetTTL")),
+			connect.WithClientOptions(opts...),
+		),
+		invalidateTags: connect.NewClient[v1.InvalidateTagsRequest, v1.InvalidateTagsResponse](
+			httpClient,
+			baseURL+CacheServiceInvalidateTagsProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("InvalidateTags")),
+			connect.WithClientOptions(opts...),
+		),
+		hSet: connect.NewClient[v1.HSetRequest, v1.HSetResponse](
+			httpClient,
+			baseURL+CacheServiceHSetProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("HSet")),
+			connect.WithClientOptions(opts...),
+		),
+		hGet: connect.NewClient[v1.HGetRequest, v1.HGetResponse](
+			httpClient,
+			baseURL+CacheServiceHGetProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("HGet")),
+			connect.WithClientOptions(opts...),
+		),
+		hDel: connect.NewClient[v1.HDelRequest, v1.HDelResponse](
+			httpClient,
+			baseURL+CacheServiceHDelProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("HDel")),
+			connect.WithClientOptions(opts...),
+		),
+		hGetAll: connect.NewClient[v1.HGetAllRequest, v1.HGetAllResponse](
+			httpClient,
+			baseURL+CacheServiceHGetAllProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("HGetAll")),
+			connect.WithClientOptions(opts...),
+		),
+		hIncrBy: connect.NewClient[v1.HIncrByRequest, v1.HIncrByResponse](
+			httpClient,
+			baseURL+CacheServiceHIncrByProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("HIncrBy")),
+			connect.WithClientOptions(opts...),
+		),
+		lPush: connect.NewClient[v1.LPushRequest, v1.LPushResponse](
+			httpClient,
+			baseURL+CacheServiceLPushProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("LPush")),
+			connect.WithClientOptions(opts...),
+		),
+		rPush: connect.NewClient[v1.RPushRequest, v1.RPushResponse](
+			httpClient,
+			baseURL+CacheServiceRPushProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("RPush")),
+			connect.WithClientOptions(opts...),
+		),
+		lPop: connect.NewClient[v1.LPopRequest, v1.LPopResponse](
+			httpClient,
+			baseURL+CacheServiceLPopProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("LPop")),
+			connect.WithClientOptions(opts...),
+		),
+		rPop: connect.NewClient[v1.RPopRequest, v1.RPopResponse](
+			httpClient,
+			baseURL+CacheServiceRPopProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("RPop")),
+			connect.WithClientOptions(opts...),
+		),
+		lRange: connect.NewClient[v1.LRangeRequest, v1.LRangeResponse](
+			httpClient,
+			baseURL+CacheServiceLRangeProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("LRange")),
+			connect.WithClientOptions(opts...),
+		),
+		lLen: connect.NewClient[v1.LLenRequest, v1.LLenResponse](
+			httpClient,
+			baseURL+CacheServiceLLenProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("LLen")),
+			connect.WithClientOptions(opts...),
+		),
+		bPop: connect.NewClient[v1.BPopRequest, v1.BPopResponse](
+			httpClient,
+			baseURL+CacheServiceBPopProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("BPop")),
+			connect.WithClientOptions(opts...),
+		),
+		zAdd: connect.NewClient[v1.ZAddRequest, v1.ZAddResponse](
+			httpClient,
+			baseURL+CacheServiceZAddProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("ZAdd")),
+			connect.WithClientOptions(opts...),
+		),
+		zRem: connect.NewClient[v1.ZRemRequest, v1.ZRemResponse](
+			httpClient,
+			baseURL+CacheServiceZRemProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("ZRem")),
+			connect.WithClientOptions(opts...),
+		),
+		zScore: connect.NewClient[v1.ZScoreRequest, v1.ZScoreResponse](
+			httpClient,
+			baseURL+CacheServiceZScoreProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("ZScore")),
+			connect.WithClientOptions(opts...),
+		),
+		zRank: connect.NewClient[v1.ZRankRequest, v1.ZRankResponse](
+			httpClient,
+			baseURL+CacheServiceZRankProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("ZRank")),
+			connect.WithClientOptions(opts...),
+		),
+		zRangeByScore: connect.NewClient[v1.ZRangeByScoreRequest, v1.ZRangeByScoreResponse](
+			httpClient,
+			baseURL+CacheServiceZRangeByScoreProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("ZRangeByScore")),
+			connect.WithClientOptions(opts...),
+		),
+		zRangeByRank: connect.NewClient[v1.ZRangeByRankRequest, v1.ZRangeByRankResponse](
+			httpClient,
+			baseURL+CacheServiceZRangeByRankProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("ZRangeByRank")),
+			connect.WithClientOptions(opts...),
+		),
+		sAdd: connect.NewClient[v1.SAddRequest, v1.SAddResponse](
+			httpClient,
+			baseURL+CacheServiceSAddProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("SAdd")),
+			connect.WithClientOptions(opts...),
+		),
+		sRem: connect.NewClient[v1.SRemRequest, v1.SRemResponse](
+			httpClient,
+			baseURL+CacheServiceSRemProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("SRem")),
+			connect.WithClientOptions(opts...),
+		),
+		sIsMember: connect.NewClient[v1.SIsMemberRequest, v1.SIsMemberResponse](
+			httpClient,
+			baseURL+CacheServiceSIsMemberProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("SIsMember")),
+			connect.WithClientOptions(opts...),
+		),
+		sMembers: connect.NewClient[v1.SMembersRequest, v1.SMembersResponse](
+			httpClient,
+			baseURL+CacheServiceSMembersProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("SMembers")),
+			connect.WithClientOptions(opts...),
+		),
+		sCard: connect.NewClient[v1.SCardRequest, v1.SCardResponse](
+			httpClient,
+			baseURL+CacheServiceSCardProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("SCard")),
+			connect.WithClientOptions(opts...),
+		),
+		sUnion: connect.NewClient[v1.SetAlgebraRequest, v1.SetAlgebraResponse](
+			httpClient,
+			baseURL+CacheServiceSUnionProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("SUnion")),
+			connect.WithClientOptions(opts...),
+		),
+		sInter: connect.NewClient[v1.SetAlgebraRequest, v1.SetAlgebraResponse](
+			httpClient,
+			baseURL+CacheServiceSInterProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("SInter")),
+			connect.WithClientOptions(opts...),
+		),
+		sDiff: connect.NewClient[v1.SetAlgebraRequest, v1.SetAlgebraResponse](
+			httpClient,
+			baseURL+CacheServiceSDiffProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("SDiff")),
+			connect.WithClientOptions(opts...),
+		),
+		transaction: connect.NewClient[v1.TransactionRequest, v1.TransactionResponse](
+			httpClient,
+			baseURL+CacheServiceTransactionProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("Transaction")),
+			connect.WithClientOptions(opts...),
+		),
+		batchSet: connect.NewClient[v1.BatchSetRequest, v1.BatchSetResponse](
+			httpClient,
+			baseURL+CacheServiceBatchSetProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("BatchSet")),
+			connect.WithClientOptions(opts...),
+		),
+		batchDelete: connect.NewClient[v1.BatchDeleteRequest, v1.BatchDeleteResponse](
+			httpClient,
+			baseURL+CacheServiceBatchDeleteProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("BatchDelete")),
+			connect.WithClientOptions(opts...),
+		),
+		export: connect.NewClient[v1.ExportRequest, v1.EntryRecord](
+			httpClient,
+			baseURL+CacheServiceExportProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("Export")),
+			connect.WithClientOptions(opts...),
+		),
+		_import: connect.NewClient[v1.EntryRecord, v1.ImportResponse](
+			httpClient,
+			baseURL+CacheServiceImportProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("Import")),
+			connect.WithClientOptions(opts...),
+		),
+		stats: connect.NewClient[v1.StatsRequest, v1.StatsResponse](
+			httpClient,
+			baseURL+CacheServiceStatsProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("Stats")),
+			connect.WithClientOptions(opts...),
+		),
+		clusterInfo: connect.NewClient[v1.ClusterInfoRequest, v1.ClusterInfoResponse](
+			httpClient,
+			baseURL+CacheServiceClusterInfoProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("ClusterInfo")),
+			connect.WithClientOptions(opts...),
+		),
+		replicate: connect.NewClient[v1.ReplicateRequest, v1.ReplicationEvent](
+			httpClient,
+			baseURL+CacheServiceReplicateProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("Replicate")),
+			connect.WithClientOptions(opts...),
+		),
+		replicationStatus: connect.NewClient[v1.ReplicationStatusRequest, v1.ReplicationStatusResponse](
+			httpClient,
+			baseURL+CacheServiceReplicationStatusProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("ReplicationStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		raftStatus: connect.NewClient[v1.RaftStatusRequest, v1.RaftStatusResponse](
+			httpClient,
+			baseURL+CacheServiceRaftStatusProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("RaftStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		members: connect.NewClient[v1.MembersRequest, v1.MembersResponse](
+			httpClient,
+			baseURL+CacheServiceMembersProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("Members")),
+			connect.WithClientOptions(opts...),
+		),
+		invalidations: connect.NewClient[v1.InvalidationsRequest, v1.InvalidationEvent](
+			httpClient,
+			baseURL+CacheServiceInvalidationsProcedure,
+			connect.WithSchema(cacheServiceMethods.ByName("Invalidations")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // cacheServiceClient implements CacheServiceClient.
 type cacheServiceClient struct {
-	set         *connect.Client[v1.SetRequest, v1.SetResponse]
-	get         *connect.Client[v1.GetRequest, v1.GetResponse]
-	delete      *connect.Client[v1.DeleteRequest, v1.DeleteResponse]
-	listEntries *connect.Client[v1.ListEntriesRequest, v1.ListEntriesResponse]
-	batchGet    *connect.Client[v1.BatchGetRequest, v1.BatchGetResponse]
+	set               *connect.Client[v1.SetRequest, v1.SetResponse]
+	get               *connect.Client[v1.GetRequest, v1.GetResponse]
+	delete            *connect.Client[v1.DeleteRequest, v1.DeleteResponse]
+	listEntries       *connect.Client[v1.ListEntriesRequest, v1.ListEntriesResponse]
+	batchGet          *connect.Client[v1.BatchGetRequest, v1.BatchGetResponse]
+	touch             *connect.Client[v1.TouchRequest, v1.TouchResponse]
+	getTTL            *connect.Client[v1.GetTTLRequest, v1.GetTTLResponse]
+	invalidateTags    *connect.Client[v1.InvalidateTagsRequest, v1.InvalidateTagsResponse]
+	hSet              *connect.Client[v1.HSetRequest, v1.HSetResponse]
+	hGet              *connect.Client[v1.HGetRequest, v1.HGetResponse]
+	hDel              *connect.Client[v1.HDelRequest, v1.HDelResponse]
+	hGetAll           *connect.Client[v1.HGetAllRequest, v1.HGetAllResponse]
+	hIncrBy           *connect.Client[v1.HIncrByRequest, v1.HIncrByResponse]
+	lPush             *connect.Client[v1.LPushRequest, v1.LPushResponse]
+	rPush             *connect.Client[v1.RPushRequest, v1.RPushResponse]
+	lPop              *connect.Client[v1.LPopRequest, v1.LPopResponse]
+	rPop              *connect.Client[v1.RPopRequest, v1.RPopResponse]
+	lRange            *connect.Client[v1.LRangeRequest, v1.LRangeResponse]
+	lLen              *connect.Client[v1.LLenRequest, v1.LLenResponse]
+	bPop              *connect.Client[v1.BPopRequest, v1.BPopResponse]
+	zAdd              *connect.Client[v1.ZAddRequest, v1.ZAddResponse]
+	zRem              *connect.Client[v1.ZRemRequest, v1.ZRemResponse]
+	zScore            *connect.Client[v1.ZScoreRequest, v1.ZScoreResponse]
+	zRank             *connect.Client[v1.ZRankRequest, v1.ZRankResponse]
+	zRangeByScore     *connect.Client[v1.ZRangeByScoreRequest, v1.ZRangeByScoreResponse]
+	zRangeByRank      *connect.Client[v1.ZRangeByRankRequest, v1.ZRangeByRankResponse]
+	sAdd              *connect.Client[v1.SAddRequest, v1.SAddResponse]
+	sRem              *connect.Client[v1.SRemRequest, v1.SRemResponse]
+	sIsMember         *connect.Client[v1.SIsMemberRequest, v1.SIsMemberResponse]
+	sMembers          *connect.Client[v1.SMembersRequest, v1.SMembersResponse]
+	sCard             *connect.Client[v1.SCardRequest, v1.SCardResponse]
+	sUnion            *connect.Client[v1.SetAlgebraRequest, v1.SetAlgebraResponse]
+	sInter            *connect.Client[v1.SetAlgebraRequest, v1.SetAlgebraResponse]
+	sDiff             *connect.Client[v1.SetAlgebraRequest, v1.SetAlgebraResponse]
+	transaction       *connect.Client[v1.TransactionRequest, v1.TransactionResponse]
+	batchSet          *connect.Client[v1.BatchSetRequest, v1.BatchSetResponse]
+	batchDelete       *connect.Client[v1.BatchDeleteRequest, v1.BatchDeleteResponse]
+	export            *connect.Client[v1.ExportRequest, v1.EntryRecord]
+	_import           *connect.Client[v1.EntryRecord, v1.ImportResponse]
+	stats             *connect.Client[v1.StatsRequest, v1.StatsResponse]
+	clusterInfo       *connect.Client[v1.ClusterInfoRequest, v1.ClusterInfoResponse]
+	replicate         *connect.Client[v1.ReplicateRequest, v1.ReplicationEvent]
+	replicationStatus *connect.Client[v1.ReplicationStatusRequest, v1.ReplicationStatusResponse]
+	raftStatus        *connect.Client[v1.RaftStatusRequest, v1.RaftStatusResponse]
+	members           *connect.Client[v1.MembersRequest, v1.MembersResponse]
+	invalidations     *connect.Client[v1.InvalidationsRequest, v1.InvalidationEvent]
 }
 
 // Set calls stache.v1.CacheService.Set.
@@ -133,6 +551,211 @@ func (c *cacheServiceClient) BatchGet(ctx context.Context, req *connect.Request[
 	return c.batchGet.CallUnary(ctx, req)
 }
 
+// Touch calls stache.v1.CacheService.Touch.
+func (c *cacheServiceClient) Touch(ctx context.Context, req *connect.Request[v1.TouchRequest]) (*connect.Response[v1.TouchResponse], error) {
+	return c.touch.CallUnary(ctx, req)
+}
+
+// GetTTL calls stache.v1.CacheService.GetTTL.
+func (c *cacheServiceClient) GetTTL(ctx context.Context, req *connect.Request[v1.GetTTLRequest]) (*connect.Response[v1.GetTTLResponse], error) {
+	return c.getTTL.CallUnary(ctx, req)
+}
+
+// InvalidateTags calls stache.v1.CacheService.InvalidateTags.
+func (c *cacheServiceClient) InvalidateTags(ctx context.Context, req *connect.Request[v1.InvalidateTagsRequest]) (*connect.Response[v1.InvalidateTagsResponse], error) {
+	return c.invalidateTags.CallUnary(ctx, req)
+}
+
+// HSet calls stache.v1.CacheService.HSet.
+func (c *cacheServiceClient) HSet(ctx context.Context, req *connect.Request[v1.HSetRequest]) (*connect.Response[v1.HSetResponse], error) {
+	return c.hSet.CallUnary(ctx, req)
+}
+
+// HGet calls stache.v1.CacheService.HGet.
+func (c *cacheServiceClient) HGet(ctx context.Context, req *connect.Request[v1.HGetRequest]) (*connect.Response[v1.HGetResponse], error) {
+	return c.hGet.CallUnary(ctx, req)
+}
+
+// HDel calls stache.v1.CacheService.HDel.
+func (c *cacheServiceClient) HDel(ctx context.Context, req *connect.Request[v1.HDelRequest]) (*connect.Response[v1.HDelResponse], error) {
+	return c.hDel.CallUnary(ctx, req)
+}
+
+// HGetAll calls stache.v1.CacheService.HGetAll.
+func (c *cacheServiceClient) HGetAll(ctx context.Context, req *connect.Request[v1.HGetAllRequest]) (*connect.Response[v1.HGetAllResponse], error) {
+	return c.hGetAll.CallUnary(ctx, req)
+}
+
+// HIncrBy calls stache.v1.CacheService.HIncrBy.
+func (c *cacheServiceClient) HIncrBy(ctx context.Context, req *connect.Request[v1.HIncrByRequest]) (*connect.Response[v1.HIncrByResponse], error) {
+	return c.hIncrBy.CallUnary(ctx, req)
+}
+
+// LPush calls stache.v1.CacheService.LPush.
+func (c *cacheServiceClient) LPush(ctx context.Context, req *connect.Request[v1.LPushRequest]) (*connect.Response[v1.LPushResponse], error) {
+	return c.lPush.CallUnary(ctx, req)
+}
+
+// RPush calls stache.v1.CacheService.RPush.
+func (c *cacheServiceClient) RPush(ctx context.Context, req *connect.Request[v1.RPushRequest]) (*connect.Response[v1.RPushResponse], error) {
+	return c.rPush.CallUnary(ctx, req)
+}
+
+// LPop calls stache.v1.CacheService.LPop.
+func (c *cacheServiceClient) LPop(ctx context.Context, req *connect.Request[v1.LPopRequest]) (*connect.Response[v1.LPopResponse], error) {
+	return c.lPop.CallUnary(ctx, req)
+}
+
+// RPop calls stache.v1.CacheService.RPop.
+func (c *cacheServiceClient) RPop(ctx context.Context, req *connect.Request[v1.RPopRequest]) (*connect.Response[v1.RPopResponse], error) {
+	return c.rPop.CallUnary(ctx, req)
+}
+
+// LRange calls stache.v1.CacheService.LRange.
+func (c *cacheServiceClient) LRange(ctx context.Context, req *connect.Request[v1.LRangeRequest]) (*connect.Response[v1.LRangeResponse], error) {
+	return c.lRange.CallUnary(ctx, req)
+}
+
+// LLen calls stache.v1.CacheService.LLen.
+func (c *cacheServiceClient) LLen(ctx context.Context, req *connect.Request[v1.LLenRequest]) (*connect.Response[v1.LLenResponse], error) {
+	return c.lLen.CallUnary(ctx, req)
+}
+
+// BPop calls stache.v1.CacheService.BPop.
+func (c *cacheServiceClient) BPop(ctx context.Context, req *connect.Request[v1.BPopRequest]) (*connect.Response[v1.BPopResponse], error) {
+	return c.bPop.CallUnary(ctx, req)
+}
+
+// ZAdd calls stache.v1.CacheService.ZAdd.
+func (c *cacheServiceClient) ZAdd(ctx context.Context, req *connect.Request[v1.ZAddRequest]) (*connect.Response[v1.ZAddResponse], error) {
+	return c.zAdd.CallUnary(ctx, req)
+}
+
+// ZRem calls stache.v1.CacheService.ZRem.
+func (c *cacheServiceClient) ZRem(ctx context.Context, req *connect.Request[v1.ZRemRequest]) (*connect.Response[v1.ZRemResponse], error) {
+	return c.zRem.CallUnary(ctx, req)
+}
+
+// ZScore calls stache.v1.CacheService.ZScore.
+func (c *cacheServiceClient) ZScore(ctx context.Context, req *connect.Request[v1.ZScoreRequest]) (*connect.Response[v1.ZScoreResponse], error) {
+	return c.zScore.CallUnary(ctx, req)
+}
+
+// ZRank calls stache.v1.CacheService.ZRank.
+func (c *cacheServiceClient) ZRank(ctx context.Context, req *connect.Request[v1.ZRankRequest]) (*connect.Response[v1.ZRankResponse], error) {
+	return c.zRank.CallUnary(ctx, req)
+}
+
+// ZRangeByScore calls stache.v1.CacheService.ZRangeByScore.
+func (c *cacheServiceClient) ZRangeByScore(ctx context.Context, req *connect.Request[v1.ZRangeByScoreRequest]) (*connect.Response[v1.ZRangeByScoreResponse], error) {
+	return c.zRangeByScore.CallUnary(ctx, req)
+}
+
+// ZRangeByRank calls stache.v1.CacheService.ZRangeByRank.
+func (c *cacheServiceClient) ZRangeByRank(ctx context.Context, req *connect.Request[v1.ZRangeByRankRequest]) (*connect.Response[v1.ZRangeByRankResponse], error) {
+	return c.zRangeByRank.CallUnary(ctx, req)
+}
+
+// SAdd calls stache.v1.CacheService.SAdd.
+func (c *cacheServiceClient) SAdd(ctx context.Context, req *connect.Request[v1.SAddRequest]) (*connect.Response[v1.SAddResponse], error) {
+	return c.sAdd.CallUnary(ctx, req)
+}
+
+// SRem calls stache.v1.CacheService.SRem.
+func (c *cacheServiceClient) SRem(ctx context.Context, req *connect.Request[v1.SRemRequest]) (*connect.Response[v1.SRemResponse], error) {
+	return c.sRem.CallUnary(ctx, req)
+}
+
+// SIsMember calls stache.v1.CacheService.SIsMember.
+func (c *cacheServiceClient) SIsMember(ctx context.Context, req *connect.Request[v1.SIsMemberRequest]) (*connect.Response[v1.SIsMemberResponse], error) {
+	return c.sIsMember.CallUnary(ctx, req)
+}
+
+// SMembers calls stache.v1.CacheService.SMembers.
+func (c *cacheServiceClient) SMembers(ctx context.Context, req *connect.Request[v1.SMembersRequest]) (*connect.Response[v1.SMembersResponse], error) {
+	return c.sMembers.CallUnary(ctx, req)
+}
+
+// SCard calls stache.v1.CacheService.SCard.
+func (c *cacheServiceClient) SCard(ctx context.Context, req *connect.Request[v1.SCardRequest]) (*connect.Response[v1.SCardResponse], error) {
+	return c.sCard.CallUnary(ctx, req)
+}
+
+// SUnion calls stache.v1.CacheService.SUnion.
+func (c *cacheServiceClient) SUnion(ctx context.Context, req *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error) {
+	return c.sUnion.CallUnary(ctx, req)
+}
+
+// SInter calls stache.v1.CacheService.SInter.
+func (c *cacheServiceClient) SInter(ctx context.Context, req *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error) {
+	return c.sInter.CallUnary(ctx, req)
+}
+
+// SDiff calls stache.v1.CacheService.SDiff.
+func (c *cacheServiceClient) SDiff(ctx context.Context, req *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error) {
+	return c.sDiff.CallUnary(ctx, req)
+}
+
+// Transaction calls stache.v1.CacheService.Transaction.
+func (c *cacheServiceClient) Transaction(ctx context.Context, req *connect.Request[v1.TransactionRequest]) (*connect.Response[v1.TransactionResponse], error) {
+	return c.transaction.CallUnary(ctx, req)
+}
+
+// BatchSet calls stache.v1.CacheService.BatchSet.
+func (c *cacheServiceClient) BatchSet(ctx context.Context, req *connect.Request[v1.BatchSetRequest]) (*connect.Response[v1.BatchSetResponse], error) {
+	return c.batchSet.CallUnary(ctx, req)
+}
+
+// BatchDelete calls stache.v1.CacheService.BatchDelete.
+func (c *cacheServiceClient) BatchDelete(ctx context.Context, req *connect.Request[v1.BatchDeleteRequest]) (*connect.Response[v1.BatchDeleteResponse], error) {
+	return c.batchDelete.CallUnary(ctx, req)
+}
+
+// Export calls stache.v1.CacheService.Export.
+func (c *cacheServiceClient) Export(ctx context.Context, req *connect.Request[v1.ExportRequest]) (*connect.ServerStreamForClient[v1.EntryRecord], error) {
+	return c.export.CallServerStream(ctx, req)
+}
+
+// Import calls stache.v1.CacheService.Import.
+func (c *cacheServiceClient) Import(ctx context.Context) *connect.ClientStreamForClient[v1.EntryRecord, v1.ImportResponse] {
+	return c._import.CallClientStream(ctx)
+}
+
+// Stats calls stache.v1.CacheService.Stats.
+func (c *cacheServiceClient) Stats(ctx context.Context, req *connect.Request[v1.StatsRequest]) (*connect.Response[v1.StatsResponse], error) {
+	return c.stats.CallUnary(ctx, req)
+}
+
+// ClusterInfo calls stache.v1.CacheService.ClusterInfo.
+func (c *cacheServiceClient) ClusterInfo(ctx context.Context, req *connect.Request[v1.ClusterInfoRequest]) (*connect.Response[v1.ClusterInfoResponse], error) {
+	return c.clusterInfo.CallUnary(ctx, req)
+}
+
+// Replicate calls stache.v1.CacheService.Replicate.
+func (c *cacheServiceClient) Replicate(ctx context.Context, req *connect.Request[v1.ReplicateRequest]) (*connect.ServerStreamForClient[v1.ReplicationEvent], error) {
+	return c.replicate.CallServerStream(ctx, req)
+}
+
+// ReplicationStatus calls stache.v1.CacheService.ReplicationStatus.
+func (c *cacheServiceClient) ReplicationStatus(ctx context.Context, req *connect.Request[v1.ReplicationStatusRequest]) (*connect.Response[v1.ReplicationStatusResponse], error) {
+	return c.replicationStatus.CallUnary(ctx, req)
+}
+
+// RaftStatus calls stache.v1.CacheService.RaftStatus.
+func (c *cacheServiceClient) RaftStatus(ctx context.Context, req *connect.Request[v1.RaftStatusRequest]) (*connect.Response[v1.RaftStatusResponse], error) {
+	return c.raftStatus.CallUnary(ctx, req)
+}
+
+// Members calls stache.v1.CacheService.Members.
+func (c *cacheServiceClient) Members(ctx context.Context, req *connect.Request[v1.MembersRequest]) (*connect.Response[v1.MembersResponse], error) {
+	return c.members.CallUnary(ctx, req)
+}
+
+// Invalidations calls stache.v1.CacheService.Invalidations.
+func (c *cacheServiceClient) Invalidations(ctx context.Context, req *connect.Request[v1.InvalidationsRequest]) (*connect.ServerStreamForClient[v1.InvalidationEvent], error) {
+	return c.invalidations.CallServerStream(ctx, req)
+}
+
 // CacheServiceHandler is an implementation of the stache.v1.CacheService service.
 type CacheServiceHandler interface {
 	Set(context.Context, *connect.Request[v1.SetRequest]) (*connect.Response[v1.SetResponse], error)
@@ -140,6 +763,47 @@ type CacheServiceHandler interface {
 	Delete(context.Context, *connect.Request[v1.DeleteRequest]) (*connect.Response[v1.DeleteResponse], error)
 	ListEntries(context.Context, *connect.Request[v1.ListEntriesRequest]) (*connect.Response[v1.ListEntriesResponse], error)
 	BatchGet(context.Context, *connect.Request[v1.BatchGetRequest]) (*connect.Response[v1.BatchGetResponse], error)
+	Touch(context.Context, *connect.Request[v1.TouchRequest]) (*connect.Response[v1.TouchResponse], error)
+	GetTTL(context.Context, *connect.Request[v1.GetTTLRequest]) (*connect.Response[v1.GetTTLResponse], error)
+	InvalidateTags(context.Context, *connect.Request[v1.InvalidateTagsRequest]) (*connect.Response[v1.InvalidateTagsResponse], error)
+	HSet(context.Context, *connect.Request[v1.HSetRequest]) (*connect.Response[v1.HSetResponse], error)
+	HGet(context.Context, *connect.Request[v1.HGetRequest]) (*connect.Response[v1.HGetResponse], error)
+	HDel(context.Context, *connect.Request[v1.HDelRequest]) (*connect.Response[v1.HDelResponse], error)
+	HGetAll(context.Context, *connect.Request[v1.HGetAllRequest]) (*connect.Response[v1.HGetAllResponse], error)
+	HIncrBy(context.Context, *connect.Request[v1.HIncrByRequest]) (*connect.Response[v1.HIncrByResponse], error)
+	LPush(context.Context, *connect.Request[v1.LPushRequest]) (*connect.Response[v1.LPushResponse], error)
+	RPush(context.Context, *connect.Request[v1.RPushRequest]) (*connect.Response[v1.RPushResponse], error)
+	LPop(context.Context, *connect.Request[v1.LPopRequest]) (*connect.Response[v1.LPopResponse], error)
+	RPop(context.Context, *connect.Request[v1.RPopRequest]) (*connect.Response[v1.RPopResponse], error)
+	LRange(context.Context, *connect.Request[v1.LRangeRequest]) (*connect.Response[v1.LRangeResponse], error)
+	LLen(context.Context, *connect.Request[v1.LLenRequest]) (*connect.Response[v1.LLenResponse], error)
+	BPop(context.Context, *connect.Request[v1.BPopRequest]) (*connect.Response[v1.BPopResponse], error)
+	ZAdd(context.Context, *connect.Request[v1.ZAddRequest]) (*connect.Response[v1.ZAddResponse], error)
+	ZRem(context.Context, *connect.Request[v1.ZRemRequest]) (*connect.Response[v1.ZRemResponse], error)
+	ZScore(context.Context, *connect.Request[v1.ZScoreRequest]) (*connect.Response[v1.ZScoreResponse], error)
+	ZRank(context.Context, *connect.Request[v1.ZRankRequest]) (*connect.Response[v1.ZRankResponse], error)
+	ZRangeByScore(context.Context, *connect.Request[v1.ZRangeByScoreRequest]) (*connect.Response[v1.ZRangeByScoreResponse], error)
+	ZRangeByRank(context.Context, *connect.Request[v1.ZRangeByRankRequest]) (*connect.Response[v1.ZRangeByRankResponse], error)
+	SAdd(context.Context, *connect.Request[v1.SAddRequest]) (*connect.Response[v1.SAddResponse], error)
+	SRem(context.Context, *connect.Request[v1.SRemRequest]) (*connect.Response[v1.SRemResponse], error)
+	SIsMember(context.Context, *connect.Request[v1.SIsMemberRequest]) (*connect.Response[v1.SIsMemberResponse], error)
+	SMembers(context.Context, *connect.Request[v1.SMembersRequest]) (*connect.Response[v1.SMembersResponse], error)
+	SCard(context.Context, *connect.Request[v1.SCardRequest]) (*connect.Response[v1.SCardResponse], error)
+	SUnion(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error)
+	SInter(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error)
+	SDiff(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error)
+	Transaction(context.Context, *connect.Request[v1.TransactionRequest]) (*connect.Response[v1.TransactionResponse], error)
+	BatchSet(context.Context, *connect.Request[v1.BatchSetRequest]) (*connect.Response[v1.BatchSetResponse], error)
+	BatchDelete(context.Context, *connect.Request[v1.BatchDeleteRequest]) (*connect.Response[v1.BatchDeleteResponse], error)
+	Export(context.Context, *connect.Request[v1.ExportRequest], *connect.ServerStream[v1.EntryRecord]) error
+	Import(context.Context, *connect.ClientStream[v1.EntryRecord]) (*connect.Response[v1.ImportResponse], error)
+	Stats(context.Context, *connect.Request[v1.StatsRequest]) (*connect.Response[v1.StatsResponse], error)
+	ClusterInfo(context.Context, *connect.Request[v1.ClusterInfoRequest]) (*connect.Response[v1.ClusterInfoResponse], error)
+	Replicate(context.Context, *connect.Request[v1.ReplicateRequest], *connect.ServerStream[v1.ReplicationEvent]) error
+	ReplicationStatus(context.Context, *connect.Request[v1.ReplicationStatusRequest]) (*connect.Response[v1.ReplicationStatusResponse], error)
+	RaftStatus(context.Context, *connect.Request[v1.RaftStatusRequest]) (*connect.Response[v1.RaftStatusResponse], error)
+	Members(context.Context, *connect.Request[v1.MembersRequest]) (*connect.Response[v1.MembersResponse], error)
+	Invalidations(context.Context, *connect.Request[v1.InvalidationsRequest], *connect.ServerStream[v1.InvalidationEvent]) error
 }
 
 // NewCacheServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -179,6 +843,252 @@ func NewCacheServiceHandler(svc CacheServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(cacheServiceMethods.ByName("BatchGet")),
 		connect.WithHandlerOptions(opts...),
 	)
+	cacheServiceTouchHandler := connect.NewUnaryHandler(
+		CacheServiceTouchProcedure,
+		svc.Touch,
+		connect.WithSchema(cacheServiceMethods.ByName("Touch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceGetTTLHandler := connect.NewUnaryHandler(
+		CacheServiceGetTTLProcedure,
+		svc.GetTTL,
+		connect.WithSchema(cacheServiceMethods.ByName("GetTTL")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceInvalidateTagsHandler := connect.NewUnaryHandler(
+		CacheServiceInvalidateTagsProcedure,
+		svc.InvalidateTags,
+		connect.WithSchema(cacheServiceMethods.ByName("InvalidateTags")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceHSetHandler := connect.NewUnaryHandler(
+		CacheServiceHSetProcedure,
+		svc.HSet,
+		connect.WithSchema(cacheServiceMethods.ByName("HSet")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceHGetHandler := connect.NewUnaryHandler(
+		CacheServiceHGetProcedure,
+		svc.HGet,
+		connect.WithSchema(cacheServiceMethods.ByName("HGet")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceHDelHandler := connect.NewUnaryHandler(
+		CacheServiceHDelProcedure,
+		svc.HDel,
+		connect.WithSchema(cacheServiceMethods.ByName("HDel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceHGetAllHandler := connect.NewUnaryHandler(
+		CacheServiceHGetAllProcedure,
+		svc.HGetAll,
+		connect.WithSchema(cacheServiceMethods.ByName("HGetAll")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceHIncrByHandler := connect.NewUnaryHandler(
+		CacheServiceHIncrByProcedure,
+		svc.HIncrBy,
+		connect.WithSchema(cacheServiceMethods.ByName("HIncrBy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceLPushHandler := connect.NewUnaryHandler(
+		CacheServiceLPushProcedure,
+		svc.LPush,
+		connect.WithSchema(cacheServiceMethods.ByName("LPush")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceRPushHandler := connect.NewUnaryHandler(
+		CacheServiceRPushProcedure,
+		svc.RPush,
+		connect.WithSchema(cacheServiceMethods.ByName("RPush")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceLPopHandler := connect.NewUnaryHandler(
+		CacheServiceLPopProcedure,
+		svc.LPop,
+		connect.WithSchema(cacheServiceMethods.ByName("LPop")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceRPopHandler := connect.NewUnaryHandler(
+		CacheServiceRPopProcedure,
+		svc.RPop,
+		connect.WithSchema(cacheServiceMethods.ByName("RPop")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceLRangeHandler := connect.NewUnaryHandler(
+		CacheServiceLRangeProcedure,
+		svc.LRange,
+		connect.WithSchema(cacheServiceMethods.ByName("LRange")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceLLenHandler := connect.NewUnaryHandler(
+		CacheServiceLLenProcedure,
+		svc.LLen,
+		connect.WithSchema(cacheServiceMethods.ByName("LLen")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceBPopHandler := connect.NewUnaryHandler(
+		CacheServiceBPopProcedure,
+		svc.BPop,
+		connect.WithSchema(cacheServiceMethods.ByName("BPop")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceZAddHandler := connect.NewUnaryHandler(
+		CacheServiceZAddProcedure,
+		svc.ZAdd,
+		connect.WithSchema(cacheServiceMethods.ByName("ZAdd")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceZRemHandler := connect.NewUnaryHandler(
+		CacheServiceZRemProcedure,
+		svc.ZRem,
+		connect.WithSchema(cacheServiceMethods.ByName("ZRem")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceZScoreHandler := connect.NewUnaryHandler(
+		CacheServiceZScoreProcedure,
+		svc.ZScore,
+		connect.WithSchema(cacheServiceMethods.ByName("ZScore")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceZRankHandler := connect.NewUnaryHandler(
+		CacheServiceZRankProcedure,
+		svc.ZRank,
+		connect.WithSchema(cacheServiceMethods.ByName("ZRank")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceZRangeByScoreHandler := connect.NewUnaryHandler(
+		CacheServiceZRangeByScoreProcedure,
+		svc.ZRangeByScore,
+		connect.WithSchema(cacheServiceMethods.ByName("ZRangeByScore")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceZRangeByRankHandler := connect.NewUnaryHandler(
+		CacheServiceZRangeByRankProcedure,
+		svc.ZRangeByRank,
+		connect.WithSchema(cacheServiceMethods.ByName("ZRangeByRank")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceSAddHandler := connect.NewUnaryHandler(
+		CacheServiceSAddProcedure,
+		svc.SAdd,
+		connect.WithSchema(cacheServiceMethods.ByName("SAdd")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceSRemHandler := connect.NewUnaryHandler(
+		CacheServiceSRemProcedure,
+		svc.SRem,
+		connect.WithSchema(cacheServiceMethods.ByName("SRem")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceSIsMemberHandler := connect.NewUnaryHandler(
+		CacheServiceSIsMemberProcedure,
+		svc.SIsMember,
+		connect.WithSchema(cacheServiceMethods.ByName("SIsMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceSMembersHandler := connect.NewUnaryHandler(
+		CacheServiceSMembersProcedure,
+		svc.SMembers,
+		connect.WithSchema(cacheServiceMethods.ByName("SMembers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceSCardHandler := connect.NewUnaryHandler(
+		CacheServiceSCardProcedure,
+		svc.SCard,
+		connect.WithSchema(cacheServiceMethods.ByName("SCard")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceSUnionHandler := connect.NewUnaryHandler(
+		CacheServiceSUnionProcedure,
+		svc.SUnion,
+		connect.WithSchema(cacheServiceMethods.ByName("SUnion")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceSInterHandler := connect.NewUnaryHandler(
+		CacheServiceSInterProcedure,
+		svc.SInter,
+		connect.WithSchema(cacheServiceMethods.ByName("SInter")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceSDiffHandler := connect.NewUnaryHandler(
+		CacheServiceSDiffProcedure,
+		svc.SDiff,
+		connect.WithSchema(cacheServiceMethods.ByName("SDiff")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceTransactionHandler := connect.NewUnaryHandler(
+		CacheServiceTransactionProcedure,
+		svc.Transaction,
+		connect.WithSchema(cacheServiceMethods.ByName("Transaction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceBatchSetHandler := connect.NewUnaryHandler(
+		CacheServiceBatchSetProcedure,
+		svc.BatchSet,
+		connect.WithSchema(cacheServiceMethods.ByName("BatchSet")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceBatchDeleteHandler := connect.NewUnaryHandler(
+		CacheServiceBatchDeleteProcedure,
+		svc.BatchDelete,
+		connect.WithSchema(cacheServiceMethods.ByName("BatchDelete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceExportHandler := connect.NewServerStreamHandler(
+		CacheServiceExportProcedure,
+		svc.Export,
+		connect.WithSchema(cacheServiceMethods.ByName("Export")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceImportHandler := connect.NewClientStreamHandler(
+		CacheServiceImportProcedure,
+		svc.Import,
+		connect.WithSchema(cacheServiceMethods.ByName("Import")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceStatsHandler := connect.NewUnaryHandler(
+		CacheServiceStatsProcedure,
+		svc.Stats,
+		connect.WithSchema(cacheServiceMethods.ByName("Stats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceClusterInfoHandler := connect.NewUnaryHandler(
+		CacheServiceClusterInfoProcedure,
+		svc.ClusterInfo,
+		connect.WithSchema(cacheServiceMethods.ByName("ClusterInfo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceReplicateHandler := connect.NewServerStreamHandler(
+		CacheServiceReplicateProcedure,
+		svc.Replicate,
+		connect.WithSchema(cacheServiceMethods.ByName("Replicate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceReplicationStatusHandler := connect.NewUnaryHandler(
+		CacheServiceReplicationStatusProcedure,
+		svc.ReplicationStatus,
+		connect.WithSchema(cacheServiceMethods.ByName("ReplicationStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceRaftStatusHandler := connect.NewUnaryHandler(
+		CacheServiceRaftStatusProcedure,
+		svc.RaftStatus,
+		connect.WithSchema(cacheServiceMethods.ByName("RaftStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceMembersHandler := connect.NewUnaryHandler(
+		CacheServiceMembersProcedure,
+		svc.Members,
+		connect.WithSchema(cacheServiceMethods.ByName("Members")),
+		connect.WithHandlerOptions(opts...),
+	)
+	cacheServiceInvalidationsHandler := connect.NewServerStreamHandler(
+		CacheServiceInvalidationsProcedure,
+		svc.Invalidations,
+		connect.WithSchema(cacheServiceMethods.ByName("Invalidations")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/stache.v1.CacheService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case CacheServiceSetProcedure:
@@ -191,6 +1101,88 @@ func NewCacheServiceHandler(svc CacheServiceHandler, opts ...connect.HandlerOpti
 			cacheServiceListEntriesHandler.ServeHTTP(w, r)
 		case CacheServiceBatchGetProcedure:
 			cacheServiceBatchGetHandler.ServeHTTP(w, r)
+		case CacheServiceTouchProcedure:
+			cacheServiceTouchHandler.ServeHTTP(w, r)
+		case CacheServiceGetTTLProcedure:
+			cacheServiceGetTTLHandler.ServeHTTP(w, r)
+		case CacheServiceInvalidateTagsProcedure:
+			cacheServiceInvalidateTagsHandler.ServeHTTP(w, r)
+		case CacheServiceHSetProcedure:
+			cacheServiceHSetHandler.ServeHTTP(w, r)
+		case CacheServiceHGetProcedure:
+			cacheServiceHGetHandler.ServeHTTP(w, r)
+		case CacheServiceHDelProcedure:
+			cacheServiceHDelHandler.ServeHTTP(w, r)
+		case CacheServiceHGetAllProcedure:
+			cacheServiceHGetAllHandler.ServeHTTP(w, r)
+		case CacheServiceHIncrByProcedure:
+			cacheServiceHIncrByHandler.ServeHTTP(w, r)
+		case CacheServiceLPushProcedure:
+			cacheServiceLPushHandler.ServeHTTP(w, r)
+		case CacheServiceRPushProcedure:
+			cacheServiceRPushHandler.ServeHTTP(w, r)
+		case CacheServiceLPopProcedure:
+			cacheServiceLPopHandler.ServeHTTP(w, r)
+		case CacheServiceRPopProcedure:
+			cacheServiceRPopHandler.ServeHTTP(w, r)
+		case CacheServiceLRangeProcedure:
+			cacheServiceLRangeHandler.ServeHTTP(w, r)
+		case CacheServiceLLenProcedure:
+			cacheServiceLLenHandler.ServeHTTP(w, r)
+		case CacheServiceBPopProcedure:
+			cacheServiceBPopHandler.ServeHTTP(w, r)
+		case CacheServiceZAddProcedure:
+			cacheServiceZAddHandler.ServeHTTP(w, r)
+		case CacheServiceZRemProcedure:
+			cacheServiceZRemHandler.ServeHTTP(w, r)
+		case CacheServiceZScoreProcedure:
+			cacheServiceZScoreHandler.ServeHTTP(w, r)
+		case CacheServiceZRankProcedure:
+			cacheServiceZRankHandler.ServeHTTP(w, r)
+		case CacheServiceZRangeByScoreProcedure:
+			cacheServiceZRangeByScoreHandler.ServeHTTP(w, r)
+		case CacheServiceZRangeByRankProcedure:
+			cacheServiceZRangeByRankHandler.ServeHTTP(w, r)
+		case CacheServiceSAddProcedure:
+			cacheServiceSAddHandler.ServeHTTP(w, r)
+		case CacheServiceSRemProcedure:
+			cacheServiceSRemHandler.ServeHTTP(w, r)
+		case CacheServiceSIsMemberProcedure:
+			cacheServiceSIsMemberHandler.ServeHTTP(w, r)
+		case CacheServiceSMembersProcedure:
+			cacheServiceSMembersHandler.ServeHTTP(w, r)
+		case CacheServiceSCardProcedure:
+			cacheServiceSCardHandler.ServeHTTP(w, r)
+		case CacheServiceSUnionProcedure:
+			cacheServiceSUnionHandler.ServeHTTP(w, r)
+		case CacheServiceSInterProcedure:
+			cacheServiceSInterHandler.ServeHTTP(w, r)
+		case CacheServiceSDiffProcedure:
+			cacheServiceSDiffHandler.ServeHTTP(w, r)
+		case CacheServiceTransactionProcedure:
+			cacheServiceTransactionHandler.ServeHTTP(w, r)
+		case CacheServiceBatchSetProcedure:
+			cacheServiceBatchSetHandler.ServeHTTP(w, r)
+		case CacheServiceBatchDeleteProcedure:
+			cacheServiceBatchDeleteHandler.ServeHTTP(w, r)
+		case CacheServiceExportProcedure:
+			cacheServiceExportHandler.ServeHTTP(w, r)
+		case CacheServiceImportProcedure:
+			cacheServiceImportHandler.ServeHTTP(w, r)
+		case CacheServiceStatsProcedure:
+			cacheServiceStatsHandler.ServeHTTP(w, r)
+		case CacheServiceClusterInfoProcedure:
+			cacheServiceClusterInfoHandler.ServeHTTP(w, r)
+		case CacheServiceReplicateProcedure:
+			cacheServiceReplicateHandler.ServeHTTP(w, r)
+		case CacheServiceReplicationStatusProcedure:
+			cacheServiceReplicationStatusHandler.ServeHTTP(w, r)
+		case CacheServiceRaftStatusProcedure:
+			cacheServiceRaftStatusHandler.ServeHTTP(w, r)
+		case CacheServiceMembersProcedure:
+			cacheServiceMembersHandler.ServeHTTP(w, r)
+		case CacheServiceInvalidationsProcedure:
+			cacheServiceInvalidationsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -219,3 +1211,167 @@ func (UnimplementedCacheServiceHandler) ListEntries(context.Context, *connect.Re
 func (UnimplementedCacheServiceHandler) BatchGet(context.Context, *connect.Request[v1.BatchGetRequest]) (*connect.Response[v1.BatchGetResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.BatchGet is not implemented"))
 }
+
+func (UnimplementedCacheServiceHandler) Touch(context.Context, *connect.Request[v1.TouchRequest]) (*connect.Response[v1.TouchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.Touch is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) GetTTL(context.Context, *connect.Request[v1.GetTTLRequest]) (*connect.Response[v1.GetTTLResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.GetTTL is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) InvalidateTags(context.Context, *connect.Request[v1.InvalidateTagsRequest]) (*connect.Response[v1.InvalidateTagsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.InvalidateTags is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) HSet(context.Context, *connect.Request[v1.HSetRequest]) (*connect.Response[v1.HSetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.HSet is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) HGet(context.Context, *connect.Request[v1.HGetRequest]) (*connect.Response[v1.HGetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.HGet is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) HDel(context.Context, *connect.Request[v1.HDelRequest]) (*connect.Response[v1.HDelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.HDel is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) HGetAll(context.Context, *connect.Request[v1.HGetAllRequest]) (*connect.Response[v1.HGetAllResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.HGetAll is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) HIncrBy(context.Context, *connect.Request[v1.HIncrByRequest]) (*connect.Response[v1.HIncrByResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.HIncrBy is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) LPush(context.Context, *connect.Request[v1.LPushRequest]) (*connect.Response[v1.LPushResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.LPush is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) RPush(context.Context, *connect.Request[v1.RPushRequest]) (*connect.Response[v1.RPushResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.RPush is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) LPop(context.Context, *connect.Request[v1.LPopRequest]) (*connect.Response[v1.LPopResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.LPop is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) RPop(context.Context, *connect.Request[v1.RPopRequest]) (*connect.Response[v1.RPopResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.RPop is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) LRange(context.Context, *connect.Request[v1.LRangeRequest]) (*connect.Response[v1.LRangeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.LRange is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) LLen(context.Context, *connect.Request[v1.LLenRequest]) (*connect.Response[v1.LLenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.LLen is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) BPop(context.Context, *connect.Request[v1.BPopRequest]) (*connect.Response[v1.BPopResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.BPop is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) ZAdd(context.Context, *connect.Request[v1.ZAddRequest]) (*connect.Response[v1.ZAddResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.ZAdd is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) ZRem(context.Context, *connect.Request[v1.ZRemRequest]) (*connect.Response[v1.ZRemResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.ZRem is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) ZScore(context.Context, *connect.Request[v1.ZScoreRequest]) (*connect.Response[v1.ZScoreResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.ZScore is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) ZRank(context.Context, *connect.Request[v1.ZRankRequest]) (*connect.Response[v1.ZRankResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.ZRank is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) ZRangeByScore(context.Context, *connect.Request[v1.ZRangeByScoreRequest]) (*connect.Response[v1.ZRangeByScoreResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.ZRangeByScore is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) ZRangeByRank(context.Context, *connect.Request[v1.ZRangeByRankRequest]) (*connect.Response[v1.ZRangeByRankResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.ZRangeByRank is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) SAdd(context.Context, *connect.Request[v1.SAddRequest]) (*connect.Response[v1.SAddResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.SAdd is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) SRem(context.Context, *connect.Request[v1.SRemRequest]) (*connect.Response[v1.SRemResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.SRem is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) SIsMember(context.Context, *connect.Request[v1.SIsMemberRequest]) (*connect.Response[v1.SIsMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.SIsMember is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) SMembers(context.Context, *connect.Request[v1.SMembersRequest]) (*connect.Response[v1.SMembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.SMembers is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) SCard(context.Context, *connect.Request[v1.SCardRequest]) (*connect.Response[v1.SCardResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.SCard is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) SUnion(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.SUnion is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) SInter(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.SInter is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) SDiff(context.Context, *connect.Request[v1.SetAlgebraRequest]) (*connect.Response[v1.SetAlgebraResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.SDiff is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) Transaction(context.Context, *connect.Request[v1.TransactionRequest]) (*connect.Response[v1.TransactionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.Transaction is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) BatchSet(context.Context, *connect.Request[v1.BatchSetRequest]) (*connect.Response[v1.BatchSetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.BatchSet is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) BatchDelete(context.Context, *connect.Request[v1.BatchDeleteRequest]) (*connect.Response[v1.BatchDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.BatchDelete is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) Export(context.Context, *connect.Request[v1.ExportRequest], *connect.ServerStream[v1.EntryRecord]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.Export is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) Import(context.Context, *connect.ClientStream[v1.EntryRecord]) (*connect.Response[v1.ImportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.Import is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) Stats(context.Context, *connect.Request[v1.StatsRequest]) (*connect.Response[v1.StatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.Stats is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) ClusterInfo(context.Context, *connect.Request[v1.ClusterInfoRequest]) (*connect.Response[v1.ClusterInfoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.ClusterInfo is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) Replicate(context.Context, *connect.Request[v1.ReplicateRequest], *connect.ServerStream[v1.ReplicationEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.Replicate is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) ReplicationStatus(context.Context, *connect.Request[v1.ReplicationStatusRequest]) (*connect.Response[v1.ReplicationStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.ReplicationStatus is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) RaftStatus(context.Context, *connect.Request[v1.RaftStatusRequest]) (*connect.Response[v1.RaftStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.RaftStatus is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) Members(context.Context, *connect.Request[v1.MembersRequest]) (*connect.Response[v1.MembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.Members is not implemented"))
+}
+
+func (UnimplementedCacheServiceHandler) Invalidations(context.Context, *connect.Request[v1.InvalidationsRequest], *connect.ServerStream[v1.InvalidationEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stache.v1.CacheService.Invalidations is not implemented"))
+}
@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
 	stachev1 "github.com/byytelope/stache/api/stache/v1"
 	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
 )
@@ -19,12 +22,14 @@ type Handler struct {
 	err    io.Writer
 }
 
-func (h *Handler) Set(key string, value string, contentType string, ttlSeconds int64) error {
+func (h *Handler) Set(key string, value string, contentType string, ttl time.Duration, sliding bool, tags []string) error {
 	req := &stachev1.SetRequest{
 		Key:         &key,
 		Value:       []byte(value),
-		Ttl:         &ttlSeconds,
+		TtlDuration: durationpb.New(ttl),
 		ContentType: &contentType,
+		Sliding:     &sliding,
+		Tags:        tags,
 	}
 	_, err := h.client.Set(context.Background(), connect.NewRequest(req))
 	if err != nil {
@@ -32,7 +37,7 @@ func (h *Handler) Set(key string, value string, contentType string, ttlSeconds i
 		return err
 	}
 
-	fmt.Fprintf(h.out, "OK set key=%q ct=%q ttl=%ds\n", key, contentType, ttlSeconds)
+	fmt.Fprintf(h.out, "OK set key=%q ct=%q ttl=%s\n", key, contentType, ttl)
 	return nil
 }
 
@@ -71,7 +76,7 @@ func (h *Handler) List() error {
 
 	ents := res.Msg.GetEntries()
 	tw := tabwriter.NewWriter(h.out, 2, 4, 2, ' ', 0)
-	fmt.Fprintln(tw, "KEY\tSIZE\tCONTENT-TYPE\tEXPIRES")
+	fmt.Fprintln(tw, "KEY\tSIZE\tCONTENT-TYPE\tEXPIRES\tTAGS")
 
 	for _, e := range ents {
 		exp := "-"
@@ -79,9 +84,86 @@ func (h *Handler) List() error {
 			exp = time.UnixMilli(e.GetExpiresAtMs()).Format(time.RFC3339)
 		}
 
-		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", e.GetKey(), e.GetSize(), e.GetContentType(), exp)
+		tags := "-"
+		if len(e.GetTags()) > 0 {
+			tags = strings.Join(e.GetTags(), ",")
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", e.GetKey(), e.GetSize(), e.GetContentType(), exp, tags)
 	}
 
 	tw.Flush()
 	return nil
 }
+
+func (h *Handler) Touch(key string, ttl time.Duration) error {
+	req := &stachev1.TouchRequest{Key: &key, TtlDuration: durationpb.New(ttl)}
+	_, err := h.client.Touch(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "Touch error:", err)
+		return err
+	}
+
+	if ttl <= 0 {
+		fmt.Fprintf(h.out, "OK persist key=%q\n", key)
+	} else {
+		fmt.Fprintf(h.out, "OK touch key=%q ttl=%s\n", key, ttl)
+	}
+	return nil
+}
+
+func (h *Handler) TTL(key string) error {
+	req := &stachev1.GetTTLRequest{Key: &key}
+	res, err := h.client.GetTTL(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "TTL error:", err)
+		return err
+	}
+
+	if res.Msg.GetExpiresAtMs() == 0 {
+		fmt.Fprintln(h.out, "no expiry")
+		return nil
+	}
+
+	ttl := time.Duration(res.Msg.GetTtlMs()) * time.Millisecond
+	exp := time.UnixMilli(res.Msg.GetExpiresAtMs()).Format(time.RFC3339)
+	fmt.Fprintf(h.out, "%s (expires %s)\n", ttl, exp)
+	return nil
+}
+
+func (h *Handler) InvalidateTags(tags []string) error {
+	req := &stachev1.InvalidateTagsRequest{Tags: tags}
+	res, err := h.client.InvalidateTags(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "InvalidateTags error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "OK invalidated tags=%q removed=%d\n", tags, res.Msg.GetRemoved())
+	return nil
+}
+
+func (h *Handler) Stats() error {
+	res, err := h.client.Stats(context.Background(), connect.NewRequest(&stachev1.StatsRequest{}))
+	if err != nil {
+		fmt.Fprintln(h.err, "Stats error:", err)
+		return err
+	}
+
+	s := res.Msg
+	ratio := "-"
+	if total := s.GetHits() + s.GetMisses(); total > 0 {
+		ratio = fmt.Sprintf("%.1f%%", 100*float64(s.GetHits())/float64(total))
+	}
+
+	tw := tabwriter.NewWriter(h.out, 2, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "entries\t%d\n", s.GetEntries())
+	fmt.Fprintf(tw, "bytes\t%d\n", s.GetBytes())
+	fmt.Fprintf(tw, "hits\t%d\n", s.GetHits())
+	fmt.Fprintf(tw, "misses\t%d\n", s.GetMisses())
+	fmt.Fprintf(tw, "hit ratio\t%s\n", ratio)
+	fmt.Fprintf(tw, "uptime\t%s\n", s.GetUptime().AsDuration().Round(time.Second))
+	tw.Flush()
+
+	return nil
+}
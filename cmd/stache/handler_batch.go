@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+// batchLine is one line of a -batch-set file. Value may be a JSON string,
+// stored as-is, or any other JSON value, stored as its JSON encoding.
+type batchLine struct {
+	Key         string          `json:"key"`
+	Value       json.RawMessage `json:"value"`
+	ContentType string          `json:"content_type"`
+	TTL         string          `json:"ttl"`
+	Sliding     bool            `json:"sliding"`
+	Tags        []string        `json:"tags"`
+}
+
+func (l batchLine) item() (*stachev1.BatchSetItem, error) {
+	item := &stachev1.BatchSetItem{Key: &l.Key, Sliding: &l.Sliding, Tags: l.Tags}
+
+	ct := l.ContentType
+	var s string
+	if err := json.Unmarshal(l.Value, &s); err == nil {
+		item.Value = []byte(s)
+		if ct == "" {
+			ct = "text/plain"
+		}
+	} else {
+		item.Value = bytes.TrimSpace(l.Value)
+		if ct == "" {
+			ct = "application/json"
+		}
+	}
+	item.ContentType = &ct
+
+	if l.TTL != "" {
+		var ttl ttlValue
+		if err := ttl.Set(l.TTL); err != nil {
+			return nil, fmt.Errorf("key %q: bad ttl: %w", l.Key, err)
+		}
+		item.Ttl = durationpb.New(time.Duration(ttl))
+	}
+
+	return item, nil
+}
+
+// openInput opens path for reading, or stdin if path is "-".
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+func (h *Handler) BatchSet(path string) error {
+	f, err := openInput(path)
+	if err != nil {
+		fmt.Fprintln(h.err, "BatchSet error:", err)
+		return err
+	}
+	defer f.Close()
+
+	req := &stachev1.BatchSetRequest{}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 64<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+
+		var l batchLine
+		if err := json.Unmarshal(sc.Bytes(), &l); err != nil {
+			err = fmt.Errorf("%s:%d: %w", path, n, err)
+			fmt.Fprintln(h.err, "BatchSet error:", err)
+			return err
+		}
+
+		item, err := l.item()
+		if err != nil {
+			err = fmt.Errorf("%s:%d: %w", path, n, err)
+			fmt.Fprintln(h.err, "BatchSet error:", err)
+			return err
+		}
+		req.Items = append(req.Items, item)
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintln(h.err, "BatchSet error:", err)
+		return err
+	}
+
+	res, err := h.client.BatchSet(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "BatchSet error:", err)
+		return err
+	}
+
+	failed := 0
+	for _, r := range res.Msg.GetResults() {
+		if !r.GetOk() {
+			failed++
+			fmt.Fprintf(h.err, "FAIL key=%q: %s\n", r.GetKey(), r.GetError())
+		}
+	}
+
+	fmt.Fprintf(h.out, "OK batch set=%d failed=%d\n", len(req.Items)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d items failed", failed)
+	}
+	return nil
+}
+
+func (h *Handler) BatchDelete(path string) error {
+	f, err := openInput(path)
+	if err != nil {
+		fmt.Fprintln(h.err, "BatchDelete error:", err)
+		return err
+	}
+	defer f.Close()
+
+	req := &stachev1.BatchDeleteRequest{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if key := strings.TrimSpace(sc.Text()); key != "" {
+			req.Keys = append(req.Keys, key)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintln(h.err, "BatchDelete error:", err)
+		return err
+	}
+
+	res, err := h.client.BatchDelete(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "BatchDelete error:", err)
+		return err
+	}
+
+	deleted := 0
+	for _, r := range res.Msg.GetResults() {
+		if r.GetDeleted() {
+			deleted++
+		}
+	}
+
+	fmt.Fprintf(h.out, "OK batch deleted=%d missing=%d\n", deleted, len(req.Keys)-deleted)
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+func (h *Handler) ClusterInfo() error {
+	res, err := h.client.ClusterInfo(context.Background(), connect.NewRequest(&stachev1.ClusterInfoRequest{}))
+	if err != nil {
+		fmt.Fprintln(h.err, "ClusterInfo error:", err)
+		return err
+	}
+
+	if !res.Msg.GetEnabled() {
+		fmt.Fprintln(h.out, "standalone (cluster mode off)")
+		return nil
+	}
+
+	fmt.Fprintf(h.out, "self=%s vnodes=%d\n", res.Msg.GetSelf(), res.Msg.GetVnodes())
+	tw := tabwriter.NewWriter(h.out, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NODE\tSHARE\tSELF")
+	for _, n := range res.Msg.GetNodes() {
+		self := ""
+		if n.GetSelf() {
+			self = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%.1f%%\t%s\n", n.GetAddress(), 100*n.GetShare(), self)
+	}
+
+	tw.Flush()
+	return nil
+}
+
+func (h *Handler) Members() error {
+	res, err := h.client.Members(context.Background(), connect.NewRequest(&stachev1.MembersRequest{}))
+	if err != nil {
+		fmt.Fprintln(h.err, "Members error:", err)
+		return err
+	}
+
+	if !res.Msg.GetEnabled() {
+		fmt.Fprintln(h.out, "gossip membership off (start stached with -seeds)")
+		return nil
+	}
+
+	fmt.Fprintf(h.out, "self=%s\n", res.Msg.GetSelf())
+	tw := tabwriter.NewWriter(h.out, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "MEMBER\tSTATE\tINCARNATION\tSELF")
+	for _, m := range res.Msg.GetMembers() {
+		self := ""
+		if m.GetSelf() {
+			self = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", m.GetAddress(), m.GetState(), m.GetIncarnation(), self)
+	}
+
+	tw.Flush()
+	return nil
+}
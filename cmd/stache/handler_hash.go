@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+func (h *Handler) HSet(key string, field string, value string) error {
+	req := &stachev1.HSetRequest{Key: &key, Field: &field, Value: []byte(value)}
+	res, err := h.client.HSet(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "HSet error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "OK hset key=%q field=%q created=%t\n", key, field, res.Msg.GetCreated())
+	return nil
+}
+
+func (h *Handler) HGet(key string, field string) error {
+	req := &stachev1.HGetRequest{Key: &key, Field: &field}
+	res, err := h.client.HGet(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "HGet error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "%s\n", string(res.Msg.GetValue()))
+	return nil
+}
+
+func (h *Handler) HDel(key string, fields []string) error {
+	req := &stachev1.HDelRequest{Key: &key, Fields: fields}
+	res, err := h.client.HDel(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "HDel error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "OK hdel key=%q removed=%d\n", key, res.Msg.GetRemoved())
+	return nil
+}
+
+func (h *Handler) HGetAll(key string) error {
+	req := &stachev1.HGetAllRequest{Key: &key}
+	res, err := h.client.HGetAll(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "HGetAll error:", err)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(h.out, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tVALUE")
+
+	for _, f := range res.Msg.GetFields() {
+		fmt.Fprintf(tw, "%s\t%s\n", f.GetField(), string(f.GetValue()))
+	}
+
+	tw.Flush()
+	return nil
+}
+
+func (h *Handler) HIncrBy(key string, field string, delta int64) error {
+	req := &stachev1.HIncrByRequest{Key: &key, Field: &field, Delta: &delta}
+	res, err := h.client.HIncrBy(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "HIncrBy error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "%d\n", res.Msg.GetValue())
+	return nil
+}
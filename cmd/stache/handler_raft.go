@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+func (h *Handler) RaftStatus() error {
+	res, err := h.client.RaftStatus(context.Background(), connect.NewRequest(&stachev1.RaftStatusRequest{}))
+	if err != nil {
+		fmt.Fprintln(h.err, "RaftStatus error:", err)
+		return err
+	}
+
+	s := res.Msg
+	if !s.GetEnabled() {
+		fmt.Fprintln(h.out, "standalone (raft mode off)")
+		return nil
+	}
+
+	fmt.Fprintf(h.out, "id=%s state=%s term=%d leader=%s\n", s.GetId(), s.GetState(), s.GetTerm(), s.GetLeader())
+	fmt.Fprintf(h.out, "last=%d commit=%d applied=%d snapshot=%d\n", s.GetLastIndex(), s.GetCommitIndex(), s.GetAppliedIndex(), s.GetSnapshotIndex())
+	fmt.Fprintf(h.out, "peers=%s\n", strings.Join(s.GetPeers(), ","))
+	return nil
+}
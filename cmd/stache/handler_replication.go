@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+func (h *Handler) ReplicationStatus() error {
+	res, err := h.client.ReplicationStatus(context.Background(), connect.NewRequest(&stachev1.ReplicationStatusRequest{}))
+	if err != nil {
+		fmt.Fprintln(h.err, "ReplicationStatus error:", err)
+		return err
+	}
+
+	s := res.Msg
+	if s.GetRole() != "replica" {
+		fmt.Fprintf(h.out, "role=%s replicas=%d\n", s.GetRole(), s.GetReplicas())
+		return nil
+	}
+
+	fmt.Fprintf(h.out, "role=replica primary=%s connected=%t synced=%t\n", s.GetPrimary(), s.GetConnected(), s.GetSynced())
+	fmt.Fprintf(h.out, "applied=%d lag=%s\n", s.GetAppliedSequence(), s.GetLag().AsDuration())
+	return nil
+}
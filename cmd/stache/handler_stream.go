@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+// exportLine is one line of an -export/-import file. Unlike batchLine,
+// it keeps the value as base64 and the expiry as an absolute time so that
+// a dump restores exactly what was exported.
+type exportLine struct {
+	Key         string    `json:"key"`
+	Value       []byte    `json:"value"`
+	ContentType string    `json:"content_type"`
+	ExpiresAt   time.Time `json:"expires_at,omitzero"`
+	Sliding     string    `json:"sliding,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+}
+
+func (l exportLine) record() (*stachev1.EntryRecord, error) {
+	rec := &stachev1.EntryRecord{Key: &l.Key, Value: l.Value, ContentType: &l.ContentType, Tags: l.Tags}
+
+	if !l.ExpiresAt.IsZero() {
+		rec.ExpiresAt = timestamppb.New(l.ExpiresAt)
+	}
+
+	if l.Sliding != "" {
+		d, err := time.ParseDuration(l.Sliding)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: bad sliding: %w", l.Key, err)
+		}
+		rec.Sliding = durationpb.New(d)
+	}
+
+	return rec, nil
+}
+
+// openOutput opens path for writing, or stdout if path is "-".
+func openOutput(path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func (h *Handler) Export(path, prefix string) error {
+	f, err := openOutput(path)
+	if err != nil {
+		fmt.Fprintln(h.err, "Export error:", err)
+		return err
+	}
+	defer f.Close()
+
+	stream, err := h.client.Export(context.Background(), connect.NewRequest(&stachev1.ExportRequest{Prefix: &prefix}))
+	if err != nil {
+		fmt.Fprintln(h.err, "Export error:", err)
+		return err
+	}
+	defer stream.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	n := 0
+	for stream.Receive() {
+		rec := stream.Msg()
+		l := exportLine{
+			Key:         rec.GetKey(),
+			Value:       rec.GetValue(),
+			ContentType: rec.GetContentType(),
+			Tags:        rec.GetTags(),
+		}
+		if rec.GetExpiresAt() != nil {
+			l.ExpiresAt = rec.GetExpiresAt().AsTime()
+		}
+		if rec.GetSliding() != nil {
+			l.Sliding = rec.GetSliding().AsDuration().String()
+		}
+
+		if err := enc.Encode(l); err != nil {
+			fmt.Fprintln(h.err, "Export error:", err)
+			return err
+		}
+		n++
+	}
+	if err := stream.Err(); err != nil {
+		fmt.Fprintln(h.err, "Export error:", err)
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(h.err, "Export error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.err, "OK exported=%d\n", n)
+	return nil
+}
+
+func (h *Handler) Import(path string) error {
+	f, err := openInput(path)
+	if err != nil {
+		fmt.Fprintln(h.err, "Import error:", err)
+		return err
+	}
+	defer f.Close()
+
+	stream := h.client.Import(context.Background())
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 64<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+
+		var l exportLine
+		err := json.Unmarshal(sc.Bytes(), &l)
+		var rec *stachev1.EntryRecord
+		if err == nil {
+			rec, err = l.record()
+		}
+		if err != nil {
+			err = fmt.Errorf("%s:%d: %w", path, n, err)
+			fmt.Fprintln(h.err, "Import error:", err)
+			stream.CloseAndReceive()
+			return err
+		}
+
+		if err := stream.Send(rec); err != nil {
+			break // the real error is reported by CloseAndReceive
+		}
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintln(h.err, "Import error:", err)
+		stream.CloseAndReceive()
+		return err
+	}
+
+	res, err := stream.CloseAndReceive()
+	if err != nil {
+		fmt.Fprintln(h.err, "Import error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "OK imported=%d skipped=%d\n", res.Msg.GetImported(), res.Msg.GetSkipped())
+	return nil
+}
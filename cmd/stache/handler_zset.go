@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+func (h *Handler) ZAdd(key string, member string, score float64) error {
+	req := &stachev1.ZAddRequest{Key: &key, Member: &member, Score: &score}
+	res, err := h.client.ZAdd(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "ZAdd error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "OK zadd key=%q member=%q score=%g added=%t\n", key, member, score, res.Msg.GetAdded())
+	return nil
+}
+
+func (h *Handler) ZRem(key string, members []string) error {
+	req := &stachev1.ZRemRequest{Key: &key, Members: members}
+	res, err := h.client.ZRem(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "ZRem error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "OK zrem key=%q removed=%d\n", key, res.Msg.GetRemoved())
+	return nil
+}
+
+func (h *Handler) ZScore(key string, member string) error {
+	req := &stachev1.ZScoreRequest{Key: &key, Member: &member}
+	res, err := h.client.ZScore(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "ZScore error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "%g\n", res.Msg.GetScore())
+	return nil
+}
+
+func (h *Handler) ZRank(key string, member string) error {
+	req := &stachev1.ZRankRequest{Key: &key, Member: &member}
+	res, err := h.client.ZRank(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "ZRank error:", err)
+		return err
+	}
+
+	fmt.Fprintf(h.out, "%d\n", res.Msg.GetRank())
+	return nil
+}
+
+func (h *Handler) ZRangeByRank(key string, start, stop int64) error {
+	req := &stachev1.ZRangeByRankRequest{Key: &key, Start: &start, Stop: &stop}
+	res, err := h.client.ZRangeByRank(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "ZRangeByRank error:", err)
+		return err
+	}
+
+	h.printZMembers(res.Msg.GetMembers())
+	return nil
+}
+
+func (h *Handler) ZRangeByScore(key string, min, max float64) error {
+	req := &stachev1.ZRangeByScoreRequest{Key: &key, Min: &min, Max: &max}
+	res, err := h.client.ZRangeByScore(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		fmt.Fprintln(h.err, "ZRangeByScore error:", err)
+		return err
+	}
+
+	h.printZMembers(res.Msg.GetMembers())
+	return nil
+}
+
+func (h *Handler) printZMembers(members []*stachev1.ZMember) {
+	tw := tabwriter.NewWriter(h.out, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "MEMBER\tSCORE")
+
+	for _, m := range members {
+		fmt.Fprintf(tw, "%s\t%s\n", m.GetMember(), strconv.FormatFloat(m.GetScore(), 'g', -1, 64))
+	}
+
+	tw.Flush()
+}
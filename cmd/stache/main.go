@@ -3,26 +3,137 @@ package main
 import (
 	"flag"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
 )
 
+// ttlValue is a flag.Value accepting either a Go duration string
+// ("250ms", "2h") or a bare number of seconds, as older versions did.
+type ttlValue time.Duration
+
+func (v *ttlValue) String() string {
+	return time.Duration(*v).String()
+}
+
+func (v *ttlValue) Set(s string) error {
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*v = ttlValue(time.Duration(secs) * time.Second)
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*v = ttlValue(d)
+	return nil
+}
+
+// stringsValue is a flag.Value collecting every occurrence of a repeated flag.
+type stringsValue []string
+
+func (v *stringsValue) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *stringsValue) Set(s string) error {
+	*v = append(*v, s)
+	return nil
+}
+
+// tokenTransport sends token as a bearer token with every request.
+type tokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t tokenTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(r)
+}
+
 func main() {
 	addr := flag.String("addr", "http://localhost:8080", "Daemon base URL")
+	token := flag.String("token", os.Getenv("STACHE_TOKEN"), "Bearer token for daemons started with -token (default $STACHE_TOKEN)")
 	doList := flag.Bool("list", false, "List all items")
+	doStats := flag.Bool("stats", false, "Show cache statistics")
+	doCluster := flag.Bool("cluster", false, "Show cluster ring membership")
+	doMembers := flag.Bool("members", false, "Show gossip membership and member states")
+	doReplication := flag.Bool("replication", false, "Show replication role and lag")
+	doRaft := flag.Bool("raft", false, "Show Raft group state")
 	setKey := flag.String("set", "", "Set value for key (requires -v)")
 	getKey := flag.String("get", "", "Get value for key")
+	touchKey := flag.String("touch", "", "Reset TTL for key (uses -l, 0 = no expiry)")
+	ttlKey := flag.String("ttl", "", "Show remaining TTL for key")
 	val := flag.String("v", "", "Value to set (used with -set)")
 	ct := flag.String("t", "text/plain", "MIME content type (used with -set)")
-	ttlSec := flag.Int("l", 0, "TTL in seconds (0 = no expiry) (used with -set)")
+	var ttl ttlValue
+	flag.Var(&ttl, "l", "TTL as a duration like 250ms or 2h, or whole seconds (0 = no expiry) (used with -set, -touch)")
+	sliding := flag.Bool("sliding", false, "Extend TTL on every read (used with -set)")
+	var tags, invalidateTags stringsValue
+	flag.Var(&tags, "tag", "Tag the entry, may be repeated (used with -set)")
+	flag.Var(&invalidateTags, "invalidate-tag", "Remove all entries with tag, may be repeated")
+	hsetKey := flag.String("hset", "", "Set a hash field (requires -f, -v)")
+	hgetKey := flag.String("hget", "", "Get a hash field (requires -f)")
+	hdelKey := flag.String("hdel", "", "Delete hash fields (requires -f)")
+	hgetallKey := flag.String("hgetall", "", "Get all fields of a hash")
+	hincrbyKey := flag.String("hincrby", "", "Increment an integer hash field (requires -f, uses -by)")
+	var fields stringsValue
+	flag.Var(&fields, "f", "Hash field, may be repeated for -hdel")
+	by := flag.Int64("by", 1, "Increment (used with -hincrby)")
+	zaddKey := flag.String("zadd", "", "Add a sorted set member (requires -m, uses -score)")
+	zremKey := flag.String("zrem", "", "Remove sorted set members (requires -m)")
+	zscoreKey := flag.String("zscore", "", "Get a sorted set member's score (requires -m)")
+	zrankKey := flag.String("zrank", "", "Get a sorted set member's rank (requires -m)")
+	zrangeKey := flag.String("zrange", "", "List sorted set members by rank (uses -start, -stop)")
+	zrangeByScoreKey := flag.String("zrangebyscore", "", "List sorted set members by score (uses -min, -max)")
+	var members stringsValue
+	flag.Var(&members, "m", "Sorted set member, may be repeated for -zrem")
+	score := flag.Float64("score", 0, "Member score (used with -zadd)")
+	start := flag.Int64("start", 0, "First rank, negative counts from the end (used with -zrange)")
+	stop := flag.Int64("stop", -1, "Last rank, negative counts from the end (used with -zrange)")
+	minScore := flag.Float64("min", math.Inf(-1), "Lowest score (used with -zrangebyscore)")
+	maxScore := flag.Float64("max", math.Inf(1), "Highest score (used with -zrangebyscore)")
+	batchSetFile := flag.String("batch-set", "", "Set entries from a JSON Lines file (- for stdin)")
+	batchDeleteFile := flag.String("batch-delete", "", "Delete keys listed one per line in a file (- for stdin)")
+	exportFile := flag.String("export", "", "Dump entries to a JSON Lines file (- for stdout, uses -prefix)")
+	importFile := flag.String("import", "", "Restore entries from an -export file (- for stdin)")
+	prefix := flag.String("prefix", "", "Only export keys with this prefix (used with -export)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage:\n")
-		fmt.Fprintf(os.Stderr, "  stache -set <key> -v <value> [-t <content-type>] [-l <ttl-seconds>] [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -set <key> -v <value> [-t <content-type>] [-l <ttl>] [-sliding] [-tag <tag>]... [-addr <url>]\n")
 		fmt.Fprintf(os.Stderr, "  stache -get <key> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -touch <key> [-l <ttl>] [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -ttl <key> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -invalidate-tag <tag>... [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -hset <key> -f <field> -v <value> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -hget <key> -f <field> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -hdel <key> -f <field>... [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -hgetall <key> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -hincrby <key> -f <field> [-by <n>] [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -zadd <key> -m <member> -score <score> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -zrem <key> -m <member>... [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -zscore|-zrank <key> -m <member> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -zrange <key> [-start <rank>] [-stop <rank>] [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -zrangebyscore <key> [-min <score>] [-max <score>] [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -batch-set <file.jsonl> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -batch-delete <file> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -export <file.jsonl> [-prefix <prefix>] [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -import <file.jsonl> [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -stats [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -cluster [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -members [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -replication [-addr <url>]\n")
+		fmt.Fprintf(os.Stderr, "  stache -raft [-addr <url>]\n")
 		fmt.Fprintf(os.Stderr, "  stache -list [-addr <url>]\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
 		flag.PrintDefaults()
@@ -31,14 +142,51 @@ func main() {
 	flag.Parse()
 
 	nActions := 0
-	if *doList {
-		nActions++
+	for _, set := range []bool{
+		*doList,
+		*doStats,
+		*doCluster,
+		*doMembers,
+		*doReplication,
+		*doRaft,
+		*setKey != "",
+		*getKey != "",
+		*touchKey != "",
+		*ttlKey != "",
+		len(invalidateTags) > 0,
+		*hsetKey != "",
+		*hgetKey != "",
+		*hdelKey != "",
+		*hgetallKey != "",
+		*hincrbyKey != "",
+		*zaddKey != "",
+		*zremKey != "",
+		*zscoreKey != "",
+		*zrankKey != "",
+		*zrangeKey != "",
+		*zrangeByScoreKey != "",
+		*batchSetFile != "",
+		*batchDeleteFile != "",
+		*exportFile != "",
+		*importFile != "",
+	} {
+		if set {
+			nActions++
+		}
 	}
-	if *setKey != "" {
-		nActions++
+
+	needField := *hsetKey != "" || *hgetKey != "" || *hdelKey != "" || *hincrbyKey != ""
+	if needField && len(fields) == 0 {
+		fmt.Fprintln(os.Stderr, "error: hash commands require -f <field>")
+		flag.Usage()
+		os.Exit(2)
 	}
-	if *getKey != "" {
-		nActions++
+
+	needMember := *zaddKey != "" || *zremKey != "" || *zscoreKey != "" || *zrankKey != ""
+	if needMember && len(members) == 0 {
+		fmt.Fprintln(os.Stderr, "error: sorted set commands require -m <member>")
+		flag.Usage()
+		os.Exit(2)
 	}
 
 	if nActions != 1 {
@@ -49,6 +197,13 @@ func main() {
 	httpClient := &http.Client{
 		Timeout: time.Second * 10,
 	}
+	if *exportFile != "" || *importFile != "" {
+		// Streams last as long as the dump does.
+		httpClient.Timeout = 0
+	}
+	if *token != "" {
+		httpClient.Transport = tokenTransport{token: *token, base: http.DefaultTransport}
+	}
 	h := Handler{
 		client: stachev1connect.NewCacheServiceClient(httpClient, *addr),
 		out:    os.Stdout,
@@ -61,13 +216,38 @@ func main() {
 			os.Exit(1)
 		}
 
+	case *doStats:
+		if err := h.Stats(); err != nil {
+			os.Exit(1)
+		}
+
+	case *doCluster:
+		if err := h.ClusterInfo(); err != nil {
+			os.Exit(1)
+		}
+
+	case *doMembers:
+		if err := h.Members(); err != nil {
+			os.Exit(1)
+		}
+
+	case *doReplication:
+		if err := h.ReplicationStatus(); err != nil {
+			os.Exit(1)
+		}
+
+	case *doRaft:
+		if err := h.RaftStatus(); err != nil {
+			os.Exit(1)
+		}
+
 	case *setKey != "":
 		if *val == "" {
 			fmt.Fprintln(os.Stderr, "error: -set requires -v <value>")
 			flag.Usage()
 			os.Exit(2)
 		}
-		if err := h.Set(*setKey, *val, *ct, int64(*ttlSec)); err != nil {
+		if err := h.Set(*setKey, *val, *ct, time.Duration(ttl), *sliding, tags); err != nil {
 			os.Exit(1)
 		}
 
@@ -75,5 +255,95 @@ func main() {
 		if err := h.Get(*getKey); err != nil {
 			os.Exit(1)
 		}
+
+	case *touchKey != "":
+		if err := h.Touch(*touchKey, time.Duration(ttl)); err != nil {
+			os.Exit(1)
+		}
+
+	case *ttlKey != "":
+		if err := h.TTL(*ttlKey); err != nil {
+			os.Exit(1)
+		}
+
+	case len(invalidateTags) > 0:
+		if err := h.InvalidateTags(invalidateTags); err != nil {
+			os.Exit(1)
+		}
+
+	case *hsetKey != "":
+		if err := h.HSet(*hsetKey, fields[0], *val); err != nil {
+			os.Exit(1)
+		}
+
+	case *hgetKey != "":
+		if err := h.HGet(*hgetKey, fields[0]); err != nil {
+			os.Exit(1)
+		}
+
+	case *hdelKey != "":
+		if err := h.HDel(*hdelKey, fields); err != nil {
+			os.Exit(1)
+		}
+
+	case *hgetallKey != "":
+		if err := h.HGetAll(*hgetallKey); err != nil {
+			os.Exit(1)
+		}
+
+	case *hincrbyKey != "":
+		if err := h.HIncrBy(*hincrbyKey, fields[0], *by); err != nil {
+			os.Exit(1)
+		}
+
+	case *zaddKey != "":
+		if err := h.ZAdd(*zaddKey, members[0], *score); err != nil {
+			os.Exit(1)
+		}
+
+	case *zremKey != "":
+		if err := h.ZRem(*zremKey, members); err != nil {
+			os.Exit(1)
+		}
+
+	case *zscoreKey != "":
+		if err := h.ZScore(*zscoreKey, members[0]); err != nil {
+			os.Exit(1)
+		}
+
+	case *zrankKey != "":
+		if err := h.ZRank(*zrankKey, members[0]); err != nil {
+			os.Exit(1)
+		}
+
+	case *zrangeKey != "":
+		if err := h.ZRangeByRank(*zrangeKey, *start, *stop); err != nil {
+			os.Exit(1)
+		}
+
+	case *zrangeByScoreKey != "":
+		if err := h.ZRangeByScore(*zrangeByScoreKey, *minScore, *maxScore); err != nil {
+			os.Exit(1)
+		}
+
+	case *batchSetFile != "":
+		if err := h.BatchSet(*batchSetFile); err != nil {
+			os.Exit(1)
+		}
+
+	case *batchDeleteFile != "":
+		if err := h.BatchDelete(*batchDeleteFile); err != nil {
+			os.Exit(1)
+		}
+
+	case *exportFile != "":
+		if err := h.Export(*exportFile, *prefix); err != nil {
+			os.Exit(1)
+		}
+
+	case *importFile != "":
+		if err := h.Import(*importFile); err != nil {
+			os.Exit(1)
+		}
 	}
 }
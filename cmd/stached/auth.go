@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+)
+
+var errUnauthenticated = errors.New("missing or invalid bearer token")
+
+// tokenValid reports whether got matches the configured token, in constant time.
+func tokenValid(want, got string) bool {
+	return subtle.ConstantTimeCompare([]byte(want), []byte(got)) == 1
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(h http.Header) string {
+	scheme, token, ok := strings.Cut(h.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// authInterceptor rejects RPCs that do not carry the bearer token.
+type authInterceptor struct {
+	token string
+}
+
+func (a authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !tokenValid(a.token, bearerToken(req.Header())) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, errUnauthenticated)
+		}
+		return next(ctx, req)
+	}
+}
+
+func (a authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (a authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if !tokenValid(a.token, bearerToken(conn.RequestHeader())) {
+			return connect.NewError(connect.CodeUnauthenticated, errUnauthenticated)
+		}
+		return next(ctx, conn)
+	}
+}
+
+// requireToken is authInterceptor for plain HTTP handlers such as the REST API.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tokenValid(token, bearerToken(r.Header)) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="stache"`)
+			http.Error(w, errUnauthenticated.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func TestAuthToken(t *testing.T) {
+	c := stache.NewCache()
+	_ = c.SetString("k", "v", 0)
+
+	path, handler := stachev1connect.NewCacheServiceHandler(
+		&cacheServer{cache: c},
+		connect.WithInterceptors(authInterceptor{"s3cret"}),
+	)
+	restMux := http.NewServeMux()
+	(&restServer{cache: c}).register(restMux)
+
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	mux.Handle("/v1/", requireToken("s3cret", restMux))
+	mux.Handle("/dashboard/", dashboardHandler())
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := stachev1connect.NewCacheServiceClient(ts.Client(), ts.URL)
+	key := "k"
+
+	_, err := client.Get(context.Background(), connect.NewRequest(&stachev1.GetRequest{Key: &key}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("RPC without token: got=%v want=unauthenticated", err)
+	}
+
+	req := connect.NewRequest(&stachev1.GetRequest{Key: &key})
+	req.Header().Set("Authorization", "Bearer s3cret")
+	res, err := client.Get(context.Background(), req)
+	if err != nil || string(res.Msg.GetValue()) != "v" {
+		t.Fatalf("RPC with token: res=%v err=%v", res, err)
+	}
+
+	for token, want := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "s3cret": http.StatusOK} {
+		r, _ := http.NewRequest("GET", ts.URL+"/v1/keys/k", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatalf("REST: %v", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != want {
+			t.Fatalf("REST with token %q: got=%d want=%d", token, res.StatusCode, want)
+		}
+	}
+
+	// The dashboard page is static; its data calls carry the token.
+	dres, err := http.Get(ts.URL + "/dashboard/")
+	if err != nil || dres.StatusCode != http.StatusOK {
+		t.Fatalf("dashboard: res=%v err=%v", dres, err)
+	}
+	dres.Body.Close()
+}
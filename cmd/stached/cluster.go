@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/cluster"
+	"github.com/byytelope/stache/pkg/gossip"
+)
+
+// forwardedHeader marks a request one node has forwarded to the owner of
+// its key, so the owner serves it locally instead of forwarding it again
+// while rings disagree. It is only honoured if it names another node on
+// the ring.
+const forwardedHeader = "Stache-Forwarded-By"
+
+// errKeysSpread rejects a request for several keys that no one node owns.
+var errKeysSpread = errors.New("the keys are owned by different nodes")
+
+// peerClients hands out a Connect client per peer, by base URL.
+type peerClients struct {
+	httpClient *http.Client
+
+	mutex   sync.Mutex
+	clients map[string]stachev1connect.CacheServiceClient
+}
+
+func newPeerClients() peerClients {
+	return peerClients{httpClient: &http.Client{}, clients: map[string]stachev1connect.CacheServiceClient{}}
+}
+
+func (p *peerClients) client(node string) stachev1connect.CacheServiceClient {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	c, ok := p.clients[node]
+	if !ok {
+		c = stachev1connect.NewCacheServiceClient(p.httpClient, node)
+		p.clients[node] = c
+	}
+	return c
+}
+
+// clusterState spreads keys over the nodes of a ring. Nodes are named by
+// the base URL other nodes reach them at.
+type clusterState struct {
+	self string
+	ring *cluster.Ring
+
+	// members keeps the ring to the live nodes, with -seeds; it is nil
+	// with a static -peers list.
+	members *gossip.Memberlist
+	peerClients
+}
+
+func newCluster(self string, peers []string, vnodes int) *clusterState {
+	return &clusterState{
+		self:        self,
+		ring:        cluster.NewRing(vnodes, append([]string{self}, peers...)...),
+		peerClients: newPeerClients(),
+	}
+}
+
+// newGossipCluster returns a cluster whose ring starts with self alone and
+// follows gossip membership: nodes join the ring when they are first heard
+// of, stay on it while suspected, and leave it once they are declared dead
+// or leave.
+func newGossipCluster(cfg gossip.Config, vnodes int, token string) *clusterState {
+	cl := newCluster(cfg.Self, nil, vnodes)
+	cfg.Notify = func(m gossip.Member) {
+		if m.Live() {
+			cl.ring.Add(m.Addr)
+		} else {
+			cl.ring.Remove(m.Addr)
+		}
+	}
+	cl.members = gossip.New(cfg, &gossip.HTTPTransport{Client: &http.Client{}, Token: token})
+	return cl
+}
+
+// route returns a client for the owner of key, or nil if this node should
+// serve the request itself: because it owns the key, the request was
+// already forwarded, or clustering is off.
+func (cl *clusterState) route(key string, h http.Header) stachev1connect.CacheServiceClient {
+	peer, _ := cl.routeAll([]string{key}, h)
+	return peer
+}
+
+// routeAll is route for a request that must be served whole by one node,
+// such as a transaction. It refuses keys owned by different nodes.
+func (cl *clusterState) routeAll(keys []string, h http.Header) (stachev1connect.CacheServiceClient, error) {
+	if cl == nil || len(keys) == 0 || cl.forwarded(h) {
+		return nil, nil
+	}
+
+	owner := cl.owner(keys[0])
+	for _, key := range keys[1:] {
+		if cl.owner(key) != owner {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, errKeysSpread)
+		}
+	}
+	if owner == "" {
+		return nil, nil
+	}
+
+	return cl.client(owner), nil
+}
+
+// scatter splits a request for several keys, such as a batch, between the
+// nodes owning them. It calls serve once per node with the positions of
+// that node's keys, in order, and a client for the node or nil for this
+// one, and returns the first error.
+func (cl *clusterState) scatter(keys []string, h http.Header, serve func(peer stachev1connect.CacheServiceClient, pos []int) error) error {
+	if cl == nil || cl.forwarded(h) {
+		pos := make([]int, len(keys))
+		for i := range pos {
+			pos[i] = i
+		}
+		return serve(nil, pos)
+	}
+
+	byOwner := map[string][]int{}
+	for i, key := range keys {
+		owner := cl.owner(key)
+		byOwner[owner] = append(byOwner[owner], i)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 0, len(byOwner))
+	var mutex sync.Mutex
+	for owner, pos := range byOwner {
+		var peer stachev1connect.CacheServiceClient
+		if owner != "" {
+			peer = cl.client(owner)
+		}
+		wg.Go(func() {
+			if err := serve(peer, pos); err != nil {
+				mutex.Lock()
+				errs = append(errs, err)
+				mutex.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// owner returns the node owning key, or "" if that is this node.
+func (cl *clusterState) owner(key string) string {
+	if owner := cl.ring.Owner(key); owner != cl.self {
+		return owner
+	}
+	return ""
+}
+
+// forwarded reports whether h marks a request forwarded by another node
+// on the ring.
+func (cl *clusterState) forwarded(h http.Header) bool {
+	by := h.Get(forwardedHeader)
+	return by != "" && by != cl.self && slices.Contains(cl.ring.Nodes(), by)
+}
+
+// misdirectedError refuses a key owned by another node.
+type misdirectedError struct {
+	key, owner string
+}
+
+func (e *misdirectedError) Error() string {
+	return fmt.Sprintf("key %q is owned by %s", e.key, e.owner)
+}
+
+// misdirected returns a *misdirectedError for the first of keys this node
+// does not own, or nil if it owns them all or clustering is off. The REST,
+// Redis and memcached listeners cannot forward requests, so they turn away
+// keys owned elsewhere rather than keep them where no other node looks.
+func (cl *clusterState) misdirected(keys ...string) error {
+	if cl == nil {
+		return nil
+	}
+
+	for _, key := range keys {
+		if owner := cl.owner(key); owner != "" {
+			return &misdirectedError{key: key, owner: owner}
+		}
+	}
+	return nil
+}
+
+// forward copies req for sending to a peer, keeping the caller's
+// credentials and marking it as forwarded by self.
+func forward[T any](self string, req *connect.Request[T]) *connect.Request[T] {
+	return forwardMsg(self, req.Header(), req.Msg)
+}
+
+// forwardMsg is forward for a request made up for a peer, such as part of
+// a batch, on behalf of a caller that sent the headers h.
+func forwardMsg[T any](self string, h http.Header, msg *T) *connect.Request[T] {
+	out := connect.NewRequest(msg)
+	if auth := h.Get("Authorization"); auth != "" {
+		out.Header().Set("Authorization", auth)
+	}
+	out.Header().Set(forwardedHeader, self)
+	return out
+}
+
+func (s *cacheServer) ClusterInfo(ctx context.Context, req *connect.Request[stachev1.ClusterInfoRequest]) (*connect.Response[stachev1.ClusterInfoResponse], error) {
+	cl := s.cluster
+	if cl == nil {
+		enabled, self, share := false, true, 1.0
+		res := &stachev1.ClusterInfoResponse{
+			Enabled: &enabled,
+			Nodes:   []*stachev1.ClusterNode{{Self: &self, Share: &share}},
+		}
+		return connect.NewResponse(res), nil
+	}
+
+	shares := cl.ring.Shares()
+	nodes := []*stachev1.ClusterNode{}
+	for _, n := range cl.ring.Nodes() {
+		self, share := n == cl.self, shares[n]
+		nodes = append(nodes, &stachev1.ClusterNode{Address: &n, Self: &self, Share: &share})
+	}
+
+	enabled, vnodes := true, uint32(cl.ring.Vnodes())
+	res := &stachev1.ClusterInfoResponse{
+		Enabled: &enabled,
+		Self:    &cl.self,
+		Nodes:   nodes,
+		Vnodes:  &vnodes,
+	}
+	if key := req.Msg.GetKey(); key != "" {
+		owner := cl.ring.Owner(key)
+		res.Owner = &owner
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (s *cacheServer) Members(ctx context.Context, req *connect.Request[stachev1.MembersRequest]) (*connect.Response[stachev1.MembersResponse], error) {
+	enabled := s.cluster != nil && s.cluster.members != nil
+	if !enabled {
+		return connect.NewResponse(&stachev1.MembersResponse{Enabled: &enabled}), nil
+	}
+
+	cl := s.cluster
+	members := []*stachev1.Member{}
+	for _, m := range cl.members.Members() {
+		state, self := m.State.String(), m.Addr == cl.self
+		members = append(members, &stachev1.Member{
+			Address:     &m.Addr,
+			State:       &state,
+			Incarnation: &m.Incarnation,
+			Self:        &self,
+		})
+	}
+
+	return connect.NewResponse(&stachev1.MembersResponse{
+		Enabled: &enabled,
+		Self:    &cl.self,
+		Members: members,
+	}), nil
+}
@@ -0,0 +1,535 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/gossip"
+	"github.com/byytelope/stache/pkg/stache"
+	"github.com/byytelope/stache/pkg/stacheclient"
+)
+
+type testNode struct {
+	url     string
+	cache   *stache.Cache
+	service *cacheServer
+	client  stachev1connect.CacheServiceClient
+}
+
+// startCluster runs n stached nodes on loopback, each knowing all the others.
+func startCluster(t *testing.T, n int) []testNode {
+	t.Helper()
+
+	servers := make([]*httptest.Server, n)
+	urls := make([]string, n)
+	for i := range n {
+		servers[i] = httptest.NewUnstartedServer(nil)
+		urls[i] = "http://" + servers[i].Listener.Addr().String()
+	}
+
+	nodes := make([]testNode, n)
+	for i, ts := range servers {
+		c := stache.NewCache()
+		service := &cacheServer{cache: c, cluster: newCluster(urls[i], urls, 64)}
+
+		mux := http.NewServeMux()
+		mux.Handle(stachev1connect.NewCacheServiceHandler(service))
+		(&restServer{cache: c, cluster: service.cluster}).register(mux)
+		ts.Config.Handler = mux
+		ts.Start()
+		t.Cleanup(ts.Close)
+
+		nodes[i] = testNode{url: urls[i], cache: c, service: service, client: stachev1connect.NewCacheServiceClient(ts.Client(), urls[i])}
+	}
+
+	return nodes
+}
+
+// keyOn returns the n-th key, counting from 0, of those named "key:<i>"
+// that node i owns.
+func keyOn(nodes []testNode, i, n int) string {
+	ring := nodes[0].service.cluster.ring
+	for j := 0; ; j++ {
+		key := "key:" + strconv.Itoa(j)
+		if ring.Owner(key) != nodes[i].url {
+			continue
+		}
+		if n == 0 {
+			return key
+		}
+		n--
+	}
+}
+
+func TestClusterForwarding(t *testing.T) {
+	nodes := startCluster(t, 3)
+	ctx := context.Background()
+
+	// Write every key through node 0; each must land on exactly one node
+	const keys = 60
+	for i := range keys {
+		key := "key:" + strconv.Itoa(i)
+		_, err := nodes[0].client.Set(ctx, connect.NewRequest(&stachev1.SetRequest{Key: &key, Value: []byte(key)}))
+		if err != nil {
+			t.Fatalf("Set(%q): %v", key, err)
+		}
+	}
+
+	total := 0
+	for i, n := range nodes {
+		if n.cache.Len() == 0 {
+			t.Fatalf("node %d owns no keys", i)
+		}
+		total += n.cache.Len()
+	}
+	if total != keys {
+		t.Fatalf("keys stored across nodes: got=%d want=%d", total, keys)
+	}
+
+	// Any node can read any key, and the owner is the one holding it
+	for i := range keys {
+		key := "key:" + strconv.Itoa(i)
+		res, err := nodes[i%3].client.Get(ctx, connect.NewRequest(&stachev1.GetRequest{Key: &key}))
+		if err != nil || string(res.Msg.GetValue()) != key {
+			t.Fatalf("Get(%q) via node %d: res=%v err=%v", key, i%3, res, err)
+		}
+
+		info, err := nodes[1].client.ClusterInfo(ctx, connect.NewRequest(&stachev1.ClusterInfoRequest{Key: &key}))
+		if err != nil {
+			t.Fatalf("ClusterInfo: %v", err)
+		}
+		for _, n := range nodes {
+			_, err := n.cache.GetEntry(key)
+			if (n.url == info.Msg.GetOwner()) != (err == nil) {
+				t.Fatalf("key %q: owner=%s but node %s has it=%v", key, info.Msg.GetOwner(), n.url, err == nil)
+			}
+		}
+	}
+
+	// A request forwarded by another node is served locally, even by a
+	// node not owning the key; the header means nothing from anyone else
+	key := "key:7"
+	for i, n := range nodes {
+		req := connect.NewRequest(&stachev1.GetRequest{Key: &key})
+		req.Header().Set(forwardedHeader, nodes[(i+1)%3].url)
+		_, err := n.client.Get(ctx, req)
+		if _, local := n.cache.GetEntry(key); (local == nil) != (err == nil) {
+			t.Fatalf("forwarded Get on %s was not served locally: err=%v", n.url, err)
+		}
+
+		for _, by := range []string{"test", n.url} {
+			req := connect.NewRequest(&stachev1.GetRequest{Key: &key})
+			req.Header().Set(forwardedHeader, by)
+			if _, err := n.client.Get(ctx, req); err != nil {
+				t.Fatalf("Get on %s claiming to be forwarded by %s: %v", n.url, by, err)
+			}
+		}
+	}
+
+	res, err := nodes[2].client.Delete(ctx, connect.NewRequest(&stachev1.DeleteRequest{Key: &key}))
+	if err != nil || !res.Msg.GetDeleted() {
+		t.Fatalf("Delete via non-owner: res=%v err=%v", res, err)
+	}
+	_, err = nodes[0].client.Get(ctx, connect.NewRequest(&stachev1.GetRequest{Key: &key}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("Get after Delete: got=%v want=not_found", err)
+	}
+}
+
+func TestClusterRouting(t *testing.T) {
+	nodes := startCluster(t, 3)
+	ctx := context.Background()
+	a, b := keyOn(nodes, 1, 0), keyOn(nodes, 2, 0)
+
+	// Structured types live on the owner, whichever node is asked
+	field := "f"
+	if _, err := nodes[0].client.HSet(ctx, connect.NewRequest(&stachev1.HSetRequest{Key: &a, Field: &field, Value: []byte("v")})); err != nil {
+		t.Fatalf("HSet via non-owner: %v", err)
+	}
+	if _, err := nodes[1].cache.HGet(a, field); err != nil {
+		t.Fatalf("HSet did not reach the owner: %v", err)
+	}
+	if _, err := nodes[0].cache.HGet(a, field); err == nil {
+		t.Fatalf("HSet was also stored on the node asked")
+	}
+	res, err := nodes[2].client.HGet(ctx, connect.NewRequest(&stachev1.HGetRequest{Key: &a, Field: &field}))
+	if err != nil || string(res.Msg.GetValue()) != "v" {
+		t.Fatalf("HGet via non-owner: res=%v err=%v", res, err)
+	}
+
+	// Batches are split between the owners, and answered in order
+	var items []*stachev1.BatchSetItem
+	var keys []string
+	for i := range 12 {
+		key := keyOn(nodes, i%3, 1+i/3)
+		keys = append(keys, key)
+		items = append(items, &stachev1.BatchSetItem{Key: &key, Value: []byte(key)})
+	}
+	set, err := nodes[0].client.BatchSet(ctx, connect.NewRequest(&stachev1.BatchSetRequest{Items: items}))
+	if err != nil {
+		t.Fatalf("BatchSet: %v", err)
+	}
+	for i, r := range set.Msg.GetResults() {
+		if r.GetKey() != keys[i] || !r.GetOk() {
+			t.Fatalf("BatchSet result %d: %v", i, r)
+		}
+	}
+	for i, n := range nodes {
+		want := 4
+		if i == 1 {
+			want++ // the hash
+		}
+		if n.cache.Len() != want {
+			t.Fatalf("node %d holds %d entries, want %d", i, n.cache.Len(), want)
+		}
+	}
+	get, err := nodes[1].client.BatchGet(ctx, connect.NewRequest(&stachev1.BatchGetRequest{Keys: keys}))
+	if err != nil {
+		t.Fatalf("BatchGet: %v", err)
+	}
+	for i, it := range get.Msg.GetItems() {
+		if it.GetKey() != keys[i] || string(it.GetValue()) != keys[i] {
+			t.Fatalf("BatchGet item %d: %v", i, it)
+		}
+	}
+	del, err := nodes[2].client.BatchDelete(ctx, connect.NewRequest(&stachev1.BatchDeleteRequest{Keys: keys}))
+	if err != nil {
+		t.Fatalf("BatchDelete: %v", err)
+	}
+	for i, r := range del.Msg.GetResults() {
+		if r.GetKey() != keys[i] || !r.GetDeleted() {
+			t.Fatalf("BatchDelete result %d: %v", i, r)
+		}
+	}
+
+	// Atomic requests must keep to one owner
+	op := func(key string) *stachev1.TxnOp {
+		return &stachev1.TxnOp{Key: &key, Action: &stachev1.TxnOp_Set{Set: &stachev1.TxnSet{Value: []byte("t")}}}
+	}
+	c, d := keyOn(nodes, 1, 1), keyOn(nodes, 1, 2)
+	_, err = nodes[0].client.Transaction(ctx, connect.NewRequest(&stachev1.TransactionRequest{Ops: []*stachev1.TxnOp{op(c), op(d)}}))
+	if err != nil {
+		t.Fatalf("Transaction on one owner: %v", err)
+	}
+	if _, err := nodes[1].cache.GetString(d); err != nil {
+		t.Fatalf("Transaction did not reach the owner: %v", err)
+	}
+	_, err = nodes[0].client.Transaction(ctx, connect.NewRequest(&stachev1.TransactionRequest{Ops: []*stachev1.TxnOp{op(a), op(b)}}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("Transaction across owners: got=%v want=failed_precondition", err)
+	}
+	_, err = nodes[0].client.SUnion(ctx, connect.NewRequest(&stachev1.SetAlgebraRequest{Keys: []string{a}, Destination: &b}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("SUnion across owners: got=%v want=failed_precondition", err)
+	}
+
+	// Tags are invalidated on every node
+	for i, n := range nodes {
+		key := keyOn(nodes, i, 0)
+		_, err := n.client.Set(ctx, connect.NewRequest(&stachev1.SetRequest{Key: &key, Value: []byte("v"), Tags: []string{"t"}}))
+		if err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+	}
+	inv, err := nodes[0].client.InvalidateTags(ctx, connect.NewRequest(&stachev1.InvalidateTagsRequest{Tags: []string{"t"}}))
+	if err != nil || inv.Msg.GetRemoved() != 3 {
+		t.Fatalf("InvalidateTags: res=%v err=%v", inv, err)
+	}
+
+	// Import sends each record to its owner
+	stream := nodes[0].client.Import(ctx)
+	for _, key := range keys {
+		if err := stream.Send(&stachev1.EntryRecord{Key: &key, Value: []byte(key)}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	imp, err := stream.CloseAndReceive()
+	if err != nil || imp.Msg.GetImported() != uint64(len(keys)) {
+		t.Fatalf("Import: res=%v err=%v", imp, err)
+	}
+	for _, key := range keys {
+		for _, n := range nodes {
+			_, err := n.cache.GetEntry(key)
+			if (n.url == nodes[0].service.cluster.ring.Owner(key)) != (err == nil) {
+				t.Fatalf("imported key %q on %s: err=%v", key, n.url, err)
+			}
+		}
+	}
+}
+
+func TestClusterListeners(t *testing.T) {
+	nodes := startCluster(t, 2)
+	mine, theirs := keyOn(nodes, 0, 0), keyOn(nodes, 1, 0)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// REST redirects to the owner
+	res, _ := restDo(t, http.MethodPut, nodes[0].url+"/v1/keys/"+theirs, "v", nil)
+	if res.StatusCode != http.StatusCreated || res.Request.URL.Host != strings.TrimPrefix(nodes[1].url, "http://") {
+		t.Fatalf("PUT via non-owner: status=%s url=%s", res.Status, res.Request.URL)
+	}
+	if _, err := nodes[1].cache.GetString(theirs); err != nil {
+		t.Fatalf("PUT did not reach the owner: %v", err)
+	}
+	if res, body := restDo(t, http.MethodGet, nodes[0].url+"/v1/keys/"+theirs, "", nil); res.StatusCode != http.StatusOK || body != "v" {
+		t.Fatalf("GET via non-owner: status=%s body=%q", res.Status, body)
+	}
+
+	// Redis and memcached refuse keys owned elsewhere
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	rs := newRESPServer(nodes[0].cache, logger)
+	rs.cluster = nodes[0].service.cluster
+	go rs.Serve(ln)
+	defer rs.Close()
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	rc := &respClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+
+	if got := rc.do("SET", mine, "v"); got != "+OK\r\n" {
+		t.Fatalf("SET on owner: got=%q", got)
+	}
+	for _, args := range [][]string{{"SET", theirs, "w"}, {"INCR", theirs}, {"DEL", mine, theirs}} {
+		if got := rc.do(args...); !strings.HasPrefix(got, "-ERR key "+strconv.Quote(theirs)+" is owned by "+nodes[1].url) {
+			t.Fatalf("%v on non-owner: got=%q", args, got)
+		}
+	}
+
+	ln, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ms := newMemcachedServer(nodes[0].cache, logger)
+	ms.cluster = nodes[0].service.cluster
+	go ms.Serve(ln)
+	defer ms.Close()
+	mconn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	mr := bufio.NewReader(mconn)
+
+	if got := mcDo(t, mconn, mr, "get "+mine+"\r\n"); !strings.HasPrefix(got, "VALUE "+mine) {
+		t.Fatalf("get on owner: got=%q", got)
+	}
+	for _, req := range []string{"set " + theirs + " 0 0 1\r\nw\r\n", "incr " + theirs + " 1\r\n", "get " + mine + " " + theirs + "\r\n"} {
+		if got := mcDo(t, mconn, mr, req); !strings.HasPrefix(got, "SERVER_ERROR key") {
+			t.Fatalf("%q on non-owner: got=%q", req, got)
+		}
+	}
+	if got, _ := nodes[1].cache.GetString(theirs); got != "v" {
+		t.Fatalf("refused writes changed the owner's value to %q", got)
+	}
+}
+
+func TestClusterNearCache(t *testing.T) {
+	nodes := startCluster(t, 2)
+	ctx := context.Background()
+
+	key := keyOn(nodes, 1, 0)
+	_ = nodes[1].cache.SetString(key, "v1", 0)
+
+	// Each node numbers its tracking ids from 1, so both clients get the
+	// same id from different nodes
+	clients := make([]*stacheclient.Client, len(nodes))
+	for i, n := range nodes {
+		clients[i] = stacheclient.New(stacheclient.Config{Addr: n.url, NearCache: stacheclient.NearCacheConfig{MaxEntries: 16, FallbackTTL: 50 * time.Millisecond}})
+		defer clients[i].Close()
+		eventually(t, "invalidation stream", func() bool {
+			n.service.tracking.mutex.Lock()
+			defer n.service.tracking.mutex.Unlock()
+			return len(n.service.tracking.clients) == 1
+		})
+	}
+
+	// A read forwarded by node 0 must not register the key at node 1
+	// under the id of node 1's own client
+	if v, err := clients[0].GetString(ctx, key); err != nil || v != "v1" {
+		t.Fatalf("GetString via non-owner: got=%q err=%v", v, err)
+	}
+	tracker := &nodes[1].service.tracking
+	tracker.mutex.Lock()
+	tracked := len(tracker.keys[key])
+	tracker.mutex.Unlock()
+	if tracked != 0 {
+		t.Fatalf("forwarded read tracked %q at the owner for %d clients", key, tracked)
+	}
+	if v, err := clients[1].GetString(ctx, key); err != nil || v != "v1" {
+		t.Fatalf("GetString via owner: got=%q err=%v", v, err)
+	}
+
+	// The owner's client hears of the change; the other one only keeps
+	// the untracked value for FallbackTTL
+	_ = nodes[1].cache.SetString(key, "v2", 0)
+	for i, c := range clients {
+		eventually(t, "change seen by client "+strconv.Itoa(i), func() bool {
+			v, _ := c.GetString(ctx, key)
+			return v == "v2"
+		})
+	}
+}
+
+func TestClusterInfo(t *testing.T) {
+	nodes := startCluster(t, 3)
+
+	res, err := nodes[0].client.ClusterInfo(context.Background(), connect.NewRequest(&stachev1.ClusterInfoRequest{}))
+	if err != nil {
+		t.Fatalf("ClusterInfo: %v", err)
+	}
+
+	msg := res.Msg
+	if !msg.GetEnabled() || msg.GetSelf() != nodes[0].url || len(msg.GetNodes()) != 3 || msg.GetVnodes() != 64 {
+		t.Fatalf("ClusterInfo mismatch: %v", msg)
+	}
+
+	selves, share := 0, 0.0
+	for _, n := range msg.GetNodes() {
+		if n.GetSelf() {
+			selves++
+		}
+		share += n.GetShare()
+	}
+	if selves != 1 || share < 0.999 || share > 1.001 {
+		t.Fatalf("ClusterInfo nodes mismatch: selves=%d share=%v", selves, share)
+	}
+}
+
+type gossipTestNode struct {
+	testNode
+	server  *httptest.Server
+	cluster *clusterState
+}
+
+// startGossipCluster runs n stached nodes on loopback; all but the first
+// join through the first.
+func startGossipCluster(t *testing.T, n int) []gossipTestNode {
+	t.Helper()
+
+	servers := make([]*httptest.Server, n)
+	urls := make([]string, n)
+	for i := range n {
+		servers[i] = httptest.NewUnstartedServer(nil)
+		urls[i] = "http://" + servers[i].Listener.Addr().String()
+	}
+
+	nodes := make([]gossipTestNode, n)
+	for i, ts := range servers {
+		cfg := gossip.Config{
+			Self:             urls[i],
+			Seeds:            urls[:1],
+			ProbeInterval:    20 * time.Millisecond,
+			ProbeTimeout:     50 * time.Millisecond,
+			SuspicionTimeout: 300 * time.Millisecond,
+		}
+		c := stache.NewCache()
+		cl := newGossipCluster(cfg, 64, "")
+		service := &cacheServer{cache: c, cluster: cl}
+
+		mux := http.NewServeMux()
+		mux.Handle(stachev1connect.NewCacheServiceHandler(service))
+		mux.Handle(gossip.HTTPPrefix, gossip.Handler(cl.members))
+		ts.Config.Handler = mux
+		ts.Start()
+		cl.members.Start()
+		t.Cleanup(func() {
+			cl.members.Stop()
+			ts.Close()
+		})
+
+		nodes[i] = gossipTestNode{
+			testNode: testNode{url: urls[i], cache: c, client: stachev1connect.NewCacheServiceClient(ts.Client(), urls[i])},
+			server:   ts,
+			cluster:  cl,
+		}
+	}
+
+	return nodes
+}
+
+// ringIs reports whether every node's ring holds exactly want.
+func ringIs(nodes []gossipTestNode, want ...string) bool {
+	want = slices.Sorted(slices.Values(want))
+	for _, n := range nodes {
+		if !slices.Equal(n.cluster.ring.Nodes(), want) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGossipCluster(t *testing.T) {
+	ctx := context.Background()
+	nodes := startGossipCluster(t, 3)
+	urls := []string{nodes[0].url, nodes[1].url, nodes[2].url}
+
+	// Every node learns of the others and puts them on its ring
+	eventually(t, "rings to hold every node", func() bool { return ringIs(nodes, urls...) })
+
+	res, err := nodes[1].client.Members(ctx, connect.NewRequest(&stachev1.MembersRequest{}))
+	if err != nil {
+		t.Fatalf("Members: %v", err)
+	}
+	if !res.Msg.GetEnabled() || res.Msg.GetSelf() != nodes[1].url || len(res.Msg.GetMembers()) != 3 {
+		t.Fatalf("Members mismatch: %v", res.Msg)
+	}
+	for _, m := range res.Msg.GetMembers() {
+		if m.GetState() != "alive" || m.GetSelf() != (m.GetAddress() == nodes[1].url) {
+			t.Fatalf("Members entry mismatch: %v", m)
+		}
+	}
+
+	// Keys are spread over the gossiped ring
+	for i := range 30 {
+		key := "key:" + strconv.Itoa(i)
+		if _, err := nodes[i%3].client.Set(ctx, connect.NewRequest(&stachev1.SetRequest{Key: &key, Value: []byte(key)})); err != nil {
+			t.Fatalf("Set(%q): %v", key, err)
+		}
+	}
+	for i, n := range nodes {
+		if n.cache.Len() == 0 {
+			t.Fatalf("node %d owns no keys", i)
+		}
+	}
+
+	// A failed node is detected and taken off the survivors' rings
+	failed := nodes[2]
+	failed.cluster.members.Stop()
+	failed.server.Close()
+	eventually(t, "the failed node to leave the rings", func() bool { return ringIs(nodes[:2], urls[:2]...) })
+
+	res, err = nodes[0].client.Members(ctx, connect.NewRequest(&stachev1.MembersRequest{}))
+	if err != nil {
+		t.Fatalf("Members: %v", err)
+	}
+	for _, m := range res.Msg.GetMembers() {
+		if m.GetAddress() == failed.url && m.GetState() != "dead" {
+			t.Fatalf("failed node reported as %q, want dead", m.GetState())
+		}
+	}
+}
+
+func TestMembersStandalone(t *testing.T) {
+	nodes := startCluster(t, 1)
+
+	res, err := nodes[0].client.Members(context.Background(), connect.NewRequest(&stachev1.MembersRequest{}))
+	if err != nil || res.Msg.GetEnabled() {
+		t.Fatalf("Members without gossip: res=%v err=%v", res, err)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+// dashboardHandler serves the web dashboard under /dashboard/. The page
+// itself holds no data: it reads and writes through the Connect API from
+// the browser, so it is subject to the same token as any other client.
+func dashboardHandler() http.Handler {
+	sub, err := fs.Sub(dashboardFiles, "dashboard")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix("/dashboard/", http.FileServerFS(sub))
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"connectrpc.com/connect"
@@ -31,3 +32,67 @@ func unaryLogging(logger *slog.Logger) connect.UnaryInterceptorFunc {
 		}
 	}
 }
+
+// streamLogging logs streaming RPCs the way unaryLogging logs unary ones.
+type streamLogging struct {
+	logger *slog.Logger
+}
+
+func (l streamLogging) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+func (l streamLogging) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (l streamLogging) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+
+		err := next(ctx, conn)
+		level := slog.LevelInfo
+		if err != nil {
+			level = slog.LevelError
+		}
+
+		l.logger.Log(ctx, level, "rpc",
+			"procedure", conn.Spec().Procedure,
+			"lat_ms", time.Since(start).Milliseconds(),
+		)
+
+		return err
+	}
+}
+
+// statusRecorder remembers the status code written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// httpLogging logs plain HTTP requests, such as those to the REST API.
+func httpLogging(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+		level := slog.LevelInfo
+		if rec.status >= 500 {
+			level = slog.LevelError
+		}
+
+		logger.Log(r.Context(), level, "http",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"lat_ms", time.Since(start).Milliseconds(),
+		)
+	})
+}
@@ -3,12 +3,16 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"io"
 	"log"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -19,12 +23,29 @@ import (
 	"golang.org/x/net/http2/h2c"
 
 	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/cluster"
+	"github.com/byytelope/stache/pkg/gossip"
+	"github.com/byytelope/stache/pkg/raft"
 	"github.com/byytelope/stache/pkg/stache"
 )
 
 type cacheServer struct {
-	cache  *stache.Cache
-	logger *slog.Logger
+	cache   *stache.Cache
+	logger  *slog.Logger
+	started time.Time
+
+	// cluster is nil unless stached runs with -peers or -seeds, raft
+	// unless it runs with -raft-peers.
+	cluster *clusterState
+	raft    *raftState
+
+	// replica is nil unless stached runs with -replica-of; replicas counts
+	// the replicas streaming from this node.
+	replica  *replica
+	replicas atomic.Int32
+
+	// tracking serves the Invalidations stream.
+	tracking tracker
 	stachev1connect.UnimplementedCacheServiceHandler
 }
 
@@ -38,8 +59,106 @@ func waitForShutdown(s *http.Server, timeout time.Duration) {
 	_ = s.Shutdown(ctx)
 }
 
+// newHTTPServer serves handler over HTTP/1.1 and h2c. Request bodies
+// other than client streams must arrive within readTimeout.
+//
+// There is no WriteTimeout, and no ReadTimeout either: both would cut off
+// streams such as Import and Export, and BPop, which holds its response
+// open until a value arrives, bounded by its own timeout or the caller's
+// Connect deadline.
+func newHTTPServer(addr string, handler http.Handler, readTimeout time.Duration) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           h2c.NewHandler(bodyDeadline(readTimeout, handler), &http2.Server{}),
+		ReadHeaderTimeout: readTimeout,
+		IdleTimeout:       60 * time.Second,
+	}
+}
+
+// bodyDeadline gives a request timeout to send its body, lifting the
+// deadline once the body is read so the response may take as long as it
+// needs. Requests without a body and Import's client stream are exempt.
+func bodyDeadline(timeout time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != http.NoBody && r.URL.Path != stachev1connect.CacheServiceImportProcedure {
+			rc := http.NewResponseController(w)
+			if err := rc.SetReadDeadline(time.Now().Add(timeout)); err == nil {
+				r.Body = &deadlineBody{ReadCloser: r.Body, rc: rc}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// deadlineBody clears the read deadline of rc when the body hits EOF.
+type deadlineBody struct {
+	io.ReadCloser
+	rc   *http.ResponseController
+	done bool
+}
+
+func (b *deadlineBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF && !b.done {
+		b.done = true
+		_ = b.rc.SetReadDeadline(time.Time{})
+	}
+	return n, err
+}
+
+// defaultAdvertise turns a listen address such as ":8080" into a URL
+// other nodes on the same host can reach.
+func defaultAdvertise(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(s string) []string {
+	var out []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, strings.TrimSuffix(item, "/"))
+		}
+	}
+	return out
+}
+
 func main() {
-	c := stache.NewCache()
+	addr := flag.String("addr", ":8080", "Listen address for the Connect/gRPC API")
+	respAddr := flag.String("resp-addr", "", "Listen address for the Redis protocol (RESP) API, disabled if empty")
+	mcAddr := flag.String("memcached-addr", "", "Listen address for the memcached text protocol API, disabled if empty")
+	token := flag.String("token", os.Getenv("STACHE_TOKEN"), "Bearer token required by the RPC, REST and RESP APIs, disabled if empty (default $STACHE_TOKEN)")
+	peers := flag.String("peers", "", "Comma-separated base URLs of the other cluster nodes; enables cluster mode")
+	seeds := flag.String("seeds", "", "Comma-separated base URLs of nodes to join; enables cluster mode with gossip membership")
+	advertise := flag.String("advertise", "", "Base URL peers reach this node at (default http://localhost<addr>)")
+	vnodes := flag.Int("vnodes", cluster.DefaultVnodes, "Virtual nodes per cluster node on the hash ring")
+	replicaOf := flag.String("replica-of", "", "Base URL of a primary to replicate from; makes this node a read-only replica")
+	raftPeers := flag.String("raft-peers", "", "Comma-separated base URLs of the other members of a Raft group; enables raft mode")
+	raftDir := flag.String("raft-dir", "", "Directory keeping this node's Raft term, vote and log across restarts; required in raft mode")
+	flag.Parse()
+
+	modes := 0
+	for _, set := range []bool{*peers != "", *seeds != "", *replicaOf != "", *raftPeers != ""} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		log.Fatal("-peers, -seeds, -replica-of and -raft-peers cannot be combined")
+	}
+	if *raftPeers != "" && *raftDir == "" {
+		log.Fatal("-raft-peers requires -raft-dir")
+	}
+
+	// Raft nodes must only change their cache by applying the log
+	c := stache.NewCacheWithOptions(stache.Options{KeepExpired: *raftPeers != ""})
 	logger := slog.New(
 		slog.NewJSONHandler(
 			os.Stdout,
@@ -48,11 +167,62 @@ func main() {
 			},
 		),
 	)
-	service := &cacheServer{cache: c, logger: logger}
+	service := &cacheServer{cache: c, logger: logger, started: time.Now()}
+	self := *advertise
+	if self == "" {
+		self = defaultAdvertise(*addr)
+	}
+	self = strings.TrimSuffix(self, "/")
+	if *peers != "" {
+		service.cluster = newCluster(self, splitList(*peers), *vnodes)
+		logger.Info("cluster mode", "self", self, "nodes", service.cluster.ring.Nodes())
+	}
+	if *seeds != "" {
+		cfg := gossip.Config{Self: self, Seeds: splitList(*seeds), Logger: logger}
+		service.cluster = newGossipCluster(cfg, *vnodes, *token)
+		logger.Info("cluster mode", "self", self, "seeds", splitList(*seeds))
+	}
+	if *raftPeers != "" {
+		rs, err := newRaft(self, splitList(*raftPeers), *raftDir, *token, c, logger)
+		if err != nil {
+			log.Fatal(err)
+		}
+		service.raft = rs
+		logger.Info("raft mode", "self", self, "peers", splitList(*raftPeers))
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	if *replicaOf != "" {
+		service.replica = newReplica(strings.TrimSuffix(*replicaOf, "/"), *token, c, logger)
+		go service.replica.run(ctx)
+		logger.Info("replica mode", "primary", service.replica.primary)
+	}
+
+	interceptors := []connect.Interceptor{unaryLogging(logger), streamLogging{logger}}
+	if *token != "" {
+		interceptors = append(interceptors, authInterceptor{*token})
+	}
+	if service.replica != nil {
+		interceptors = append(interceptors, readOnlyInterceptor{})
+	}
+	if service.raft != nil {
+		interceptors = append(interceptors, readOnlyInterceptor{allow: raftWrites, err: errRaftNotSupported})
+	}
+	// The REST, Redis and memcached listeners write to the local cache
+	// only, so they are read-only on a replica and in raft mode, and in
+	// cluster mode refuse keys another node owns.
+	var readOnly error
+	switch {
+	case service.replica != nil:
+		readOnly = errReadOnly
+	case service.raft != nil:
+		readOnly = errRaftNotSupported
+	}
 
 	path, handler := stachev1connect.NewCacheServiceHandler(
 		service,
-		connect.WithInterceptors(unaryLogging(logger)),
+		connect.WithInterceptors(interceptors...),
 	)
 
 	checker := grpchealth.NewStaticChecker("stache.v1.CacheService")
@@ -63,24 +233,48 @@ func main() {
 	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
 	mux.Handle(grpchealth.NewHandler(checker))
 	mux.Handle(path, handler)
+	restMux := http.NewServeMux()
+	(&restServer{cache: c, readOnly: readOnly, cluster: service.cluster}).register(restMux)
+	var rest http.Handler = restMux
+	if *token != "" {
+		rest = requireToken(*token, rest)
+	}
+	mux.Handle("/v1/", httpLogging(logger, rest))
+	if service.raft != nil {
+		var rh http.Handler = raft.Handler(service.raft.node)
+		if *token != "" {
+			rh = requireToken(*token, rh)
+		}
+		mux.Handle(raft.HTTPPrefix, rh)
+	}
+	if service.cluster != nil && service.cluster.members != nil {
+		var gh http.Handler = gossip.Handler(service.cluster.members)
+		if *token != "" {
+			gh = requireToken(*token, gh)
+		}
+		mux.Handle(gossip.HTTPPrefix, gh)
+	}
+	mux.Handle("/dashboard/", dashboardHandler())
+	mux.Handle("GET /dashboard", http.RedirectHandler("/dashboard/", http.StatusMovedPermanently))
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	h2s := &http2.Server{}
-	server := &http.Server{
-		Addr:         ":8080",
-		Handler:      h2c.NewHandler(mux, h2s),
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
+	server := newHTTPServer(*addr, mux, 5*time.Second)
 
 	ln, err := net.Listen("tcp", server.Addr)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if service.raft != nil {
+		service.raft.node.Start()
+		go service.raft.run(ctx)
+	}
+	if service.cluster != nil && service.cluster.members != nil {
+		service.cluster.members.Start()
+	}
+
 	go func() {
 		log.Println("stached (Connect) listening on", server.Addr)
 		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -88,5 +282,60 @@ func main() {
 		}
 	}()
 
+	var resp *respServer
+	if *respAddr != "" {
+		resp = newRESPServer(c, logger)
+		resp.token = *token
+		resp.readOnly = readOnly != nil
+		resp.cluster = service.cluster
+		respLn, err := net.Listen("tcp", *respAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		go func() {
+			log.Println("stached (RESP) listening on", *respAddr)
+			if err := resp.Serve(respLn); err != nil {
+				log.Println("resp serve error:", err)
+			}
+		}()
+	}
+
+	var mc *mcServer
+	if *mcAddr != "" {
+		if *token != "" {
+			logger.Warn("the memcached protocol has no authentication; -token does not protect -memcached-addr")
+		}
+		mc = newMemcachedServer(c, logger)
+		mc.readOnly = readOnly
+		mc.cluster = service.cluster
+		mcLn, err := net.Listen("tcp", *mcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		go func() {
+			log.Println("stached (memcached) listening on", *mcAddr)
+			if err := mc.Serve(mcLn); err != nil {
+				log.Println("memcached serve error:", err)
+			}
+		}()
+	}
+
 	waitForShutdown(server, time.Second*5)
+	stop()
+	if service.cluster != nil && service.cluster.members != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		service.cluster.members.Leave(ctx)
+		cancel()
+	}
+	if service.raft != nil {
+		service.raft.node.Stop()
+	}
+	if resp != nil {
+		_ = resp.Close()
+	}
+	if mc != nil {
+		_ = mc.Close()
+	}
 }
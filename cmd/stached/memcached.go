@@ -0,0 +1,539 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+const (
+	mcMaxKey   = 250
+	mcMaxValue = 1 << 20
+
+	// mcRelativeExpiryMax is the largest exptime read as seconds from now;
+	// larger values are absolute Unix times, as in memcached.
+	mcRelativeExpiryMax = 60 * 60 * 24 * 30
+)
+
+// Flags 0-2 map to these content types; other flags are kept in a
+// content type of the form "application/vnd.stache.memcached; flags=N"
+// so they round-trip.
+var mcFlagTypes = []stache.ContentType{stache.Text, stache.JSON, "application/octet-stream"}
+
+const mcFlagsPrefix = "application/vnd.stache.memcached; flags="
+
+func mcContentType(flags uint32) stache.ContentType {
+	if int(flags) < len(mcFlagTypes) {
+		return mcFlagTypes[flags]
+	}
+	return stache.ContentType(mcFlagsPrefix + strconv.FormatUint(uint64(flags), 10))
+}
+
+// mcFlags is the inverse of mcContentType. Content types it does not know,
+// such as those set through the Connect API, map to 0.
+func mcFlags(ct stache.ContentType) uint32 {
+	for i, t := range mcFlagTypes {
+		if t == ct {
+			return uint32(i)
+		}
+	}
+
+	if s, ok := strings.CutPrefix(string(ct), mcFlagsPrefix); ok {
+		if f, err := strconv.ParseUint(s, 10, 32); err == nil {
+			return uint32(f)
+		}
+	}
+	return 0
+}
+
+// mcExpiry converts a memcached exptime: 0 never expires, up to 30 days is
+// relative, anything larger is a Unix time and a negative value has
+// already expired. The zero time means no absolute expiry.
+func mcExpiry(exptime int64) (ttl time.Duration, at time.Time) {
+	switch {
+	case exptime < 0:
+		return 0, time.Unix(0, 0)
+	case exptime > mcRelativeExpiryMax:
+		return 0, time.Unix(exptime, 0)
+	default:
+		return time.Duration(exptime) * time.Second, time.Time{}
+	}
+}
+
+func mcMeta(flags uint32, exptime int64) stache.Meta {
+	ttl, at := mcExpiry(exptime)
+	return stache.Meta{TTL: ttl, ExpiresAt: at, ContentType: mcContentType(flags)}
+}
+
+// mcServer speaks the memcached ASCII protocol on top of a Cache.
+type mcServer struct {
+	*tcpServer
+	cache   *stache.Cache
+	logger  *slog.Logger
+	started time.Time
+	stats   mcStats
+
+	// readOnly, if set, rejects every write with this error.
+	readOnly error
+
+	// cluster, if set, has commands on keys owned by another node refused.
+	cluster *clusterState
+}
+
+// mcStats holds the counters reported by the stats command.
+type mcStats struct {
+	totalConns                    atomic.Uint64
+	cmdGet, cmdSet, cmdTouch      atomic.Uint64
+	cmdFlush                      atomic.Uint64
+	getHits, getMisses            atomic.Uint64
+	deleteHits, deleteMisses      atomic.Uint64
+	incrHits, incrMisses          atomic.Uint64
+	decrHits, decrMisses          atomic.Uint64
+	casHits, casMisses, casBadval atomic.Uint64
+	touchHits, touchMisses        atomic.Uint64
+}
+
+func newMemcachedServer(c *stache.Cache, logger *slog.Logger) *mcServer {
+	s := &mcServer{cache: c, logger: logger, started: time.Now()}
+	s.tcpServer = newTCPServer(s.serveConn)
+	return s
+}
+
+type mcConn struct {
+	r *bufio.Reader
+	w *bufio.Writer
+
+	// noreply suppresses the reply to the current command.
+	noreply bool
+}
+
+func (mc *mcConn) reply(format string, args ...any) {
+	if !mc.noreply {
+		fmt.Fprintf(mc.w, format+"\r\n", args...)
+	}
+}
+
+func (s *mcServer) serveConn(conn net.Conn) {
+	s.stats.totalConns.Add(1)
+
+	mc := &mcConn{r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}
+	for {
+		line, err := mc.r.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			mc.w.WriteString("CLIENT_ERROR line too long\r\n")
+			mc.w.Flush()
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(string(line))
+		if len(fields) == 0 {
+			mc.w.WriteString("ERROR\r\n")
+		} else if fields[0] == "quit" {
+			mc.w.Flush()
+			return
+		} else {
+			mc.noreply = len(fields) > 1 && fields[len(fields)-1] == "noreply"
+			if mc.noreply {
+				fields = fields[:len(fields)-1]
+			}
+
+			if err := s.dispatch(mc, fields); err != nil {
+				mc.w.Flush()
+				if !errors.Is(err, io.EOF) {
+					s.logger.Warn("memcached connection error", "remote", conn.RemoteAddr().String(), "err", err)
+				}
+				return
+			}
+		}
+
+		// Flush once the client has nothing more pipelined.
+		if mc.r.Buffered() == 0 {
+			if err := mc.w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// dispatch runs one command. A returned error closes the connection.
+func (s *mcServer) dispatch(mc *mcConn, fields []string) error {
+	args := fields[1:]
+
+	switch fields[0] {
+	case "delete", "incr", "decr", "touch", "flush_all":
+		if s.readOnly != nil {
+			mc.reply("SERVER_ERROR " + s.readOnly.Error())
+			return nil
+		}
+	}
+
+	switch fields[0] {
+	case "delete", "incr", "decr", "touch":
+		if len(args) > 0 {
+			if err := s.cluster.misdirected(args[0]); err != nil {
+				mc.reply("SERVER_ERROR " + err.Error())
+				return nil
+			}
+		}
+	}
+
+	switch fields[0] {
+	case "get", "gets":
+		if len(args) == 0 {
+			mc.w.WriteString("ERROR\r\n")
+			return nil
+		}
+		if err := s.cluster.misdirected(args...); err != nil {
+			mc.reply("SERVER_ERROR " + err.Error())
+			return nil
+		}
+		s.get(mc, args, fields[0] == "gets")
+
+	case "set", "add", "replace", "cas":
+		return s.store(mc, fields[0], args)
+
+	case "delete":
+		// A trailing 0 is accepted for compatibility with old clients.
+		if len(args) == 0 || len(args) > 2 || (len(args) == 2 && args[1] != "0") {
+			mc.reply("CLIENT_ERROR bad command line format")
+			return nil
+		}
+		if _, ok := s.cache.Delete(args[0]); ok {
+			s.stats.deleteHits.Add(1)
+			mc.reply("DELETED")
+		} else {
+			s.stats.deleteMisses.Add(1)
+			mc.reply("NOT_FOUND")
+		}
+
+	case "incr", "decr":
+		s.incr(mc, fields[0] == "incr", args)
+
+	case "touch":
+		s.touch(mc, args)
+
+	case "flush_all":
+		s.flushAll(mc, args)
+
+	case "stats":
+		if len(args) == 0 {
+			s.writeStats(mc)
+		} else {
+			mc.reply("END")
+		}
+
+	case "version":
+		mc.reply("VERSION 1.0.0 stache")
+
+	case "verbosity":
+		mc.reply("OK")
+
+	default:
+		mc.reply("ERROR")
+	}
+
+	return nil
+}
+
+func (s *mcServer) get(mc *mcConn, keys []string, withCAS bool) {
+	for _, key := range keys {
+		s.stats.cmdGet.Add(1)
+
+		value, info, err := s.cache.GetWithInfo(key)
+
+		// Hashes, lists and other structured types count as misses.
+		if err != nil {
+			s.stats.getMisses.Add(1)
+			continue
+		}
+		s.stats.getHits.Add(1)
+
+		if withCAS {
+			fmt.Fprintf(mc.w, "VALUE %s %d %d %d\r\n", key, mcFlags(info.ContentType), len(value), info.Version)
+		} else {
+			fmt.Fprintf(mc.w, "VALUE %s %d %d\r\n", key, mcFlags(info.ContentType), len(value))
+		}
+		mc.w.Write(value)
+		mc.w.WriteString("\r\n")
+	}
+	mc.w.WriteString("END\r\n")
+}
+
+// store handles set, add, replace and cas:
+//
+//	<cmd> <key> <flags> <exptime> <bytes> [<cas unique>] [noreply]
+func (s *mcServer) store(mc *mcConn, cmd string, args []string) error {
+	want := 4
+	if cmd == "cas" {
+		want = 5
+	}
+	if len(args) != want {
+		mc.reply("CLIENT_ERROR bad command line format")
+		return nil
+	}
+
+	key := args[0]
+	flags, err1 := strconv.ParseUint(args[1], 10, 32)
+	exptime, err2 := strconv.ParseInt(args[2], 10, 64)
+	size, err3 := strconv.Atoi(args[3])
+	var unique uint64
+	var err4 error
+	if cmd == "cas" {
+		unique, err4 = strconv.ParseUint(args[4], 10, 64)
+	}
+	if err := errors.Join(err1, err2, err3, err4); err != nil || size < 0 {
+		mc.reply("CLIENT_ERROR bad command line format")
+		return nil
+	}
+
+	if size > mcMaxValue {
+		// Swallow the data so the connection stays in sync.
+		if _, err := io.CopyN(io.Discard, mc.r, int64(size)+2); err != nil {
+			return err
+		}
+		mc.reply("SERVER_ERROR object too large for cache")
+		return nil
+	}
+
+	data := make([]byte, size+2)
+	if _, err := io.ReadFull(mc.r, data); err != nil {
+		return err
+	}
+	if data[size] != '\r' || data[size+1] != '\n' {
+		mc.reply("CLIENT_ERROR bad data chunk")
+		return nil
+	}
+	data = data[:size]
+
+	if s.readOnly != nil {
+		mc.reply("SERVER_ERROR " + s.readOnly.Error())
+		return nil
+	}
+	if err := s.cluster.misdirected(key); err != nil {
+		mc.reply("SERVER_ERROR " + err.Error())
+		return nil
+	}
+
+	if len(key) > mcMaxKey {
+		mc.reply("CLIENT_ERROR key too long")
+		return nil
+	}
+
+	s.stats.cmdSet.Add(1)
+	meta := mcMeta(uint32(flags), exptime)
+
+	var stored bool
+	var err error
+	switch cmd {
+	case "set":
+		stored, err = true, s.cache.Set(key, data, meta)
+	case "add":
+		stored, err = s.cache.Add(key, data, meta)
+	case "replace":
+		stored, err = s.cache.Replace(key, data, meta)
+	case "cas":
+		err = s.cache.Txn(func(tx *stache.Tx) error {
+			switch tx.Version(key) {
+			case 0:
+				return stache.ErrNotFound
+			case unique:
+				return tx.Set(key, data, meta)
+			default:
+				return stache.ErrConflict
+			}
+		})
+		switch {
+		case errors.Is(err, stache.ErrNotFound):
+			s.stats.casMisses.Add(1)
+			mc.reply("NOT_FOUND")
+			return nil
+		case errors.Is(err, stache.ErrConflict):
+			s.stats.casBadval.Add(1)
+			mc.reply("EXISTS")
+			return nil
+		case err == nil:
+			s.stats.casHits.Add(1)
+			stored = true
+		}
+	}
+
+	switch {
+	case err != nil:
+		mc.reply("SERVER_ERROR %s", err)
+	case stored:
+		mc.reply("STORED")
+	default:
+		mc.reply("NOT_STORED")
+	}
+	return nil
+}
+
+// incr handles incr and decr on unsigned 64-bit values: incr wraps around
+// and decr stops at 0, as in memcached. The entry keeps its content type,
+// expiry and tags, but a sliding expiry becomes fixed.
+func (s *mcServer) incr(mc *mcConn, up bool, args []string) {
+	hits, misses := &s.stats.decrHits, &s.stats.decrMisses
+	if up {
+		hits, misses = &s.stats.incrHits, &s.stats.incrMisses
+	}
+
+	if len(args) != 2 {
+		mc.reply("ERROR")
+		return
+	}
+
+	delta, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		mc.reply("CLIENT_ERROR invalid numeric delta argument")
+		return
+	}
+
+	key := args[0]
+	var n uint64
+	err = s.cache.Txn(func(tx *stache.Tx) error {
+		value, info, err := tx.Get(key)
+		if err != nil {
+			return err
+		}
+
+		n, err = strconv.ParseUint(string(value), 10, 64)
+		if err != nil {
+			return stache.ErrNotInteger
+		}
+
+		switch {
+		case up:
+			n += delta
+		case delta > n:
+			n = 0
+		default:
+			n -= delta
+		}
+
+		return tx.Set(key, strconv.AppendUint(nil, n, 10), stache.Meta{
+			ContentType: info.ContentType,
+			ExpiresAt:   info.ExpiresAt,
+			Tags:        info.Tags,
+		})
+	})
+
+	switch {
+	case errors.Is(err, stache.ErrNotFound):
+		misses.Add(1)
+		mc.reply("NOT_FOUND")
+	case err != nil:
+		mc.reply("CLIENT_ERROR cannot increment or decrement non-numeric value")
+	default:
+		hits.Add(1)
+		mc.reply("%d", n)
+	}
+}
+
+func (s *mcServer) touch(mc *mcConn, args []string) {
+	if len(args) != 2 {
+		mc.reply("ERROR")
+		return
+	}
+
+	exptime, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		mc.reply("CLIENT_ERROR invalid exptime argument")
+		return
+	}
+
+	s.stats.cmdTouch.Add(1)
+
+	ttl, at := mcExpiry(exptime)
+	if at.IsZero() {
+		err = s.cache.Touch(args[0], ttl)
+	} else {
+		err = s.cache.ExpireAt(args[0], at)
+	}
+
+	if err != nil {
+		s.stats.touchMisses.Add(1)
+		mc.reply("NOT_FOUND")
+		return
+	}
+	s.stats.touchHits.Add(1)
+	mc.reply("TOUCHED")
+}
+
+// flushAll removes every entry, now or after an optional delay in seconds.
+func (s *mcServer) flushAll(mc *mcConn, args []string) {
+	if len(args) > 1 {
+		mc.reply("ERROR")
+		return
+	}
+
+	var delay int64
+	if len(args) == 1 {
+		var err error
+		delay, err = strconv.ParseInt(args[0], 10, 64)
+		if err != nil || delay < 0 {
+			mc.reply("CLIENT_ERROR bad command line format")
+			return
+		}
+	}
+
+	s.stats.cmdFlush.Add(1)
+	if delay == 0 {
+		s.cache.Clear()
+	} else {
+		time.AfterFunc(time.Duration(delay)*time.Second, func() { s.cache.Clear() })
+	}
+	mc.reply("OK")
+}
+
+func (s *mcServer) writeStats(mc *mcConn) {
+	now := time.Now()
+	entries := s.cache.Entries()
+	size := 0
+	for _, e := range entries {
+		size += e.Size
+	}
+
+	stat := func(name string, v any) {
+		fmt.Fprintf(mc.w, "STAT %s %v\r\n", name, v)
+	}
+
+	stat("pid", os.Getpid())
+	stat("uptime", int64(now.Sub(s.started).Seconds()))
+	stat("time", now.Unix())
+	stat("version", "1.0.0")
+	stat("curr_connections", s.Len())
+	stat("total_connections", s.stats.totalConns.Load())
+	stat("curr_items", len(entries))
+	stat("bytes", size)
+	stat("cmd_get", s.stats.cmdGet.Load())
+	stat("cmd_set", s.stats.cmdSet.Load())
+	stat("cmd_flush", s.stats.cmdFlush.Load())
+	stat("cmd_touch", s.stats.cmdTouch.Load())
+	stat("get_hits", s.stats.getHits.Load())
+	stat("get_misses", s.stats.getMisses.Load())
+	stat("delete_hits", s.stats.deleteHits.Load())
+	stat("delete_misses", s.stats.deleteMisses.Load())
+	stat("incr_hits", s.stats.incrHits.Load())
+	stat("incr_misses", s.stats.incrMisses.Load())
+	stat("decr_hits", s.stats.decrHits.Load())
+	stat("decr_misses", s.stats.decrMisses.Load())
+	stat("cas_hits", s.stats.casHits.Load())
+	stat("cas_misses", s.stats.casMisses.Load())
+	stat("cas_badval", s.stats.casBadval.Load())
+	stat("touch_hits", s.stats.touchHits.Load())
+	stat("touch_misses", s.stats.touchMisses.Load())
+	mc.w.WriteString("END\r\n")
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func startMemcached(t *testing.T) (*stache.Cache, net.Conn, *bufio.Reader) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	c := stache.NewCache()
+	s := newMemcachedServer(c, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	go s.Serve(ln)
+	t.Cleanup(func() { s.Close() })
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	return c, conn, bufio.NewReader(conn)
+}
+
+// mcDo sends req and reads lines until one that ends a reply.
+func mcDo(t *testing.T, conn net.Conn, r *bufio.Reader, req string) string {
+	t.Helper()
+
+	if _, err := io.WriteString(conn, req); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var out strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read after %q: %v", out.String(), err)
+		}
+		out.WriteString(line)
+
+		if strings.HasPrefix(line, "VALUE ") || strings.HasPrefix(line, "STAT ") {
+			continue
+		}
+		if strings.HasPrefix(out.String(), "VALUE ") && !strings.HasPrefix(line, "END") {
+			continue // data line
+		}
+		return out.String()
+	}
+}
+
+func TestMemcachedCommands(t *testing.T) {
+	c, conn, r := startMemcached(t)
+
+	tests := []struct {
+		req, want string
+	}{
+		{"get k\r\n", "END\r\n"},
+		{"set k 0 0 5\r\nhello\r\n", "STORED\r\n"},
+		{"get k missing\r\n", "VALUE k 0 5\r\nhello\r\nEND\r\n"},
+		{"add k 0 0 1\r\nx\r\n", "NOT_STORED\r\n"},
+		{"replace nope 0 0 1\r\nx\r\n", "NOT_STORED\r\n"},
+		{"set j 1 100 7\r\n{\"a\":1}\r\n", "STORED\r\n"},
+		{"set f 42 0 1 noreply\r\nz\r\n", ""},
+		{"get f\r\n", "VALUE f 42 1\r\nz\r\nEND\r\n"},
+		{"set n 0 0 2\r\n10\r\n", "STORED\r\n"},
+		{"incr n 5\r\n", "15\r\n"},
+		{"decr n 100\r\n", "0\r\n"},
+		{"incr k 1\r\n", "CLIENT_ERROR cannot increment or decrement non-numeric value\r\n"},
+		{"incr nope 1\r\n", "NOT_FOUND\r\n"},
+		{"touch k 100\r\n", "TOUCHED\r\n"},
+		{"touch nope 100\r\n", "NOT_FOUND\r\n"},
+		{"delete k\r\n", "DELETED\r\n"},
+		{"delete k\r\n", "NOT_FOUND\r\n"},
+		{"bogus\r\n", "ERROR\r\n"},
+	}
+
+	for _, tt := range tests {
+		if tt.want == "" {
+			// noreply: check it was applied with the next command instead
+			io.WriteString(conn, tt.req)
+			continue
+		}
+		if got := mcDo(t, conn, r, tt.req); got != tt.want {
+			t.Fatalf("%q mismatch: got=%q want=%q", tt.req, got, tt.want)
+		}
+	}
+
+	info, err := c.GetEntry("j")
+	if err != nil || info.ContentType != stache.JSON || info.ExpiresAt.IsZero() {
+		t.Fatalf("flags/exptime not mapped onto entry: %+v err=%v", info, err)
+	}
+
+	got := mcDo(t, conn, r, "stats\r\n")
+	for _, stat := range []string{"STAT get_hits 2\r\n", "STAT get_misses 2\r\n", "STAT incr_hits 1\r\n"} {
+		if !strings.Contains(got, stat) {
+			t.Fatalf("stats missing %q:\n%s", stat, got)
+		}
+	}
+
+	if got := mcDo(t, conn, r, "flush_all\r\n"); got != "OK\r\n" || c.Len() != 0 {
+		t.Fatalf("flush_all mismatch: got=%q len=%d", got, c.Len())
+	}
+}
+
+func TestMemcachedCAS(t *testing.T) {
+	_, conn, r := startMemcached(t)
+
+	mcDo(t, conn, r, "set k 0 0 1\r\na\r\n")
+	got := mcDo(t, conn, r, "gets k\r\n")
+	m := regexp.MustCompile(`^VALUE k 0 1 (\d+)\r\n`).FindStringSubmatch(got)
+	if m == nil {
+		t.Fatalf("gets mismatch: got=%q", got)
+	}
+	unique := m[1]
+
+	if got := mcDo(t, conn, r, "cas k 0 0 1 "+unique+"\r\nb\r\n"); got != "STORED\r\n" {
+		t.Fatalf("cas with current unique: got=%q", got)
+	}
+	if got := mcDo(t, conn, r, "cas k 0 0 1 "+unique+"\r\nc\r\n"); got != "EXISTS\r\n" {
+		t.Fatalf("cas with stale unique: got=%q", got)
+	}
+	if got := mcDo(t, conn, r, "cas nope 0 0 1 1\r\nc\r\n"); got != "NOT_FOUND\r\n" {
+		t.Fatalf("cas on missing key: got=%q", got)
+	}
+	if got := mcDo(t, conn, r, "get k\r\n"); got != "VALUE k 0 1\r\nb\r\nEND\r\n" {
+		t.Fatalf("value after cas: got=%q", got)
+	}
+}
@@ -3,14 +3,51 @@ package main
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	stachev1 "github.com/byytelope/stache/api/stache/v1"
 	"github.com/byytelope/stache/pkg/stache"
 )
 
+// requestTTL resolves the TTL of a request, preferring the typed duration
+// over the legacy whole-seconds field.
+func requestTTL(seconds int64, d *durationpb.Duration) (time.Duration, error) {
+	if d == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	if err := d.CheckValid(); err != nil {
+		return 0, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return d.AsDuration(), nil
+}
+
+// cacheError maps a stache error onto the matching Connect error code.
+func cacheError(err error) error {
+	switch {
+	case errors.Is(err, stache.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, stache.ErrIncorrectType), errors.Is(err, stache.ErrNotInteger),
+		errors.Is(err, stache.ErrOverflow):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, stache.ErrNotFloat):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return connect.NewError(connect.CodeDeadlineExceeded, err)
+	case errors.Is(err, context.Canceled):
+		return connect.NewError(connect.CodeCanceled, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
 func (s *cacheServer) Set(
 	ctx context.Context,
 	req *connect.Request[stachev1.SetRequest],
@@ -21,14 +58,34 @@ func (s *cacheServer) Set(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key required"))
 	}
 
-	ttl := time.Duration(r.GetTtl()) * time.Second
-	ct := stache.ContentType(*r.ContentType)
+	if peer := s.cluster.route(r.GetKey(), req.Header()); peer != nil {
+		return peer.Set(ctx, forward(s.cluster.self, req))
+	}
+
+	ttl, err := requestTTL(r.GetTtl(), r.GetTtlDuration())
+	if err != nil {
+		return nil, err
+	}
+
+	ct := stache.ContentType(r.GetContentType())
 	if ct == "" {
 		ct = stache.Text
 	}
 
-	if err := s.cache.Set(r.GetKey(), r.GetValue(), stache.Meta{TTL: ttl, ContentType: ct}); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	meta := stache.Meta{TTL: ttl, ContentType: ct, Sliding: r.GetSliding(), Tags: r.GetTags()}
+	if exp := r.GetExpiresAt(); exp != nil {
+		if err := exp.CheckValid(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		meta.ExpiresAt = exp.AsTime()
+	}
+
+	if s.raft != nil {
+		return s.raftSet(ctx, req, meta)
+	}
+
+	if err := s.cache.Set(r.GetKey(), r.GetValue(), meta); err != nil {
+		return nil, cacheError(err)
 	}
 
 	return connect.NewResponse(&stachev1.SetResponse{}), nil
@@ -43,17 +100,32 @@ func (s *cacheServer) Get(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key required"))
 	}
 
-	b, err := s.cache.GetBytes(key)
-	if err != nil {
-		if errors.Is(err, stache.ErrNotFound) {
-			return nil, connect.NewError(connect.CodeNotFound, err)
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		// Tracking ids belong to this node's Invalidations stream, and the
+		// owner's changes never reach it, so the read goes untracked
+		out := forward(s.cluster.self, req)
+		if req.Msg.TrackingId != nil {
+			out.Msg = proto.CloneOf(req.Msg)
+			out.Msg.TrackingId = nil
 		}
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return peer.Get(ctx, out)
 	}
 
-	entry, err := s.cache.GetEntry(key)
-	if err != nil && !errors.Is(err, stache.ErrNotFound) {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	// Register before reading, so that a change made in between is not
+	// missed.
+	id := req.Msg.GetTrackingId()
+	tracked := id != 0 && s.tracking.track(id, key)
+
+	b, entry, err := s.cache.GetWithInfo(key)
+	if err != nil {
+		if tracked {
+			s.tracking.untrack(id, key)
+		}
+		return nil, cacheError(err)
+	}
+	if tracked && entry.Sliding {
+		s.tracking.untrack(id, key)
+		tracked = false
 	}
 
 	var expMs int64
@@ -66,6 +138,8 @@ func (s *cacheServer) Get(
 		Value:       b,
 		ContentType: &ct,
 		ExpiresAtMs: &expMs,
+		Version:     &entry.Version,
+		Tracked:     &tracked,
 	}
 
 	return connect.NewResponse(res), nil
@@ -77,13 +151,37 @@ func (s *cacheServer) Delete(ctx context.Context, req *connect.Request[stachev1.
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
 	}
 
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.Delete(ctx, forward(s.cluster.self, req))
+	}
+
+	if s.raft != nil {
+		return s.raftDelete(ctx, req)
+	}
+
 	_, ok := s.cache.Delete(key)
 
 	return connect.NewResponse(&stachev1.DeleteResponse{Deleted: &ok}), nil
 }
 
-func (s *cacheServer) ListEntries(ctx context.Context, _ *connect.Request[stachev1.ListEntriesRequest]) (*connect.Response[stachev1.ListEntriesResponse], error) {
+func (s *cacheServer) ListEntries(ctx context.Context, req *connect.Request[stachev1.ListEntriesRequest]) (*connect.Response[stachev1.ListEntriesResponse], error) {
 	ents := s.cache.Entries()
+	if search := req.Msg.GetSearch(); search != "" {
+		ents = slices.DeleteFunc(ents, func(e stache.EntryInfo) bool {
+			return !strings.Contains(e.Key, search)
+		})
+	}
+	total := uint32(len(ents))
+
+	offset, limit := int(req.Msg.GetOffset()), int(req.Msg.GetLimit())
+	if offset > 0 || limit > 0 {
+		slices.SortFunc(ents, func(a, b stache.EntryInfo) int { return strings.Compare(a.Key, b.Key) })
+		ents = ents[min(offset, len(ents)):]
+		if limit > 0 && limit < len(ents) {
+			ents = ents[:limit]
+		}
+	}
+
 	out := make([]*stachev1.EntryInfo, 0, len(ents))
 	for _, e := range ents {
 		var expMs int64
@@ -97,8 +195,110 @@ func (s *cacheServer) ListEntries(ctx context.Context, _ *connect.Request[stache
 			Size:        &size,
 			ContentType: &ct,
 			ExpiresAtMs: &expMs,
+			Tags:        e.Tags,
+			Version:     &e.Version,
 		})
 	}
 
-	return connect.NewResponse(&stachev1.ListEntriesResponse{Entries: out}), nil
+	return connect.NewResponse(&stachev1.ListEntriesResponse{Entries: out, Total: &total}), nil
+}
+
+func (s *cacheServer) Stats(ctx context.Context, _ *connect.Request[stachev1.StatsRequest]) (*connect.Response[stachev1.StatsResponse], error) {
+	st := s.cache.Stats()
+	entries, bytes := uint64(st.Entries), uint64(st.Bytes)
+
+	return connect.NewResponse(&stachev1.StatsResponse{
+		Entries: &entries,
+		Bytes:   &bytes,
+		Hits:    &st.Hits,
+		Misses:  &st.Misses,
+		Uptime:  durationpb.New(time.Since(s.started)),
+	}), nil
+}
+
+func (s *cacheServer) Touch(ctx context.Context, req *connect.Request[stachev1.TouchRequest]) (*connect.Response[stachev1.TouchResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.Touch(ctx, forward(s.cluster.self, req))
+	}
+
+	if exp := req.Msg.GetExpiresAt(); exp != nil {
+		if err := exp.CheckValid(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if err := s.cache.ExpireAt(key, exp.AsTime()); err != nil {
+			return nil, cacheError(err)
+		}
+	} else {
+		ttl, err := requestTTL(req.Msg.GetTtl(), req.Msg.GetTtlDuration())
+		if err != nil {
+			return nil, err
+		}
+		if err := s.cache.Touch(key, ttl); err != nil {
+			return nil, cacheError(err)
+		}
+	}
+
+	var expMs int64
+	if entry, err := s.cache.GetEntry(key); err == nil && !entry.ExpiresAt.IsZero() {
+		expMs = entry.ExpiresAt.UnixMilli()
+	}
+
+	return connect.NewResponse(&stachev1.TouchResponse{ExpiresAtMs: &expMs}), nil
+}
+
+func (s *cacheServer) GetTTL(ctx context.Context, req *connect.Request[stachev1.GetTTLRequest]) (*connect.Response[stachev1.GetTTLResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.GetTTL(ctx, forward(s.cluster.self, req))
+	}
+
+	ttl, err := s.cache.TTL(key)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	var ttlMs, expMs int64
+	if ttl > 0 {
+		ttlMs = ttl.Milliseconds()
+		expMs = time.Now().Add(ttl).UnixMilli()
+	}
+
+	return connect.NewResponse(&stachev1.GetTTLResponse{TtlMs: &ttlMs, ExpiresAtMs: &expMs}), nil
+}
+
+func (s *cacheServer) InvalidateTags(ctx context.Context, req *connect.Request[stachev1.InvalidateTagsRequest]) (*connect.Response[stachev1.InvalidateTagsResponse], error) {
+	tags := req.Msg.GetTags()
+	if len(tags) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one tag is required"))
+	}
+
+	var removed uint32
+	for _, tag := range tags {
+		removed += uint32(s.cache.InvalidateTag(tag))
+	}
+
+	// Tagged entries may be on any node
+	if cl := s.cluster; cl != nil && !cl.forwarded(req.Header()) {
+		for _, node := range cl.ring.Nodes() {
+			if node == cl.self {
+				continue
+			}
+			res, err := cl.client(node).InvalidateTags(ctx, forward(cl.self, req))
+			if err != nil {
+				return nil, err
+			}
+			removed += res.Msg.GetRemoved()
+		}
+	}
+
+	return connect.NewResponse(&stachev1.InvalidateTagsResponse{Removed: &removed}), nil
 }
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// errPeerBatch reports a peer answering part of a batch with the wrong
+// number of results.
+var errPeerBatch = errors.New("peer returned a short batch")
+
+// BatchGet reads each key from the node owning it.
+func (s *cacheServer) BatchGet(ctx context.Context, req *connect.Request[stachev1.BatchGetRequest]) (*connect.Response[stachev1.BatchGetResponse], error) {
+	keys := req.Msg.GetKeys()
+	items := make([]*stachev1.GetResponseItem, len(keys))
+
+	err := s.cluster.scatter(keys, req.Header(), func(peer stachev1connect.CacheServiceClient, pos []int) error {
+		part := pick(keys, pos)
+		if peer == nil {
+			place(items, pos, s.batchGet(part))
+			return nil
+		}
+
+		res, err := peer.BatchGet(ctx, forwardMsg(s.cluster.self, req.Header(), &stachev1.BatchGetRequest{Keys: part}))
+		if err != nil {
+			return err
+		}
+		return place(items, pos, res.Msg.GetItems())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&stachev1.BatchGetResponse{Items: items}), nil
+}
+
+// pick returns the elements of s at positions pos.
+func pick[T any](s []T, pos []int) []T {
+	out := make([]T, len(pos))
+	for j, i := range pos {
+		out[j] = s[i]
+	}
+	return out
+}
+
+// place puts the results for the elements at positions pos back at those
+// positions of dst.
+func place[T any](dst []T, pos []int, results []T) error {
+	if len(results) != len(pos) {
+		return connect.NewError(connect.CodeInternal, errPeerBatch)
+	}
+	for j, i := range pos {
+		dst[i] = results[j]
+	}
+	return nil
+}
+
+func (s *cacheServer) batchGet(keys []string) []*stachev1.GetResponseItem {
+	items := make([]*stachev1.GetResponseItem, 0, len(keys))
+
+	for _, key := range keys {
+		item := &stachev1.GetResponseItem{Key: &key}
+		items = append(items, item)
+
+		b, entry, err := s.cache.GetWithInfo(key)
+		if err != nil {
+			continue
+		}
+
+		var expMs int64
+		if !entry.ExpiresAt.IsZero() {
+			expMs = entry.ExpiresAt.UnixMilli()
+		}
+
+		found := true
+		ct := string(entry.ContentType)
+		item.Value = b
+		item.ContentType = &ct
+		item.ExpiresAtMs = &expMs
+		item.Version = &entry.Version
+		item.Found = &found
+	}
+
+	return items
+}
+
+// batchItem converts a BatchSetItem, reporting why it is invalid if it is.
+func batchItem(it *stachev1.BatchSetItem) (stache.Item, error) {
+	if it.GetKey() == "" {
+		return stache.Item{}, errors.New("key is required")
+	}
+
+	meta, err := txnMeta(&stachev1.TxnSet{
+		ContentType: it.ContentType,
+		Ttl:         it.GetTtl(),
+		ExpiresAt:   it.GetExpiresAt(),
+		Sliding:     it.Sliding,
+		Tags:        it.GetTags(),
+	})
+	if err != nil {
+		return stache.Item{}, err
+	}
+
+	return stache.Item{Key: it.GetKey(), Value: it.GetValue(), Meta: meta}, nil
+}
+
+// BatchSet stores every valid item in one pass over the cache of the node
+// owning it. Invalid items are skipped and reported in their result rather
+// than failing the call.
+func (s *cacheServer) BatchSet(ctx context.Context, req *connect.Request[stachev1.BatchSetRequest]) (*connect.Response[stachev1.BatchSetResponse], error) {
+	reqItems := req.Msg.GetItems()
+	keys := make([]string, len(reqItems))
+	for i, it := range reqItems {
+		keys[i] = it.GetKey()
+	}
+	results := make([]*stachev1.BatchSetResult, len(reqItems))
+
+	err := s.cluster.scatter(keys, req.Header(), func(peer stachev1connect.CacheServiceClient, pos []int) error {
+		part := pick(reqItems, pos)
+		if peer == nil {
+			place(results, pos, s.batchSet(part))
+			return nil
+		}
+
+		res, err := peer.BatchSet(ctx, forwardMsg(s.cluster.self, req.Header(), &stachev1.BatchSetRequest{Items: part}))
+		if err != nil {
+			return err
+		}
+		return place(results, pos, res.Msg.GetResults())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&stachev1.BatchSetResponse{Results: results}), nil
+}
+
+func (s *cacheServer) batchSet(reqItems []*stachev1.BatchSetItem) []*stachev1.BatchSetResult {
+	results := make([]*stachev1.BatchSetResult, len(reqItems))
+
+	items := make([]stache.Item, 0, len(reqItems))
+	pos := make([]int, 0, len(reqItems))
+
+	for i, it := range reqItems {
+		key := it.GetKey()
+		results[i] = &stachev1.BatchSetResult{Key: &key}
+
+		item, err := batchItem(it)
+		if err != nil {
+			results[i].Error = batchError(err)
+			continue
+		}
+
+		items = append(items, item)
+		pos = append(pos, i)
+	}
+
+	for j, err := range s.cache.SetMany(items) {
+		r := results[pos[j]]
+		if err != nil {
+			r.Error = batchError(err)
+			continue
+		}
+
+		ok := true
+		r.Ok = &ok
+	}
+
+	return results
+}
+
+func batchError(err error) *string {
+	var connectErr *connect.Error
+	msg := err.Error()
+	if errors.As(err, &connectErr) {
+		msg = connectErr.Message()
+	}
+	return &msg
+}
+
+// BatchDelete removes each key from the node owning it.
+func (s *cacheServer) BatchDelete(ctx context.Context, req *connect.Request[stachev1.BatchDeleteRequest]) (*connect.Response[stachev1.BatchDeleteResponse], error) {
+	keys := req.Msg.GetKeys()
+	results := make([]*stachev1.BatchDeleteResult, len(keys))
+
+	err := s.cluster.scatter(keys, req.Header(), func(peer stachev1connect.CacheServiceClient, pos []int) error {
+		part := pick(keys, pos)
+		if peer == nil {
+			place(results, pos, s.batchDelete(part))
+			return nil
+		}
+
+		res, err := peer.BatchDelete(ctx, forwardMsg(s.cluster.self, req.Header(), &stachev1.BatchDeleteRequest{Keys: part}))
+		if err != nil {
+			return err
+		}
+		return place(results, pos, res.Msg.GetResults())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&stachev1.BatchDeleteResponse{Results: results}), nil
+}
+
+func (s *cacheServer) batchDelete(keys []string) []*stachev1.BatchDeleteResult {
+	existed := s.cache.DeleteMany(keys...)
+
+	results := make([]*stachev1.BatchDeleteResult, len(keys))
+	for i, key := range keys {
+		results[i] = &stachev1.BatchDeleteResult{Key: &key, Deleted: &existed[i]}
+	}
+
+	return results
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+func (s *cacheServer) HSet(ctx context.Context, req *connect.Request[stachev1.HSetRequest]) (*connect.Response[stachev1.HSetResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.HSet(ctx, forward(s.cluster.self, req))
+	}
+
+	created, err := s.cache.HSet(key, req.Msg.GetField(), req.Msg.GetValue())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.HSetResponse{Created: &created}), nil
+}
+
+func (s *cacheServer) HGet(ctx context.Context, req *connect.Request[stachev1.HGetRequest]) (*connect.Response[stachev1.HGetResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.HGet(ctx, forward(s.cluster.self, req))
+	}
+
+	v, err := s.cache.HGet(key, req.Msg.GetField())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.HGetResponse{Value: v}), nil
+}
+
+func (s *cacheServer) HDel(ctx context.Context, req *connect.Request[stachev1.HDelRequest]) (*connect.Response[stachev1.HDelResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.HDel(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.HDel(key, req.Msg.GetFields()...)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	removed := uint32(n)
+	return connect.NewResponse(&stachev1.HDelResponse{Removed: &removed}), nil
+}
+
+func (s *cacheServer) HGetAll(ctx context.Context, req *connect.Request[stachev1.HGetAllRequest]) (*connect.Response[stachev1.HGetAllResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.HGetAll(ctx, forward(s.cluster.self, req))
+	}
+
+	h, err := s.cache.HGetAll(key)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	out := make([]*stachev1.HashField, 0, len(h))
+	for _, f := range slices.Sorted(maps.Keys(h)) {
+		out = append(out, &stachev1.HashField{Field: &f, Value: h[f]})
+	}
+
+	return connect.NewResponse(&stachev1.HGetAllResponse{Fields: out}), nil
+}
+
+func (s *cacheServer) HIncrBy(ctx context.Context, req *connect.Request[stachev1.HIncrByRequest]) (*connect.Response[stachev1.HIncrByResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.HIncrBy(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.HIncrBy(key, req.Msg.GetField(), req.Msg.GetDelta())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.HIncrByResponse{Value: &n}), nil
+}
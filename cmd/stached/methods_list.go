@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+)
+
+func (s *cacheServer) LPush(ctx context.Context, req *connect.Request[stachev1.LPushRequest]) (*connect.Response[stachev1.LPushResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if len(req.Msg.GetValues()) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one value is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.LPush(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.LPush(key, req.Msg.GetValues()...)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	length := uint32(n)
+	return connect.NewResponse(&stachev1.LPushResponse{Length: &length}), nil
+}
+
+func (s *cacheServer) RPush(ctx context.Context, req *connect.Request[stachev1.RPushRequest]) (*connect.Response[stachev1.RPushResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if len(req.Msg.GetValues()) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one value is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.RPush(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.RPush(key, req.Msg.GetValues()...)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	length := uint32(n)
+	return connect.NewResponse(&stachev1.RPushResponse{Length: &length}), nil
+}
+
+func (s *cacheServer) LPop(ctx context.Context, req *connect.Request[stachev1.LPopRequest]) (*connect.Response[stachev1.LPopResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.LPop(ctx, forward(s.cluster.self, req))
+	}
+
+	v, err := s.cache.LPop(key)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.LPopResponse{Value: v}), nil
+}
+
+func (s *cacheServer) RPop(ctx context.Context, req *connect.Request[stachev1.RPopRequest]) (*connect.Response[stachev1.RPopResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.RPop(ctx, forward(s.cluster.self, req))
+	}
+
+	v, err := s.cache.RPop(key)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.RPopResponse{Value: v}), nil
+}
+
+func (s *cacheServer) LRange(ctx context.Context, req *connect.Request[stachev1.LRangeRequest]) (*connect.Response[stachev1.LRangeResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.LRange(ctx, forward(s.cluster.self, req))
+	}
+
+	vals, err := s.cache.LRange(key, int(req.Msg.GetStart()), int(req.Msg.GetStop()))
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.LRangeResponse{Values: vals}), nil
+}
+
+func (s *cacheServer) LLen(ctx context.Context, req *connect.Request[stachev1.LLenRequest]) (*connect.Response[stachev1.LLenResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.LLen(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.LLen(key)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	length := uint32(n)
+	return connect.NewResponse(&stachev1.LLenResponse{Length: &length}), nil
+}
+
+// BPop waits on the cache with the request context, so the caller's
+// Connect deadline (or a dropped connection) ends the wait.
+func (s *cacheServer) BPop(ctx context.Context, req *connect.Request[stachev1.BPopRequest]) (*connect.Response[stachev1.BPopResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.BPop(ctx, forward(s.cluster.self, req))
+	}
+
+	timeout := req.Msg.GetTimeout()
+	if err := timeout.CheckValid(); timeout != nil && err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	v, err := s.cache.BPop(ctx, key, timeout.AsDuration())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.BPopResponse{Value: v}), nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func (s *cacheServer) SAdd(ctx context.Context, req *connect.Request[stachev1.SAddRequest]) (*connect.Response[stachev1.SAddResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.SAdd(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.SAdd(key, req.Msg.GetMembers()...)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	added := uint32(n)
+	return connect.NewResponse(&stachev1.SAddResponse{Added: &added}), nil
+}
+
+func (s *cacheServer) SRem(ctx context.Context, req *connect.Request[stachev1.SRemRequest]) (*connect.Response[stachev1.SRemResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.SRem(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.SRem(key, req.Msg.GetMembers()...)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	removed := uint32(n)
+	return connect.NewResponse(&stachev1.SRemResponse{Removed: &removed}), nil
+}
+
+func (s *cacheServer) SIsMember(ctx context.Context, req *connect.Request[stachev1.SIsMemberRequest]) (*connect.Response[stachev1.SIsMemberResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.SIsMember(ctx, forward(s.cluster.self, req))
+	}
+
+	ok, err := s.cache.SIsMember(key, req.Msg.GetMember())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.SIsMemberResponse{IsMember: &ok}), nil
+}
+
+func (s *cacheServer) SMembers(ctx context.Context, req *connect.Request[stachev1.SMembersRequest]) (*connect.Response[stachev1.SMembersResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.SMembers(ctx, forward(s.cluster.self, req))
+	}
+
+	members, err := s.cache.SMembers(key)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.SMembersResponse{Members: members}), nil
+}
+
+func (s *cacheServer) SCard(ctx context.Context, req *connect.Request[stachev1.SCardRequest]) (*connect.Response[stachev1.SCardResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.SCard(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.SCard(key)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	count := uint32(n)
+	return connect.NewResponse(&stachev1.SCardResponse{Count: &count}), nil
+}
+
+func (s *cacheServer) SUnion(ctx context.Context, req *connect.Request[stachev1.SetAlgebraRequest]) (*connect.Response[stachev1.SetAlgebraResponse], error) {
+	return s.setAlgebra(ctx, req, stache.Union, stachev1connect.CacheServiceClient.SUnion)
+}
+
+func (s *cacheServer) SInter(ctx context.Context, req *connect.Request[stachev1.SetAlgebraRequest]) (*connect.Response[stachev1.SetAlgebraResponse], error) {
+	return s.setAlgebra(ctx, req, stache.Inter, stachev1connect.CacheServiceClient.SInter)
+}
+
+func (s *cacheServer) SDiff(ctx context.Context, req *connect.Request[stachev1.SetAlgebraRequest]) (*connect.Response[stachev1.SetAlgebraResponse], error) {
+	return s.setAlgebra(ctx, req, stache.Diff, stachev1connect.CacheServiceClient.SDiff)
+}
+
+// setAlgebra runs op, or calls forwardTo to have the node owning every
+// key, destination included, run it.
+func (s *cacheServer) setAlgebra(
+	ctx context.Context,
+	req *connect.Request[stachev1.SetAlgebraRequest],
+	op stache.SetOp,
+	forwardTo func(stachev1connect.CacheServiceClient, context.Context, *connect.Request[stachev1.SetAlgebraRequest]) (*connect.Response[stachev1.SetAlgebraResponse], error),
+) (*connect.Response[stachev1.SetAlgebraResponse], error) {
+	r := req.Msg
+	if len(r.GetKeys()) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one key is required"))
+	}
+
+	keys := r.GetKeys()
+	if dst := r.GetDestination(); dst != "" {
+		keys = append(slices.Clip(keys), dst)
+	}
+	peer, err := s.cluster.routeAll(keys, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	if peer != nil {
+		return forwardTo(peer, ctx, forward(s.cluster.self, req))
+	}
+
+	if dst := r.GetDestination(); dst != "" {
+		n, err := s.cache.SCombineStore(dst, op, r.GetKeys()...)
+		if err != nil {
+			return nil, cacheError(err)
+		}
+
+		count := uint32(n)
+		return connect.NewResponse(&stachev1.SetAlgebraResponse{Count: &count}), nil
+	}
+
+	members, err := s.cache.SCombine(op, r.GetKeys()...)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	count := uint32(len(members))
+	return connect.NewResponse(&stachev1.SetAlgebraResponse{Members: members, Count: &count}), nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// importBatch is how many records Import buffers before writing them
+// to the cache under a single lock.
+const importBatch = 512
+
+func toRecord(it stache.Item) *stachev1.EntryRecord {
+	ct := string(it.Meta.ContentType)
+	rec := &stachev1.EntryRecord{
+		Key:         &it.Key,
+		Value:       it.Value,
+		ContentType: &ct,
+		Tags:        it.Meta.Tags,
+	}
+
+	if !it.Meta.ExpiresAt.IsZero() {
+		rec.ExpiresAt = timestamppb.New(it.Meta.ExpiresAt)
+	}
+	if it.Meta.Sliding {
+		rec.Sliding = durationpb.New(it.Meta.TTL)
+	}
+
+	return rec
+}
+
+func fromRecord(rec *stachev1.EntryRecord) (stache.Item, error) {
+	if rec.GetKey() == "" {
+		return stache.Item{}, errors.New("key is required")
+	}
+
+	meta := stache.Meta{
+		ContentType: stache.ContentType(rec.GetContentType()),
+		Tags:        rec.GetTags(),
+	}
+	if meta.ContentType == "" {
+		meta.ContentType = stache.Text
+	}
+
+	if exp := rec.GetExpiresAt(); exp != nil {
+		if err := exp.CheckValid(); err != nil {
+			return stache.Item{}, err
+		}
+		meta.ExpiresAt = exp.AsTime()
+	}
+
+	if sliding := rec.GetSliding(); sliding != nil {
+		if err := sliding.CheckValid(); err != nil {
+			return stache.Item{}, err
+		}
+		meta.TTL = sliding.AsDuration()
+		meta.Sliding = true
+	}
+
+	return stache.Item{Key: rec.GetKey(), Value: rec.GetValue(), Meta: meta}, nil
+}
+
+// Export streams a snapshot of the byte-valued entries taken when the call starts.
+func (s *cacheServer) Export(ctx context.Context, req *connect.Request[stachev1.ExportRequest], stream *connect.ServerStream[stachev1.EntryRecord]) error {
+	for _, it := range s.cache.Items(req.Msg.GetPrefix()) {
+		if err := stream.Send(toRecord(it)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Import writes records in batches as they arrive, sending those for keys
+// another node owns to it. Records that have already expired or are invalid
+// are counted as skipped rather than failing the stream.
+func (s *cacheServer) Import(ctx context.Context, stream *connect.ClientStream[stachev1.EntryRecord]) (*connect.Response[stachev1.ImportResponse], error) {
+	var imported, skipped uint64
+	batch := make([]stache.Item, 0, importBatch)
+	peers := map[string][]*stachev1.BatchSetItem{}
+
+	flush := func() {
+		for _, err := range s.cache.SetMany(batch) {
+			if err != nil {
+				skipped++
+			} else {
+				imported++
+			}
+		}
+		batch = batch[:0]
+	}
+	flushPeer := func(owner string) error {
+		items := peers[owner]
+		delete(peers, owner)
+
+		req := forwardMsg(s.cluster.self, stream.RequestHeader(), &stachev1.BatchSetRequest{Items: items})
+		res, err := s.cluster.client(owner).BatchSet(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, r := range res.Msg.GetResults() {
+			if r.GetOk() {
+				imported++
+			} else {
+				skipped++
+			}
+		}
+		return nil
+	}
+
+	for stream.Receive() {
+		rec := stream.Msg()
+		it, err := fromRecord(rec)
+		if err != nil || (!it.Meta.ExpiresAt.IsZero() && it.Meta.ExpiresAt.Before(time.Now())) {
+			skipped++
+			continue
+		}
+
+		if s.cluster != nil {
+			if owner := s.cluster.owner(it.Key); owner != "" {
+				peers[owner] = append(peers[owner], recordItem(rec))
+				if len(peers[owner]) == importBatch {
+					if err := flushPeer(owner); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+		}
+
+		batch = append(batch, it)
+		if len(batch) == importBatch {
+			flush()
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	for owner := range peers {
+		if err := flushPeer(owner); err != nil {
+			return nil, err
+		}
+	}
+
+	return connect.NewResponse(&stachev1.ImportResponse{Imported: &imported, Skipped: &skipped}), nil
+}
+
+// recordItem converts a valid record for BatchSet.
+func recordItem(rec *stachev1.EntryRecord) *stachev1.BatchSetItem {
+	item := &stachev1.BatchSetItem{
+		Key:         rec.Key,
+		Value:       rec.Value,
+		ContentType: rec.ContentType,
+		ExpiresAt:   rec.ExpiresAt,
+		Tags:        rec.Tags,
+	}
+	if ttl := rec.GetSliding(); ttl != nil {
+		sliding := true
+		item.Ttl = ttl
+		item.Sliding = &sliding
+	}
+	return item
+}
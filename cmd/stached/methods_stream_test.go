@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func TestImportSlowStream(t *testing.T) {
+	ctx := context.Background()
+	const readTimeout = 100 * time.Millisecond
+
+	c := stache.NewCache()
+	mux := http.NewServeMux()
+	mux.Handle(stachev1connect.NewCacheServiceHandler(&cacheServer{cache: c}))
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config = newHTTPServer("", mux, readTimeout)
+	ts.Start()
+	defer ts.Close()
+
+	h2c := &http.Transport{Protocols: new(http.Protocols)}
+	h2c.Protocols.SetUnencryptedHTTP2(true)
+
+	for name, hc := range map[string]*http.Client{"http1": ts.Client(), "h2c": {Transport: h2c}} {
+		t.Run(name, func(t *testing.T) {
+			client := stachev1connect.NewCacheServiceClient(hc, ts.URL)
+
+			// The stream outlives readTimeout several times over
+			stream := client.Import(ctx)
+			for i := range 5 {
+				key := fmt.Sprintf("%s:%d", name, i)
+				if err := stream.Send(&stachev1.EntryRecord{Key: &key, Value: []byte("v")}); err != nil {
+					t.Fatalf("Send %d: %v", i, err)
+				}
+				time.Sleep(readTimeout / 2)
+			}
+			res, err := stream.CloseAndReceive()
+			if err != nil || res.Msg.GetImported() != 5 {
+				t.Fatalf("Import: res=%v err=%v", res, err)
+			}
+
+			// A unary request still has to send its body in time
+			body, w := io.Pipe()
+			go func() {
+				time.Sleep(3 * readTimeout)
+				_, _ = io.WriteString(w, `{"key":"late","value":"dg=="}`)
+				w.Close()
+			}()
+			req, _ := http.NewRequest(http.MethodPost, ts.URL+stachev1connect.CacheServiceSetProcedure, body)
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := hc.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if err == nil && resp.StatusCode == http.StatusOK {
+				t.Fatalf("slow unary body: expected failure, got %s", resp.Status)
+			}
+			if _, err := c.GetBytes("late"); err == nil {
+				t.Fatalf("slow unary body: expected the write to be dropped")
+			}
+
+			// A body sent in time leaves the response as long as it needs
+			key := "jobs"
+			_, err = client.BPop(ctx, connect.NewRequest(&stachev1.BPopRequest{Key: &key, Timeout: durationpb.New(3 * readTimeout)}))
+			if connect.CodeOf(err) != connect.CodeNotFound {
+				t.Fatalf("BPop past readTimeout: expected NotFound, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func TestZAddNaN(t *testing.T) {
+	service := &cacheServer{cache: stache.NewCache()}
+
+	key, member, score := "lb", "m", math.NaN()
+	_, err := service.ZAdd(context.Background(), connect.NewRequest(&stachev1.ZAddRequest{Key: &key, Member: &member, Score: &score}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("ZAdd NaN: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestPushWithoutValues(t *testing.T) {
+	service := &cacheServer{cache: stache.NewCache()}
+	ctx, key := context.Background(), "q"
+
+	_, err := service.LPush(ctx, connect.NewRequest(&stachev1.LPushRequest{Key: &key}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("LPush without values: expected InvalidArgument, got %v", err)
+	}
+	_, err = service.RPush(ctx, connect.NewRequest(&stachev1.RPushRequest{Key: &key}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("RPush without values: expected InvalidArgument, got %v", err)
+	}
+	_, err = service.LPop(ctx, connect.NewRequest(&stachev1.LPopRequest{Key: &key}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("LPop after empty pushes: expected NotFound, got %v", err)
+	}
+}
+
+// BenchmarkGet measures the Get handler, without the transport.
+func BenchmarkGet(b *testing.B) {
+	c := stache.NewCache()
+	service := &cacheServer{cache: c}
+	_ = c.Set("k", make([]byte, 1024), stache.Meta{ContentType: stache.Text})
+
+	key := "k"
+	req := connect.NewRequest(&stachev1.GetRequest{Key: &key})
+	ctx := context.Background()
+
+	b.Run("serial", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := service.Get(ctx, req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := service.Get(ctx, req); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
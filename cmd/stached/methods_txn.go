@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// txnMeta converts a TxnSet into the metadata Tx.Set expects.
+func txnMeta(set *stachev1.TxnSet) (stache.Meta, error) {
+	meta := stache.Meta{
+		ContentType: stache.ContentType(set.GetContentType()),
+		Sliding:     set.GetSliding(),
+		Tags:        set.GetTags(),
+	}
+	if meta.ContentType == "" {
+		meta.ContentType = stache.Text
+	}
+
+	if ttl := set.GetTtl(); ttl != nil {
+		if err := ttl.CheckValid(); err != nil {
+			return stache.Meta{}, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		meta.TTL = ttl.AsDuration()
+	}
+
+	if exp := set.GetExpiresAt(); exp != nil {
+		if err := exp.CheckValid(); err != nil {
+			return stache.Meta{}, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		meta.ExpiresAt = exp.AsTime()
+	}
+
+	return meta, nil
+}
+
+// Transaction checks every op's precondition against the versions before
+// the transaction, then applies all ops atomically or none of them.
+func (s *cacheServer) Transaction(ctx context.Context, req *connect.Request[stachev1.TransactionRequest]) (*connect.Response[stachev1.TransactionResponse], error) {
+	ops := req.Msg.GetOps()
+	if len(ops) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one op is required"))
+	}
+
+	for _, op := range ops {
+		if op.GetKey() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+		}
+
+		if set := op.GetSet(); set != nil {
+			if _, err := txnMeta(set); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	keys := make([]string, len(ops))
+	for i, op := range ops {
+		keys[i] = op.GetKey()
+	}
+	peer, err := s.cluster.routeAll(keys, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	if peer != nil {
+		return peer.Transaction(ctx, forward(s.cluster.self, req))
+	}
+
+	if s.raft != nil {
+		if peer := s.raft.route(req.Header()); peer != nil {
+			return peer.Transaction(ctx, forward(s.raft.self, req))
+		}
+
+		res, _, err := s.raft.commit(ctx, ops)
+		if err != nil {
+			return nil, err
+		}
+		return connect.NewResponse(res), nil
+	}
+
+	res, _, err := applyTxn(s.cache, time.Now(), ops)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// applyTxn runs validated ops as one transaction at now. before holds the
+// version of each op's key as the transaction found it.
+func applyTxn(c *stache.Cache, now time.Time, ops []*stachev1.TxnOp) (res *stachev1.TransactionResponse, before []uint64, err error) {
+	results := make([]*stachev1.TxnOpResult, len(ops))
+	before = make([]uint64, len(ops))
+	var committedTx *stache.Tx
+
+	err = c.TxnAt(now, func(tx *stache.Tx) error {
+		conflict := false
+		for i, op := range ops {
+			key := op.GetKey()
+			version := tx.Version(key)
+			ok := op.ExpectedVersion == nil || version == op.GetExpectedVersion()
+			conflict = conflict || !ok
+
+			before[i] = version
+			results[i] = &stachev1.TxnOpResult{Key: &key, Ok: &ok, Version: &version}
+		}
+
+		if conflict {
+			return stache.ErrConflict
+		}
+
+		for _, op := range ops {
+			switch op.GetAction().(type) {
+			case *stachev1.TxnOp_Set:
+				meta, err := txnMeta(op.GetSet())
+				if err != nil {
+					return err
+				}
+				if err := tx.Set(op.GetKey(), op.GetSet().GetValue(), meta); err != nil {
+					return err
+				}
+			case *stachev1.TxnOp_Delete:
+				tx.Delete(op.GetKey())
+			}
+		}
+
+		committedTx = tx
+		return nil
+	})
+	if err != nil && !errors.Is(err, stache.ErrConflict) {
+		return nil, nil, err
+	}
+
+	committed := err == nil
+	if committed {
+		for _, r := range results {
+			version := committedTx.Version(r.GetKey())
+			r.Version = &version
+		}
+	}
+
+	return &stachev1.TransactionResponse{Committed: &committed, Results: results}, before, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func toZMembers(members []stache.ZMember) []*stachev1.ZMember {
+	out := make([]*stachev1.ZMember, 0, len(members))
+	for _, m := range members {
+		out = append(out, &stachev1.ZMember{Member: &m.Member, Score: &m.Score})
+	}
+	return out
+}
+
+func (s *cacheServer) ZAdd(ctx context.Context, req *connect.Request[stachev1.ZAddRequest]) (*connect.Response[stachev1.ZAddResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.ZAdd(ctx, forward(s.cluster.self, req))
+	}
+
+	added, err := s.cache.ZAdd(key, req.Msg.GetScore(), req.Msg.GetMember())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.ZAddResponse{Added: &added}), nil
+}
+
+func (s *cacheServer) ZRem(ctx context.Context, req *connect.Request[stachev1.ZRemRequest]) (*connect.Response[stachev1.ZRemResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.ZRem(ctx, forward(s.cluster.self, req))
+	}
+
+	n, err := s.cache.ZRem(key, req.Msg.GetMembers()...)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	removed := uint32(n)
+	return connect.NewResponse(&stachev1.ZRemResponse{Removed: &removed}), nil
+}
+
+func (s *cacheServer) ZScore(ctx context.Context, req *connect.Request[stachev1.ZScoreRequest]) (*connect.Response[stachev1.ZScoreResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.ZScore(ctx, forward(s.cluster.self, req))
+	}
+
+	score, err := s.cache.ZScore(key, req.Msg.GetMember())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.ZScoreResponse{Score: &score}), nil
+}
+
+func (s *cacheServer) ZRank(ctx context.Context, req *connect.Request[stachev1.ZRankRequest]) (*connect.Response[stachev1.ZRankResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.ZRank(ctx, forward(s.cluster.self, req))
+	}
+
+	r, err := s.cache.ZRank(key, req.Msg.GetMember())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	rank := uint64(r)
+	return connect.NewResponse(&stachev1.ZRankResponse{Rank: &rank}), nil
+}
+
+func (s *cacheServer) ZRangeByScore(ctx context.Context, req *connect.Request[stachev1.ZRangeByScoreRequest]) (*connect.Response[stachev1.ZRangeByScoreResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.ZRangeByScore(ctx, forward(s.cluster.self, req))
+	}
+
+	members, err := s.cache.ZRangeByScore(key, req.Msg.GetMin(), req.Msg.GetMax())
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.ZRangeByScoreResponse{Members: toZMembers(members)}), nil
+}
+
+func (s *cacheServer) ZRangeByRank(ctx context.Context, req *connect.Request[stachev1.ZRangeByRankRequest]) (*connect.Response[stachev1.ZRangeByRankResponse], error) {
+	key := req.Msg.GetKey()
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key is required"))
+	}
+
+	if peer := s.cluster.route(key, req.Header()); peer != nil {
+		return peer.ZRangeByRank(ctx, forward(s.cluster.self, req))
+	}
+
+	members, err := s.cache.ZRangeByRank(key, int(req.Msg.GetStart()), int(req.Msg.GetStop()))
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	return connect.NewResponse(&stachev1.ZRangeByRankResponse{Members: toZMembers(members)}), nil
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/raft"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+var (
+	errNoLeader         = errors.New("no raft leader is known, retry shortly")
+	errRaftNotSupported = errors.New("in raft mode only the Set, Delete and Transaction RPCs may write")
+
+	// Reads extend sliding expiry on the node serving them only, which
+	// would leave the nodes disagreeing about when the entry expires.
+	errRaftSliding = errors.New("sliding expiry is not supported in raft mode")
+)
+
+// raftExpiryInterval is how often the leader has the group remove expired
+// entries.
+const raftExpiryInterval = 30 * time.Second
+
+// raftWrites are the RPCs that go through the log in raft mode; the other
+// writes are refused, since they would change one node only.
+var raftWrites = map[string]bool{
+	stachev1connect.CacheServiceSetProcedure:         true,
+	stachev1connect.CacheServiceDeleteProcedure:      true,
+	stachev1connect.CacheServiceTransactionProcedure: true,
+}
+
+// A raft command is the leader's clock as Unix nanoseconds (8 bytes, big
+// endian) followed by a TransactionRequest. Every node runs the
+// transaction at that time, so they agree on expiry.
+func encodeRaftCommand(now time.Time, ops []*stachev1.TxnOp) ([]byte, error) {
+	b, err := proto.Marshal(&stachev1.TransactionRequest{Ops: ops})
+	if err != nil {
+		return nil, err
+	}
+	return append(binary.BigEndian.AppendUint64(nil, uint64(now.UnixNano())), b...), nil
+}
+
+func decodeRaftCommand(b []byte) (time.Time, []*stachev1.TxnOp, error) {
+	if len(b) < 8 {
+		return time.Time{}, nil, errors.New("raft command too short")
+	}
+
+	var req stachev1.TransactionRequest
+	if err := proto.Unmarshal(b[8:], &req); err != nil {
+		return time.Time{}, nil, err
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b))), req.GetOps(), nil
+}
+
+// raftFSM applies committed commands to the cache. Snapshots use the
+// cache's own serialization, which keeps entry versions so that
+// Transaction preconditions agree across nodes.
+type raftFSM struct {
+	cache *stache.Cache
+}
+
+type raftResult struct {
+	res    *stachev1.TransactionResponse
+	before []uint64
+	err    error
+}
+
+func (f raftFSM) Apply(cmd []byte) any {
+	now, ops, err := decodeRaftCommand(cmd)
+	if err != nil {
+		return raftResult{err: err}
+	}
+
+	// A command without ops removes what has expired by its time
+	if len(ops) == 0 {
+		f.cache.RemoveExpired(now)
+		return raftResult{}
+	}
+
+	res, before, err := applyTxn(f.cache, now, ops)
+	return raftResult{res: res, before: before, err: err}
+}
+
+func (f raftFSM) Snapshot() ([]byte, error) {
+	var buf bytes.Buffer
+	err := f.cache.WriteSnapshot(&buf)
+	return buf.Bytes(), err
+}
+
+func (f raftFSM) Restore(snapshot []byte) error {
+	return f.cache.ReadSnapshot(bytes.NewReader(snapshot))
+}
+
+// raftState makes this node a member of a Raft group named by the nodes'
+// base URLs. Writes are committed through the leader's log before they are
+// applied, so they are linearizable and survive the loss of a minority of
+// nodes; reads are served from the local copy.
+type raftState struct {
+	self   string
+	node   *raft.Node
+	logger *slog.Logger
+	peerClients
+}
+
+// newRaft returns the raft state of a node keeping its term, vote and log
+// in dir.
+func newRaft(self string, peers []string, dir string, token string, c *stache.Cache, logger *slog.Logger) (*raftState, error) {
+	cfg := raft.Config{ID: self, Peers: peers, Dir: dir, Logger: logger}
+	tr := &raft.HTTPTransport{Client: &http.Client{}, Token: token}
+
+	node, err := raft.NewNode(cfg, raftFSM{c}, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &raftState{
+		self:        self,
+		node:        node,
+		logger:      logger,
+		peerClients: newPeerClients(),
+	}, nil
+}
+
+// run has the group remove expired entries every raftExpiryInterval while
+// this node leads, until ctx is done. The cache keeps expired entries in
+// raft mode, as removing them when a read comes across them would make what
+// a command finds depend on when each node was read.
+func (rs *raftState) run(ctx context.Context) {
+	ticker := time.NewTicker(raftExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if rs.node.Leader() != rs.self {
+			continue
+		}
+		if err := rs.removeExpired(ctx); err != nil && ctx.Err() == nil {
+			rs.logger.Warn("raft expiry failed", "err", err)
+		}
+	}
+}
+
+// removeExpired commits a command removing the entries expired by now.
+func (rs *raftState) removeExpired(ctx context.Context) error {
+	cmd, err := encodeRaftCommand(time.Now(), nil)
+	if err != nil {
+		return err
+	}
+	_, err = rs.node.Propose(ctx, cmd)
+	return err
+}
+
+// route returns a client for the leader, or nil if this node should
+// propose the write itself: because it leads, no leader is known yet, or
+// the request was already forwarded.
+func (rs *raftState) route(h http.Header) stachev1connect.CacheServiceClient {
+	if h.Get(forwardedHeader) != "" {
+		return nil
+	}
+
+	leader := rs.node.Leader()
+	if leader == "" || leader == rs.self {
+		return nil
+	}
+	return rs.client(leader)
+}
+
+// commit proposes ops and waits for them to be applied.
+func (rs *raftState) commit(ctx context.Context, ops []*stachev1.TxnOp) (*stachev1.TransactionResponse, []uint64, error) {
+	for _, op := range ops {
+		if op.GetSet().GetSliding() {
+			return nil, nil, connect.NewError(connect.CodeFailedPrecondition, errRaftSliding)
+		}
+	}
+
+	cmd, err := encodeRaftCommand(time.Now(), ops)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	v, err := rs.node.Propose(ctx, cmd)
+	switch {
+	case errors.Is(err, raft.ErrNotLeader):
+		return nil, nil, connect.NewError(connect.CodeUnavailable, errNoLeader)
+	case errors.Is(err, raft.ErrLeadershipLost), errors.Is(err, raft.ErrStopped):
+		return nil, nil, connect.NewError(connect.CodeUnavailable, err)
+	case err != nil:
+		return nil, nil, cacheError(err)
+	}
+
+	r := v.(raftResult)
+	if r.err != nil {
+		return nil, nil, cacheError(r.err)
+	}
+	return r.res, r.before, nil
+}
+
+func (s *cacheServer) raftSet(ctx context.Context, req *connect.Request[stachev1.SetRequest], meta stache.Meta) (*connect.Response[stachev1.SetResponse], error) {
+	if peer := s.raft.route(req.Header()); peer != nil {
+		return peer.Set(ctx, forward(s.raft.self, req))
+	}
+
+	key, ct := req.Msg.GetKey(), string(meta.ContentType)
+	set := &stachev1.TxnSet{
+		Value:       req.Msg.GetValue(),
+		ContentType: &ct,
+		ExpiresAt:   req.Msg.GetExpiresAt(),
+		Sliding:     &meta.Sliding,
+		Tags:        meta.Tags,
+	}
+	if meta.TTL > 0 {
+		set.Ttl = durationpb.New(meta.TTL)
+	}
+
+	op := &stachev1.TxnOp{Key: &key, Action: &stachev1.TxnOp_Set{Set: set}}
+	if _, _, err := s.raft.commit(ctx, []*stachev1.TxnOp{op}); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&stachev1.SetResponse{}), nil
+}
+
+func (s *cacheServer) raftDelete(ctx context.Context, req *connect.Request[stachev1.DeleteRequest]) (*connect.Response[stachev1.DeleteResponse], error) {
+	if peer := s.raft.route(req.Header()); peer != nil {
+		return peer.Delete(ctx, forward(s.raft.self, req))
+	}
+
+	key := req.Msg.GetKey()
+	op := &stachev1.TxnOp{Key: &key, Action: &stachev1.TxnOp_Delete{Delete: &stachev1.TxnDelete{}}}
+	_, before, err := s.raft.commit(ctx, []*stachev1.TxnOp{op})
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := before[0] != 0
+	return connect.NewResponse(&stachev1.DeleteResponse{Deleted: &deleted}), nil
+}
+
+func (s *cacheServer) RaftStatus(ctx context.Context, req *connect.Request[stachev1.RaftStatusRequest]) (*connect.Response[stachev1.RaftStatusResponse], error) {
+	enabled := s.raft != nil
+	if !enabled {
+		return connect.NewResponse(&stachev1.RaftStatusResponse{Enabled: &enabled}), nil
+	}
+
+	st := s.raft.node.Status()
+	state := st.State.String()
+	return connect.NewResponse(&stachev1.RaftStatusResponse{
+		Enabled:       &enabled,
+		Id:            &st.ID,
+		State:         &state,
+		Term:          &st.Term,
+		Leader:        &st.Leader,
+		Peers:         st.Peers,
+		LastIndex:     &st.LastIndex,
+		CommitIndex:   &st.CommitIndex,
+		AppliedIndex:  &st.AppliedIndex,
+		SnapshotIndex: &st.SnapshotIndex,
+	}), nil
+}
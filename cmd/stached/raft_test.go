@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/raft"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+type raftTestNode struct {
+	testNode
+	server *httptest.Server
+}
+
+// startRaftGroup runs n stached nodes in raft mode on loopback.
+func startRaftGroup(t *testing.T, n int) []raftTestNode {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	servers := make([]*httptest.Server, n)
+	urls := make([]string, n)
+	for i := range n {
+		servers[i] = httptest.NewUnstartedServer(nil)
+		urls[i] = "http://" + servers[i].Listener.Addr().String()
+	}
+
+	nodes := make([]raftTestNode, n)
+	for i, ts := range servers {
+		peers := slices.Delete(slices.Clone(urls), i, i+1)
+		c := stache.NewCacheWithOptions(stache.Options{KeepExpired: true})
+		rs, err := newRaft(urls[i], peers, t.TempDir(), "", c, logger)
+		if err != nil {
+			t.Fatalf("newRaft: %v", err)
+		}
+		service := &cacheServer{cache: c, raft: rs}
+
+		mux := http.NewServeMux()
+		mux.Handle(stachev1connect.NewCacheServiceHandler(service,
+			connect.WithInterceptors(readOnlyInterceptor{allow: raftWrites, err: errRaftNotSupported})))
+		mux.Handle(raft.HTTPPrefix, raft.Handler(service.raft.node))
+		ts.Config.Handler = mux
+		ts.Start()
+		service.raft.node.Start()
+		t.Cleanup(func() {
+			service.raft.node.Stop()
+			ts.Close()
+		})
+
+		nodes[i] = raftTestNode{
+			testNode: testNode{url: urls[i], cache: c, service: service, client: stachev1connect.NewCacheServiceClient(ts.Client(), urls[i])},
+			server:   ts,
+		}
+	}
+
+	return nodes
+}
+
+// raftLeader waits until the given nodes agree on a leader among them.
+func raftLeader(t *testing.T, nodes []raftTestNode) int {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		leader := nodes[0].service.raft.node.Leader()
+		agreed := leader != ""
+		for _, n := range nodes {
+			agreed = agreed && n.service.raft.node.Leader() == leader
+		}
+		for i, n := range nodes {
+			if agreed && n.url == leader {
+				return i
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatal("no raft leader elected")
+	return -1
+}
+
+func TestRaftGroup(t *testing.T) {
+	ctx := context.Background()
+	nodes := startRaftGroup(t, 3)
+	leader := raftLeader(t, nodes)
+	follower := (leader + 1) % 3
+
+	// A write sent to a follower is forwarded to the leader and, once it
+	// returns, applied on a majority
+	key := "lock"
+	_, err := nodes[follower].client.Set(ctx, connect.NewRequest(&stachev1.SetRequest{Key: &key, Value: []byte("a")}))
+	if err != nil {
+		t.Fatalf("Set via follower: %v", err)
+	}
+	eventually(t, "write applied on every node", func() bool {
+		for _, n := range nodes {
+			if v, err := n.cache.GetString(key); err != nil || v != "a" {
+				return false
+			}
+		}
+		return true
+	})
+
+	// Versions agree across nodes, so compare-and-swap works through any of them
+	res, err := nodes[follower].client.Get(ctx, connect.NewRequest(&stachev1.GetRequest{Key: &key}))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	version := res.Msg.GetVersion()
+	for _, n := range nodes {
+		if info, _ := n.cache.GetEntry(key); info.Version != version {
+			t.Fatalf("%s has version %d, want %d", n.url, info.Version, version)
+		}
+	}
+
+	cas := func(via int, expected uint64, value string) bool {
+		t.Helper()
+		op := &stachev1.TxnOp{Key: &key, ExpectedVersion: &expected, Action: &stachev1.TxnOp_Set{Set: &stachev1.TxnSet{Value: []byte(value)}}}
+		res, err := nodes[via].client.Transaction(ctx, connect.NewRequest(&stachev1.TransactionRequest{Ops: []*stachev1.TxnOp{op}}))
+		if err != nil {
+			t.Fatalf("Transaction: %v", err)
+		}
+		return res.Msg.GetCommitted()
+	}
+	if !cas(follower, version, "b") {
+		t.Fatal("CAS with the current version should commit")
+	}
+	if cas(leader, version, "c") {
+		t.Fatal("CAS with a stale version should not commit")
+	}
+
+	// Writes that would bypass the log are refused
+	_, err = nodes[leader].client.HSet(ctx, connect.NewRequest(&stachev1.HSetRequest{Key: &key}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("HSet in raft mode: got=%v want FailedPrecondition", err)
+	}
+	sliding := true
+	_, err = nodes[follower].client.Set(ctx, connect.NewRequest(&stachev1.SetRequest{Key: &key, Value: []byte("d"), Sliding: &sliding}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("sliding Set in raft mode: got=%v want FailedPrecondition", err)
+	}
+
+	// Losing the leader: the other two elect a new one and keep accepting writes
+	nodes[leader].service.raft.node.Stop()
+	nodes[leader].server.Close()
+	rest := slices.Delete(slices.Clone(nodes), leader, leader+1)
+	raftLeader(t, rest)
+
+	del, err := rest[0].client.Delete(ctx, connect.NewRequest(&stachev1.DeleteRequest{Key: &key}))
+	if err != nil || !del.Msg.GetDeleted() {
+		t.Fatalf("Delete after leader loss: deleted=%v err=%v", del.Msg.GetDeleted(), err)
+	}
+	eventually(t, "delete applied on survivors", func() bool {
+		for _, n := range rest {
+			if _, err := n.cache.GetBytes(key); err == nil {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestRaftExpiry(t *testing.T) {
+	f := raftFSM{stache.NewCacheWithOptions(stache.Options{KeepExpired: true})}
+	apply := func(now time.Time, ops ...*stachev1.TxnOp) raftResult {
+		t.Helper()
+		cmd, err := encodeRaftCommand(now, ops)
+		if err != nil {
+			t.Fatalf("encodeRaftCommand: %v", err)
+		}
+		return f.Apply(cmd).(raftResult)
+	}
+
+	key, start := "k", time.Now()
+	set := &stachev1.TxnOp{Key: &key, Action: &stachev1.TxnOp_Set{Set: &stachev1.TxnSet{Value: []byte("a"), Ttl: durationpb.New(50 * time.Millisecond)}}}
+	r := apply(start, set)
+	version := r.res.GetResults()[0].GetVersion()
+
+	// A read after the entry expired by this node's clock must not change
+	// what a command from before then finds
+	time.Sleep(80 * time.Millisecond)
+	if _, err := f.cache.GetBytes(key); !errors.Is(err, stache.ErrNotFound) {
+		t.Fatalf("GetBytes after expiry: %v", err)
+	}
+	cas := &stachev1.TxnOp{Key: &key, ExpectedVersion: &version, Action: &stachev1.TxnOp_Set{Set: &stachev1.TxnSet{Value: []byte("b")}}}
+	if r := apply(start.Add(10*time.Millisecond), cas); r.err != nil || !r.res.GetCommitted() {
+		t.Fatalf("CAS replayed before expiry: res=%v err=%v", r.res, r.err)
+	}
+
+	// Expired entries go only when the log says so
+	set.GetSet().Ttl = durationpb.New(time.Millisecond)
+	apply(start.Add(20*time.Millisecond), set)
+	if f.cache.Len() != 1 {
+		t.Fatalf("expired entry removed before an expiry command: len=%d", f.cache.Len())
+	}
+	apply(time.Now())
+	if f.cache.Len() != 0 {
+		t.Fatalf("expiry command left %d entries", f.cache.Len())
+	}
+}
+
+func TestRaftRemoveExpired(t *testing.T) {
+	ctx := context.Background()
+	nodes := startRaftGroup(t, 3)
+	leader := raftLeader(t, nodes)
+
+	key := "k"
+	_, err := nodes[leader].client.Set(ctx, connect.NewRequest(&stachev1.SetRequest{Key: &key, Value: []byte("v"), TtlDuration: durationpb.New(20 * time.Millisecond)}))
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if err := nodes[leader].service.raft.removeExpired(ctx); err != nil {
+		t.Fatalf("removeExpired: %v", err)
+	}
+	eventually(t, "expired entry removed on every node", func() bool {
+		for _, n := range nodes {
+			if n.cache.Len() != 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
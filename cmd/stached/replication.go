@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+const (
+	// replicationBuffer is how many changes a primary queues for a replica
+	// that is not keeping up before dropping it; the replica then resyncs.
+	replicationBuffer = 1 << 16
+
+	replicationHeartbeat = time.Second
+
+	replicaMinBackoff = 100 * time.Millisecond
+	replicaMaxBackoff = 5 * time.Second
+)
+
+var (
+	errReadOnly      = errors.New("replica is read-only, write to the primary instead")
+	errReplicaBehind = errors.New("replica fell too far behind, resync required")
+)
+
+func toReplicationEvent(ev stache.Event) *stachev1.ReplicationEvent {
+	out := &stachev1.ReplicationEvent{Sequence: &ev.Seq, Time: timestamppb.New(ev.Time)}
+
+	switch ev.Op {
+	case stache.OpSet:
+		out.Event = &stachev1.ReplicationEvent_Set{Set: toRecord(ev.Item)}
+	case stache.OpDelete:
+		out.Event = &stachev1.ReplicationEvent_Delete{Delete: ev.Item.Key}
+	case stache.OpExpire:
+		exp := &stachev1.ReplicationExpire{Key: &ev.Item.Key}
+		if !ev.Item.Meta.ExpiresAt.IsZero() {
+			exp.ExpiresAt = timestamppb.New(ev.Item.Meta.ExpiresAt)
+		}
+		if ev.Item.Meta.Sliding {
+			exp.Sliding = durationpb.New(ev.Item.Meta.TTL)
+		}
+		out.Event = &stachev1.ReplicationEvent_Expire{Expire: exp}
+	case stache.OpClear:
+		out.Event = &stachev1.ReplicationEvent_Clear{Clear: true}
+	}
+
+	return out
+}
+
+// fromReplicationEvent is the inverse of toReplicationEvent for the
+// events that change the cache; ok is false for the others.
+func fromReplicationEvent(msg *stachev1.ReplicationEvent) (ev stache.Event, ok bool, err error) {
+	switch e := msg.GetEvent().(type) {
+	case *stachev1.ReplicationEvent_Set:
+		it, err := fromRecord(e.Set)
+		return stache.Event{Op: stache.OpSet, Item: it}, true, err
+
+	case *stachev1.ReplicationEvent_Delete:
+		return stache.Event{Op: stache.OpDelete, Item: stache.Item{Key: e.Delete}}, true, nil
+
+	case *stachev1.ReplicationEvent_Expire:
+		it := stache.Item{Key: e.Expire.GetKey()}
+		if exp := e.Expire.GetExpiresAt(); exp != nil {
+			it.Meta.ExpiresAt = exp.AsTime()
+		}
+		if sliding := e.Expire.GetSliding(); sliding != nil {
+			it.Meta.TTL = sliding.AsDuration()
+			it.Meta.Sliding = true
+		}
+		return stache.Event{Op: stache.OpExpire, Item: it}, true, nil
+
+	case *stachev1.ReplicationEvent_Clear:
+		return stache.Event{Op: stache.OpClear}, true, nil
+
+	default:
+		return stache.Event{}, false, nil
+	}
+}
+
+// Replicate streams a snapshot of the byte-valued entries followed by every
+// later change. It subscribes before taking the snapshot, so changes made
+// while the snapshot is sent are replayed after it; each event carries the
+// whole state of its key, so replaying one the snapshot already holds is
+// harmless.
+func (s *cacheServer) Replicate(ctx context.Context, req *connect.Request[stachev1.ReplicateRequest], stream *connect.ServerStream[stachev1.ReplicationEvent]) error {
+	events := make(chan stache.Event, replicationBuffer)
+	behind := make(chan struct{})
+	var once sync.Once
+
+	cancel := s.cache.Subscribe(func(ev stache.Event) {
+		select {
+		case events <- ev:
+		default:
+			once.Do(func() { close(behind) })
+		}
+	})
+	defer cancel()
+
+	s.replicas.Add(1)
+	defer s.replicas.Add(-1)
+
+	items := s.cache.Items("")
+	for _, it := range items {
+		if err := stream.Send(&stachev1.ReplicationEvent{Event: &stachev1.ReplicationEvent_Set{Set: toRecord(it)}}); err != nil {
+			return err
+		}
+	}
+
+	entries := uint64(len(items))
+	end := &stachev1.ReplicationEvent{
+		Time:  timestamppb.Now(),
+		Event: &stachev1.ReplicationEvent_SnapshotEnd{SnapshotEnd: &stachev1.ReplicationSnapshotEnd{Entries: &entries}},
+	}
+	if err := stream.Send(end); err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(replicationHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-behind:
+			return connect.NewError(connect.CodeResourceExhausted, errReplicaBehind)
+
+		case ev := <-events:
+			if err := stream.Send(toReplicationEvent(ev)); err != nil {
+				return err
+			}
+
+		case <-heartbeat.C:
+			if len(events) > 0 {
+				continue
+			}
+			hb := &stachev1.ReplicationEvent{
+				Time:  timestamppb.Now(),
+				Event: &stachev1.ReplicationEvent_Heartbeat{Heartbeat: &stachev1.ReplicationHeartbeat{}},
+			}
+			if err := stream.Send(hb); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *cacheServer) ReplicationStatus(ctx context.Context, req *connect.Request[stachev1.ReplicationStatusRequest]) (*connect.Response[stachev1.ReplicationStatusResponse], error) {
+	replicas := uint32(s.replicas.Load())
+	if s.replica == nil {
+		role := "primary"
+		return connect.NewResponse(&stachev1.ReplicationStatusResponse{Role: &role, Replicas: &replicas}), nil
+	}
+
+	r := s.replica
+	role, connected, synced, applied := "replica", r.connected.Load(), r.synced.Load(), r.applied.Load()
+	res := &stachev1.ReplicationStatusResponse{
+		Role:            &role,
+		Primary:         &r.primary,
+		Connected:       &connected,
+		Synced:          &synced,
+		AppliedSequence: &applied,
+		Lag:             durationpb.New(time.Duration(r.lag.Load())),
+		Replicas:        &replicas,
+	}
+	return connect.NewResponse(res), nil
+}
+
+// replica keeps a cache in step with a primary: it syncs a snapshot, tails
+// the change stream and reconnects, resyncing from scratch, whenever the
+// stream breaks.
+type replica struct {
+	primary string
+	token   string
+	cache   *stache.Cache
+	client  stachev1connect.CacheServiceClient
+	logger  *slog.Logger
+
+	connected atomic.Bool
+	synced    atomic.Bool
+	applied   atomic.Uint64
+
+	// lag is how long before it was applied the primary made the latest
+	// change, or sent the latest heartbeat, in nanoseconds. It assumes the
+	// two clocks agree.
+	lag atomic.Int64
+}
+
+func newReplica(primary, token string, c *stache.Cache, logger *slog.Logger) *replica {
+	return &replica{
+		primary: primary,
+		token:   token,
+		cache:   c,
+		client:  stachev1connect.NewCacheServiceClient(&http.Client{}, primary),
+		logger:  logger,
+	}
+}
+
+// run replicates until ctx is done.
+func (r *replica) run(ctx context.Context) {
+	backoff := replicaMinBackoff
+	for ctx.Err() == nil {
+		err := r.sync(ctx)
+		wasSynced := r.synced.Swap(false)
+		r.connected.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Only back off further while the primary stays unreachable.
+		if wasSynced {
+			backoff = replicaMinBackoff
+		}
+		r.logger.Warn("replication stream lost", "primary", r.primary, "err", err, "retry_in", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, replicaMaxBackoff)
+	}
+}
+
+// sync runs one replication stream from the snapshot onwards.
+func (r *replica) sync(ctx context.Context) error {
+	req := connect.NewRequest(&stachev1.ReplicateRequest{})
+	if r.token != "" {
+		req.Header().Set("Authorization", "Bearer "+r.token)
+	}
+
+	stream, err := r.client.Replicate(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	r.connected.Store(true)
+	r.applied.Store(0)
+	snapshot := map[string]struct{}{}
+
+	for stream.Receive() {
+		msg := stream.Msg()
+		if t := msg.GetTime(); t != nil {
+			r.lag.Store(int64(max(time.Since(t.AsTime()), 0)))
+		}
+
+		if end := msg.GetSnapshotEnd(); end != nil {
+			// Keys left over from an earlier sync that the primary no
+			// longer has.
+			var stale []string
+			for _, e := range r.cache.Entries() {
+				if _, ok := snapshot[e.Key]; !ok {
+					stale = append(stale, e.Key)
+				}
+			}
+			r.cache.DeleteMany(stale...)
+
+			snapshot = nil
+			r.synced.Store(true)
+			r.logger.Info("replica synced", "primary", r.primary, "entries", end.GetEntries(), "stale", len(stale))
+			continue
+		}
+
+		ev, ok, err := fromReplicationEvent(msg)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		if snapshot != nil {
+			snapshot[ev.Item.Key] = struct{}{}
+		}
+		if err := r.cache.Apply(ev); err != nil {
+			return err
+		}
+		if seq := msg.GetSequence(); seq > 0 {
+			r.applied.Store(seq)
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		return err
+	}
+	return errors.New("primary closed the stream")
+}
+
+// writeProcedures are the RPCs a replica refuses. SUnion, SInter and SDiff
+// write only when given a destination.
+var writeProcedures = map[string]bool{
+	stachev1connect.CacheServiceSetProcedure:            true,
+	stachev1connect.CacheServiceDeleteProcedure:         true,
+	stachev1connect.CacheServiceTouchProcedure:          true,
+	stachev1connect.CacheServiceInvalidateTagsProcedure: true,
+	stachev1connect.CacheServiceHSetProcedure:           true,
+	stachev1connect.CacheServiceHDelProcedure:           true,
+	stachev1connect.CacheServiceHIncrByProcedure:        true,
+	stachev1connect.CacheServiceLPushProcedure:          true,
+	stachev1connect.CacheServiceRPushProcedure:          true,
+	stachev1connect.CacheServiceLPopProcedure:           true,
+	stachev1connect.CacheServiceRPopProcedure:           true,
+	stachev1connect.CacheServiceBPopProcedure:           true,
+	stachev1connect.CacheServiceZAddProcedure:           true,
+	stachev1connect.CacheServiceZRemProcedure:           true,
+	stachev1connect.CacheServiceSAddProcedure:           true,
+	stachev1connect.CacheServiceSRemProcedure:           true,
+	stachev1connect.CacheServiceTransactionProcedure:    true,
+	stachev1connect.CacheServiceBatchSetProcedure:       true,
+	stachev1connect.CacheServiceBatchDeleteProcedure:    true,
+	stachev1connect.CacheServiceImportProcedure:         true,
+}
+
+// readOnlyInterceptor rejects writes, except those in allow, with err
+// (errReadOnly if nil).
+type readOnlyInterceptor struct {
+	allow map[string]bool
+	err   error
+}
+
+func (ro readOnlyInterceptor) check(procedure string, write bool) error {
+	if !write || ro.allow[procedure] {
+		return nil
+	}
+	if ro.err != nil {
+		return connect.NewError(connect.CodeFailedPrecondition, ro.err)
+	}
+	return connect.NewError(connect.CodeFailedPrecondition, errReadOnly)
+}
+
+func (ro readOnlyInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		write := writeProcedures[procedure]
+		if r, ok := req.Any().(*stachev1.SetAlgebraRequest); ok && r.GetDestination() != "" {
+			write = true
+		}
+		if err := ro.check(procedure, write); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (ro readOnlyInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (ro readOnlyInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		procedure := conn.Spec().Procedure
+		if err := ro.check(procedure, writeProcedures[procedure]); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
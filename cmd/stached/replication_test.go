@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func startNode(t *testing.T, service *cacheServer, opts ...connect.HandlerOption) stachev1connect.CacheServiceClient {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(stachev1connect.NewCacheServiceHandler(service, opts...))
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return stachev1connect.NewCacheServiceClient(ts.Client(), ts.URL)
+}
+
+// eventually polls cond until it holds or a few seconds pass.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReplication(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	primaryCache := stache.NewCache()
+	primary := &cacheServer{cache: primaryCache}
+	mux := http.NewServeMux()
+	mux.Handle(stachev1connect.NewCacheServiceHandler(primary))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	primaryClient := stachev1connect.NewCacheServiceClient(ts.Client(), ts.URL)
+
+	// Present before the replica connects, so it arrives in the snapshot
+	_ = primaryCache.SetString("old", "1", 0)
+	_ = primaryCache.Set("ttl", []byte("x"), stache.Meta{TTL: time.Hour})
+	_, _ = primaryCache.HSet("hash", "f", []byte("v"))
+
+	replicaCache := stache.NewCache()
+	_ = replicaCache.SetString("stale", "gone after sync", 0)
+
+	r := newReplica(ts.URL, "", replicaCache, logger)
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	go r.run(runCtx)
+
+	replicaClient := startNode(t, &cacheServer{cache: replicaCache, replica: r},
+		connect.WithInterceptors(readOnlyInterceptor{}))
+
+	eventually(t, "initial sync", r.synced.Load)
+	if _, err := replicaCache.GetBytes("stale"); err == nil {
+		t.Fatal("key absent from the primary survived the sync")
+	}
+	want, _ := primaryCache.GetEntry("ttl")
+	got, err := replicaCache.GetEntry("ttl")
+	if err != nil || !got.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Fatalf("snapshot expiry mismatch: got=%v want=%v err=%v", got.ExpiresAt, want.ExpiresAt, err)
+	}
+	if _, err := replicaCache.GetBytes("hash"); err == nil {
+		t.Fatal("structured types should not be replicated")
+	}
+
+	// Changes after the sync are streamed
+	key := "new"
+	_, err = primaryClient.Set(ctx, connect.NewRequest(&stachev1.SetRequest{Key: &key, Value: []byte("2")}))
+	if err != nil {
+		t.Fatalf("Set on primary: %v", err)
+	}
+	primaryCache.Delete("old")
+	_ = primaryCache.Persist("ttl")
+
+	eventually(t, "streamed changes", func() bool {
+		v, err := replicaCache.GetString("new")
+		_, oldErr := replicaCache.GetBytes("old")
+		info, _ := replicaCache.GetEntry("ttl")
+		return err == nil && v == "2" && oldErr != nil && info.ExpiresAt.IsZero()
+	})
+
+	// Reads are served, writes refused
+	res, err := replicaClient.Get(ctx, connect.NewRequest(&stachev1.GetRequest{Key: &key}))
+	if err != nil || string(res.Msg.GetValue()) != "2" {
+		t.Fatalf("Get on replica: value=%q err=%v", res.Msg.GetValue(), err)
+	}
+	_, err = replicaClient.Set(ctx, connect.NewRequest(&stachev1.SetRequest{Key: &key, Value: []byte("3")}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("Set on replica: got=%v want FailedPrecondition", err)
+	}
+	dst := "dst"
+	_, err = replicaClient.SUnion(ctx, connect.NewRequest(&stachev1.SetAlgebraRequest{Keys: []string{"a"}, Destination: &dst}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("SUnion with destination on replica: got=%v want FailedPrecondition", err)
+	}
+
+	status, err := replicaClient.ReplicationStatus(ctx, connect.NewRequest(&stachev1.ReplicationStatusRequest{}))
+	if err != nil {
+		t.Fatalf("ReplicationStatus on replica: %v", err)
+	}
+	if s := status.Msg; s.GetRole() != "replica" || !s.GetConnected() || !s.GetSynced() || s.GetAppliedSequence() == 0 {
+		t.Fatalf("replica status mismatch: %v", s)
+	}
+
+	status, err = primaryClient.ReplicationStatus(ctx, connect.NewRequest(&stachev1.ReplicationStatusRequest{}))
+	if err != nil || status.Msg.GetRole() != "primary" || status.Msg.GetReplicas() != 1 {
+		t.Fatalf("primary status mismatch: %v err=%v", status.Msg, err)
+	}
+
+	// A lost stream is re-established with a fresh sync
+	ts.CloseClientConnections()
+	eventually(t, "resync", func() bool { return r.synced.Load() && primary.replicas.Load() == 1 })
+	_ = primaryCache.SetString("after", "3", 0)
+	eventually(t, "changes after resync", func() bool {
+		v, err := replicaCache.GetString("after")
+		return err == nil && v == "3"
+	})
+}
@@ -0,0 +1,794 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+const (
+	// respMaxBulk and respMaxArgs bound what a client may send, matching
+	// Redis' own proto-max-bulk-len default.
+	respMaxBulk = 512 << 20
+	respMaxArgs = 1 << 20
+
+	// respMaxBulkUnauthed and respMaxArgsUnauthed are the tighter bounds
+	// Redis applies until a connection has authenticated.
+	respMaxBulkUnauthed = 16 << 10
+	respMaxArgsUnauthed = 10
+
+	// respChunk is how much of a bulk string is allocated ahead of the
+	// bytes actually received.
+	respChunk = 64 << 10
+)
+
+// respServer speaks the Redis serialization protocol (RESP2, or RESP3 after
+// HELLO 3) on top of a Cache, so redis-cli and Redis client libraries can
+// use stached. Only the string commands listed in respCommands are served.
+type respServer struct {
+	*tcpServer
+	cache  *stache.Cache
+	logger *slog.Logger
+
+	// token, if set, must be given with AUTH or HELLO before other commands.
+	token string
+
+	// readOnly rejects respWriteCommands, on a replica.
+	readOnly bool
+
+	// cluster, if set, has commands on keys owned by another node refused.
+	cluster *clusterState
+}
+
+func newRESPServer(c *stache.Cache, logger *slog.Logger) *respServer {
+	s := &respServer{cache: c, logger: logger}
+	s.tcpServer = newTCPServer(s.serveConn)
+	return s
+}
+
+type respConn struct {
+	r     *bufio.Reader
+	w     *bufio.Writer
+	proto int
+	quit  bool
+
+	// authed is set once the client has given the token, or from the
+	// start if the server has none.
+	authed bool
+
+	// scanKeys is the sorted snapshot of the keyspace that SCAN cursors
+	// index into, taken when a scan starts at cursor 0.
+	scanKeys []string
+}
+
+func (s *respServer) serveConn(conn net.Conn) {
+	rc := &respConn{r: bufio.NewReader(conn), w: bufio.NewWriter(conn), proto: 2, authed: s.token == ""}
+	for !rc.quit {
+		args, err := rc.readCommand()
+		if err != nil {
+			var perr respProtocolError
+			if errors.As(err, &perr) {
+				rc.error("ERR Protocol error: " + string(perr))
+				rc.w.Flush()
+				s.logger.Warn("resp protocol error", "remote", conn.RemoteAddr().String(), "err", err)
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		s.dispatch(rc, args)
+
+		// Flush once the client has nothing more pipelined.
+		if rc.r.Buffered() == 0 {
+			if err := rc.w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+	rc.w.Flush()
+}
+
+type respProtocolError string
+
+func (e respProtocolError) Error() string { return string(e) }
+
+// readCommand reads one request: either a RESP array of bulk strings or an
+// inline command as typed into telnet.
+func (rc *respConn) readCommand() ([][]byte, error) {
+	line, err := rc.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 || line[0] != '*' {
+		fields := strings.Fields(string(line))
+		args := make([][]byte, len(fields))
+		for i, f := range fields {
+			args[i] = []byte(f)
+		}
+		return args, nil
+	}
+
+	maxArgs, maxBulk := respMaxArgs, respMaxBulk
+	if !rc.authed {
+		maxArgs, maxBulk = respMaxArgsUnauthed, respMaxBulkUnauthed
+	}
+
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n > maxArgs {
+		return nil, respProtocolError("invalid multibulk length")
+	}
+
+	args := make([][]byte, 0, min(max(n, 0), 1024))
+	for range n {
+		line, err := rc.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, respProtocolError(fmt.Sprintf("expected '$', got '%.1s'", line))
+		}
+
+		size, err := strconv.Atoi(string(line[1:]))
+		if err != nil || size < 0 || size > maxBulk {
+			return nil, respProtocolError("invalid bulk length")
+		}
+
+		buf, err := rc.readBulk(size + 2)
+		if err != nil {
+			return nil, err
+		}
+		if buf[size] != '\r' || buf[size+1] != '\n' {
+			return nil, respProtocolError("bulk string not terminated by CRLF")
+		}
+		args = append(args, buf[:size])
+	}
+
+	return args, nil
+}
+
+// readBulk reads the next n bytes, growing the buffer as they arrive
+// rather than trusting the announced length up front.
+func (rc *respConn) readBulk(n int) ([]byte, error) {
+	buf := make([]byte, 0, min(n, respChunk))
+	for len(buf) < n {
+		chunk := min(n-len(buf), respChunk)
+		buf = slices.Grow(buf, chunk)
+		if _, err := io.ReadFull(rc.r, buf[len(buf):len(buf)+chunk]); err != nil {
+			return nil, err
+		}
+		buf = buf[:len(buf)+chunk]
+	}
+	return buf, nil
+}
+
+func (rc *respConn) readLine() ([]byte, error) {
+	line, err := rc.r.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return nil, respProtocolError("too big inline request")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line = line[:len(line)-1]
+	if n := len(line); n > 0 && line[n-1] == '\r' {
+		line = line[:n-1]
+	}
+	return line, nil
+}
+
+func (rc *respConn) simple(s string) {
+	rc.w.WriteString("+" + s + "\r\n")
+}
+
+func (rc *respConn) error(s string) {
+	rc.w.WriteString("-" + s + "\r\n")
+}
+
+func (rc *respConn) integer(n int64) {
+	rc.w.WriteString(":" + strconv.FormatInt(n, 10) + "\r\n")
+}
+
+func (rc *respConn) bulk(b []byte) {
+	rc.w.WriteString("$" + strconv.Itoa(len(b)) + "\r\n")
+	rc.w.Write(b)
+	rc.w.WriteString("\r\n")
+}
+
+func (rc *respConn) null() {
+	if rc.proto >= 3 {
+		rc.w.WriteString("_\r\n")
+	} else {
+		rc.w.WriteString("$-1\r\n")
+	}
+}
+
+func (rc *respConn) array(n int) {
+	rc.w.WriteString("*" + strconv.Itoa(n) + "\r\n")
+}
+
+// mapHeader starts a map of n pairs, sent as a flat array under RESP2.
+func (rc *respConn) mapHeader(n int) {
+	if rc.proto >= 3 {
+		rc.w.WriteString("%" + strconv.Itoa(n) + "\r\n")
+	} else {
+		rc.array(2 * n)
+	}
+}
+
+// cacheError writes err as the Redis error reply clients expect.
+func (rc *respConn) cacheError(err error) {
+	switch {
+	case errors.Is(err, stache.ErrIncorrectType):
+		rc.error("WRONGTYPE Operation against a key holding the wrong kind of value")
+	case errors.Is(err, stache.ErrNotInteger):
+		rc.error("ERR value is not an integer or out of range")
+	case errors.Is(err, stache.ErrOverflow):
+		rc.error("ERR increment or decrement would overflow")
+	case errors.Is(err, stache.ErrNotFloat):
+		rc.error("ERR value is not a valid float")
+	default:
+		rc.error("ERR " + err.Error())
+	}
+}
+
+type respCommand struct {
+	// arity is the exact number of arguments including the command name,
+	// or -n for at least n.
+	arity int
+	run   func(s *respServer, rc *respConn, args [][]byte)
+}
+
+var respCommands map[string]respCommand
+
+// respWriteCommands are the commands that change the cache.
+var respWriteCommands = map[string]bool{
+	"SET": true, "DEL": true, "EXPIRE": true, "PEXPIRE": true,
+	"INCR": true, "DECR": true, "INCRBY": true, "DECRBY": true,
+}
+
+// respMultiKeyCommands are the commands whose arguments are all keys, and
+// respKeyCommands those whose first argument is.
+var respMultiKeyCommands = map[string]bool{"MGET": true, "DEL": true, "EXISTS": true}
+
+var respKeyCommands = map[string]bool{
+	"GET": true, "SET": true, "EXPIRE": true, "PEXPIRE": true, "TTL": true, "PTTL": true,
+	"INCR": true, "DECR": true, "INCRBY": true, "DECRBY": true,
+}
+
+// respKeys returns the keys the command args addresses.
+func respKeys(name string, args [][]byte) []string {
+	var keys [][]byte
+	switch {
+	case respMultiKeyCommands[name]:
+		keys = args[1:]
+	case respKeyCommands[name]:
+		keys = args[1:2]
+	}
+
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = string(k)
+	}
+	return out
+}
+
+func init() {
+	respCommands = map[string]respCommand{
+		"PING":    {-1, (*respServer).ping},
+		"ECHO":    {2, func(_ *respServer, rc *respConn, args [][]byte) { rc.bulk(args[1]) }},
+		"HELLO":   {-1, (*respServer).hello},
+		"AUTH":    {-2, (*respServer).auth},
+		"QUIT":    {-1, func(_ *respServer, rc *respConn, _ [][]byte) { rc.simple("OK"); rc.quit = true }},
+		"SELECT":  {2, (*respServer).selectDB},
+		"CLIENT":  {-2, func(_ *respServer, rc *respConn, _ [][]byte) { rc.simple("OK") }},
+		"COMMAND": {-1, func(_ *respServer, rc *respConn, _ [][]byte) { rc.array(0) }},
+		"GET":     {2, (*respServer).get},
+		"MGET":    {-2, (*respServer).mget},
+		"SET":     {-3, (*respServer).set},
+		"DEL":     {-2, (*respServer).del},
+		"EXISTS":  {-2, (*respServer).exists},
+		"EXPIRE":  {3, (*respServer).expire},
+		"PEXPIRE": {3, (*respServer).expire},
+		"TTL":     {2, (*respServer).ttl},
+		"PTTL":    {2, (*respServer).ttl},
+		"INCR":    {2, (*respServer).incr},
+		"DECR":    {2, (*respServer).incr},
+		"INCRBY":  {3, (*respServer).incr},
+		"DECRBY":  {3, (*respServer).incr},
+		"KEYS":    {2, (*respServer).keys},
+		"SCAN":    {-2, (*respServer).scan},
+		"DBSIZE":  {1, func(s *respServer, rc *respConn, _ [][]byte) { rc.integer(int64(s.cache.Len())) }},
+	}
+}
+
+func (s *respServer) dispatch(rc *respConn, args [][]byte) {
+	name := strings.ToUpper(string(args[0]))
+	cmd, ok := respCommands[name]
+	if !ok {
+		rc.error(fmt.Sprintf("ERR unknown command '%s'", args[0]))
+		return
+	}
+
+	if (cmd.arity > 0 && len(args) != cmd.arity) || (cmd.arity < 0 && len(args) < -cmd.arity) {
+		rc.error(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name)))
+		return
+	}
+
+	if s.token != "" && !rc.authed && name != "AUTH" && name != "HELLO" && name != "QUIT" {
+		rc.error("NOAUTH Authentication required.")
+		return
+	}
+
+	if s.readOnly && respWriteCommands[name] {
+		rc.error("READONLY You can't write against a read only replica.")
+		return
+	}
+
+	if s.cluster != nil {
+		if err := s.cluster.misdirected(respKeys(name, args)...); err != nil {
+			rc.error("ERR " + err.Error())
+			return
+		}
+	}
+
+	cmd.run(s, rc, args)
+}
+
+func (s *respServer) ping(rc *respConn, args [][]byte) {
+	switch len(args) {
+	case 1:
+		rc.simple("PONG")
+	case 2:
+		rc.bulk(args[1])
+	default:
+		rc.error("ERR wrong number of arguments for 'ping' command")
+	}
+}
+
+// auth implements AUTH [username] password. The username is ignored and
+// the password must be the server's token.
+func (s *respServer) auth(rc *respConn, args [][]byte) {
+	if len(args) > 3 {
+		rc.error("ERR syntax error")
+		return
+	}
+
+	if s.token == "" {
+		rc.error("ERR AUTH called without any password configured for the default user. Are you sure your configuration is correct?")
+		return
+	}
+
+	if !tokenValid(s.token, string(args[len(args)-1])) {
+		rc.error("WRONGPASS invalid username-password pair or user is disabled.")
+		return
+	}
+
+	rc.authed = true
+	rc.simple("OK")
+}
+
+// hello switches protocol version and replies with a description of the
+// server. HELLO ... AUTH username password authenticates like AUTH; SETNAME
+// is accepted and ignored.
+func (s *respServer) hello(rc *respConn, args [][]byte) {
+	proto := rc.proto
+	if len(args) > 1 {
+		v, err := strconv.Atoi(string(args[1]))
+		if err != nil || v < 2 || v > 3 {
+			rc.error("NOPROTO unsupported protocol version")
+			return
+		}
+		proto = v
+	}
+
+	authed := rc.authed
+	for i := 2; i < len(args); i++ {
+		switch strings.ToUpper(string(args[i])) {
+		case "AUTH":
+			if i+2 >= len(args) {
+				rc.error("ERR syntax error")
+				return
+			}
+			if s.token == "" || !tokenValid(s.token, string(args[i+2])) {
+				rc.error("WRONGPASS invalid username-password pair or user is disabled.")
+				return
+			}
+			authed = true
+			i += 2
+		case "SETNAME":
+			if i+1 >= len(args) {
+				rc.error("ERR syntax error")
+				return
+			}
+			i++
+		default:
+			rc.error("ERR syntax error")
+			return
+		}
+	}
+
+	if s.token != "" && !authed {
+		rc.error("NOAUTH HELLO must be called with the client already authenticated, otherwise the HELLO <proto> AUTH <user> <pass> option can be used to authenticate the client and select the RESP protocol version at the same time")
+		return
+	}
+	rc.proto, rc.authed = proto, authed
+
+	rc.mapHeader(6)
+	rc.bulk([]byte("server"))
+	rc.bulk([]byte("stache"))
+	rc.bulk([]byte("version"))
+	rc.bulk([]byte("1.0.0"))
+	rc.bulk([]byte("proto"))
+	rc.integer(int64(rc.proto))
+	rc.bulk([]byte("mode"))
+	rc.bulk([]byte("standalone"))
+	rc.bulk([]byte("role"))
+	rc.bulk([]byte("master"))
+	rc.bulk([]byte("modules"))
+	rc.array(0)
+}
+
+// selectDB accepts only database 0, the only one stache has.
+func (s *respServer) selectDB(rc *respConn, args [][]byte) {
+	if string(args[1]) != "0" {
+		rc.error("ERR DB index is out of range")
+		return
+	}
+	rc.simple("OK")
+}
+
+func (s *respServer) get(rc *respConn, args [][]byte) {
+	v, err := s.cache.GetBytes(string(args[1]))
+	switch {
+	case errors.Is(err, stache.ErrNotFound):
+		rc.null()
+	case err != nil:
+		rc.cacheError(err)
+	default:
+		rc.bulk(v)
+	}
+}
+
+// mget replies with nil for missing keys and, as Redis does, for keys
+// holding other types.
+func (s *respServer) mget(rc *respConn, args [][]byte) {
+	rc.array(len(args) - 1)
+	for _, k := range args[1:] {
+		if v, err := s.cache.GetBytes(string(k)); err == nil {
+			rc.bulk(v)
+		} else {
+			rc.null()
+		}
+	}
+}
+
+// set implements SET key value [EX seconds | PX milliseconds] [NX | XX].
+func (s *respServer) set(rc *respConn, args [][]byte) {
+	key, value := string(args[1]), args[2]
+	meta := stache.Meta{ContentType: stache.Text}
+	var nx, xx, hasTTL bool
+
+	for i := 3; i < len(args); i++ {
+		switch opt := strings.ToUpper(string(args[i])); opt {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "EX", "PX":
+			if hasTTL || i+1 == len(args) {
+				rc.error("ERR syntax error")
+				return
+			}
+			i++
+			n, err := strconv.ParseInt(string(args[i]), 10, 64)
+			if err != nil {
+				rc.error("ERR value is not an integer or out of range")
+				return
+			}
+			unit := time.Second
+			if opt == "PX" {
+				unit = time.Millisecond
+			}
+			if n <= 0 || n > math.MaxInt64/int64(unit) {
+				rc.error("ERR invalid expire time in 'set' command")
+				return
+			}
+			meta.TTL = time.Duration(n) * unit
+			hasTTL = true
+		default:
+			rc.error("ERR syntax error")
+			return
+		}
+	}
+
+	if nx && xx {
+		rc.error("ERR syntax error")
+		return
+	}
+
+	stored := true
+	var err error
+	switch {
+	case nx:
+		stored, err = s.cache.Add(key, value, meta)
+	case xx:
+		stored, err = s.cache.Replace(key, value, meta)
+	default:
+		err = s.cache.Set(key, value, meta)
+	}
+
+	switch {
+	case err != nil:
+		rc.cacheError(err)
+	case !stored:
+		rc.null()
+	default:
+		rc.simple("OK")
+	}
+}
+
+func (s *respServer) del(rc *respConn, args [][]byte) {
+	keys := make([]string, len(args)-1)
+	for i, k := range args[1:] {
+		keys[i] = string(k)
+	}
+
+	n := 0
+	for _, existed := range s.cache.DeleteMany(keys...) {
+		if existed {
+			n++
+		}
+	}
+	rc.integer(int64(n))
+}
+
+// exists counts keys that exist, counting repeated keys each time.
+func (s *respServer) exists(rc *respConn, args [][]byte) {
+	n := 0
+	for _, k := range args[1:] {
+		if _, err := s.cache.GetEntry(string(k)); err == nil {
+			n++
+		}
+	}
+	rc.integer(int64(n))
+}
+
+// expire handles EXPIRE and PEXPIRE. A non-positive timeout deletes the key.
+func (s *respServer) expire(rc *respConn, args [][]byte) {
+	n, err := strconv.ParseInt(string(args[2]), 10, 64)
+	if err != nil {
+		rc.error("ERR value is not an integer or out of range")
+		return
+	}
+
+	unit := time.Second
+	if strings.EqualFold(string(args[0]), "PEXPIRE") {
+		unit = time.Millisecond
+	}
+	if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+		rc.error(fmt.Sprintf("ERR invalid expire time in '%s' command", strings.ToLower(string(args[0]))))
+		return
+	}
+
+	if err := s.cache.ExpireAt(string(args[1]), time.Now().Add(time.Duration(n)*unit)); err != nil {
+		rc.integer(0)
+		return
+	}
+	rc.integer(1)
+}
+
+// ttl handles TTL and PTTL: -2 if the key is missing, -1 if it never expires.
+func (s *respServer) ttl(rc *respConn, args [][]byte) {
+	d, err := s.cache.TTL(string(args[1]))
+	switch {
+	case err != nil:
+		rc.integer(-2)
+	case d == 0:
+		rc.integer(-1)
+	case strings.EqualFold(string(args[0]), "PTTL"):
+		rc.integer(d.Milliseconds())
+	default:
+		rc.integer(int64(d.Round(time.Second) / time.Second))
+	}
+}
+
+// incr handles INCR, DECR, INCRBY and DECRBY.
+func (s *respServer) incr(rc *respConn, args [][]byte) {
+	name := strings.ToUpper(string(args[0]))
+
+	delta := int64(1)
+	if len(args) == 3 {
+		var err error
+		delta, err = strconv.ParseInt(string(args[2]), 10, 64)
+		if err != nil {
+			rc.error("ERR value is not an integer or out of range")
+			return
+		}
+	}
+	if strings.HasPrefix(name, "DECR") {
+		delta = -delta
+	}
+
+	n, err := s.cache.Incr(string(args[1]), delta)
+	if err != nil {
+		rc.cacheError(err)
+		return
+	}
+	rc.integer(n)
+}
+
+// sortedKeys returns the keys matching the glob pattern, in order.
+func (s *respServer) sortedKeys(pattern string) []string {
+	keys := []string{}
+	for _, e := range s.cache.Entries() {
+		if globMatch(pattern, e.Key) {
+			keys = append(keys, e.Key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (s *respServer) keys(rc *respConn, args [][]byte) {
+	keys := s.sortedKeys(string(args[1]))
+	rc.array(len(keys))
+	for _, k := range keys {
+		rc.bulk([]byte(k))
+	}
+}
+
+// scan implements SCAN cursor [MATCH pattern] [COUNT count]. The cursor is
+// a position in a sorted snapshot of the keyspace, which the connection
+// keeps from cursor 0 until the scan ends. Each call looks at count keys
+// and returns those matching pattern, so it may return fewer or none, as
+// Redis does. Keys added during a scan are not returned, and keys removed
+// may still be.
+func (s *respServer) scan(rc *respConn, args [][]byte) {
+	cursor, err := strconv.Atoi(string(args[1]))
+	if err != nil || cursor < 0 {
+		rc.error("ERR invalid cursor")
+		return
+	}
+
+	pattern, count := "*", 10
+	for i := 2; i < len(args); i++ {
+		if i+1 == len(args) {
+			rc.error("ERR syntax error")
+			return
+		}
+
+		switch strings.ToUpper(string(args[i])) {
+		case "MATCH":
+			pattern = string(args[i+1])
+		case "COUNT":
+			count, err = strconv.Atoi(string(args[i+1]))
+			if err != nil || count < 1 {
+				rc.error("ERR syntax error")
+				return
+			}
+		default:
+			rc.error("ERR syntax error")
+			return
+		}
+		i++
+	}
+
+	if cursor == 0 || rc.scanKeys == nil {
+		rc.scanKeys = s.sortedKeys("*")
+	}
+	keys := rc.scanKeys
+	cursor = min(cursor, len(keys))
+	end := min(cursor+count, len(keys))
+	next := end
+	if end == len(keys) {
+		next = 0
+		rc.scanKeys = nil
+	}
+
+	page := []string{}
+	for _, k := range keys[cursor:end] {
+		if globMatch(pattern, k) {
+			page = append(page, k)
+		}
+	}
+
+	rc.array(2)
+	rc.bulk([]byte(strconv.Itoa(next)))
+	rc.array(len(page))
+	for _, k := range page {
+		rc.bulk([]byte(k))
+	}
+}
+
+// globMatch reports whether s matches the Redis-style glob pattern, which
+// supports *, ?, [abc], [^abc], [a-z] and backslash escapes. On a mismatch
+// it backtracks to the last * only: an earlier * could not match any more
+// than the last one can, so the match takes O(len(pattern)*len(s)) time.
+func globMatch(pattern, s string) bool {
+	p, i := 0, 0
+	star, mark := -1, 0
+	for i < len(s) {
+		if p < len(pattern) && pattern[p] == '*' {
+			star, mark = p, i
+			p++
+			continue
+		}
+		if p < len(pattern) {
+			if n, ok := globToken(pattern[p:], s[i]); ok {
+				p += n
+				i++
+				continue
+			}
+		}
+		if star < 0 {
+			return false
+		}
+
+		// Let the last * take one more byte
+		mark++
+		p, i = star+1, mark
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// globToken matches c against the token at the start of pattern, which is
+// anything but *, and returns the token's length.
+func globToken(pattern string, c byte) (int, bool) {
+	switch pattern[0] {
+	case '?':
+		return 1, true
+
+	case '[':
+		p := pattern[1:]
+		negate := len(p) > 0 && p[0] == '^'
+		if negate {
+			p = p[1:]
+		}
+
+		matched := false
+		for len(p) > 0 && p[0] != ']' {
+			switch {
+			case p[0] == '\\' && len(p) > 1:
+				matched = matched || p[1] == c
+				p = p[2:]
+			case len(p) > 2 && p[1] == '-' && p[2] != ']':
+				lo, hi := min(p[0], p[2]), max(p[0], p[2])
+				matched = matched || (lo <= c && c <= hi)
+				p = p[3:]
+			default:
+				matched = matched || p[0] == c
+				p = p[1:]
+			}
+		}
+		if len(p) == 0 {
+			// An unterminated class matches nothing.
+			return 0, false
+		}
+		return len(pattern) - len(p) + 1, matched != negate
+
+	case '\\':
+		if len(pattern) > 1 {
+			return 2, pattern[1] == c
+		}
+	}
+
+	return 1, pattern[0] == c
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// respClient is a bare-bones RESP client: it sends commands as arrays of
+// bulk strings and returns each reply in its raw wire form.
+type respClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func startRESP(t *testing.T) (*stache.Cache, *respClient) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	c := stache.NewCache()
+	s := newRESPServer(c, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	go s.Serve(ln)
+	t.Cleanup(func() { s.Close() })
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	return c, &respClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+}
+
+func (rc *respClient) do(args ...string) string {
+	rc.t.Helper()
+
+	var b strings.Builder
+	b.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, a := range args {
+		b.WriteString("$" + strconv.Itoa(len(a)) + "\r\n" + a + "\r\n")
+	}
+	if _, err := io.WriteString(rc.conn, b.String()); err != nil {
+		rc.t.Fatalf("write: %v", err)
+	}
+
+	return rc.reply()
+}
+
+// reply reads one complete reply, recursing into aggregates.
+func (rc *respClient) reply() string {
+	rc.t.Helper()
+
+	line, err := rc.r.ReadString('\n')
+	if err != nil {
+		rc.t.Fatalf("read: %v", err)
+	}
+
+	switch line[0] {
+	case '$':
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if n < 0 {
+			return line
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(rc.r, buf); err != nil {
+			rc.t.Fatalf("read: %v", err)
+		}
+		return line + string(buf)
+
+	case '*', '%':
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if line[0] == '%' {
+			n *= 2
+		}
+		for range n {
+			line += rc.reply()
+		}
+		return line
+
+	default:
+		return line
+	}
+}
+
+func TestRESPCommands(t *testing.T) {
+	c, rc := startRESP(t)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"PING"}, "+PONG\r\n"},
+		{[]string{"GET", "k"}, "$-1\r\n"},
+		{[]string{"SET", "k", "v"}, "+OK\r\n"},
+		{[]string{"GET", "k"}, "$1\r\nv\r\n"},
+		{[]string{"SET", "k", "w", "NX"}, "$-1\r\n"},
+		{[]string{"SET", "k", "w", "XX", "EX", "100"}, "+OK\r\n"},
+		{[]string{"TTL", "k"}, ":100\r\n"},
+		{[]string{"SET", "missing", "x", "XX"}, "$-1\r\n"},
+		{[]string{"SET", "p", "x", "PX", "0"}, "-ERR invalid expire time in 'set' command\r\n"},
+		{[]string{"SET", "n", "41"}, "+OK\r\n"},
+		{[]string{"INCR", "n"}, ":42\r\n"},
+		{[]string{"INCR", "k"}, "-ERR value is not an integer or out of range\r\n"},
+		{[]string{"MGET", "k", "nope", "n"}, "*3\r\n$1\r\nw\r\n$-1\r\n$2\r\n42\r\n"},
+		{[]string{"EXISTS", "k", "k", "nope"}, ":2\r\n"},
+		{[]string{"TTL", "n"}, ":-1\r\n"},
+		{[]string{"TTL", "nope"}, ":-2\r\n"},
+		{[]string{"EXPIRE", "n", "50"}, ":1\r\n"},
+		{[]string{"EXPIRE", "nope", "50"}, ":0\r\n"},
+		{[]string{"EXPIRE", "n", "9999999999999"}, "-ERR invalid expire time in 'expire' command\r\n"},
+		{[]string{"EXPIRE", "n", "-9999999999999"}, "-ERR invalid expire time in 'expire' command\r\n"},
+		{[]string{"SET", "n", "x", "EX", "9999999999999"}, "-ERR invalid expire time in 'set' command\r\n"},
+		{[]string{"TTL", "n"}, ":50\r\n"},
+		{[]string{"KEYS", "*"}, "*2\r\n$1\r\nk\r\n$1\r\nn\r\n"},
+		{[]string{"KEYS", "[a-m]"}, "*1\r\n$1\r\nk\r\n"},
+		{[]string{"SCAN", "0", "COUNT", "1"}, "*2\r\n$1\r\n1\r\n*1\r\n$1\r\nk\r\n"},
+		{[]string{"SCAN", "1", "COUNT", "1"}, "*2\r\n$1\r\n0\r\n*1\r\n$1\r\nn\r\n"},
+		{[]string{"DEL", "k", "n", "nope"}, ":2\r\n"},
+		{[]string{"FLUSHALL"}, "-ERR unknown command 'FLUSHALL'\r\n"},
+		{[]string{"GET"}, "-ERR wrong number of arguments for 'get' command\r\n"},
+	}
+
+	for _, tt := range tests {
+		if got := rc.do(tt.args...); got != tt.want {
+			t.Fatalf("%v mismatch: got=%q want=%q", tt.args, got, tt.want)
+		}
+	}
+
+	_, _ = c.HSet("h", "f", []byte("v"))
+	if got := rc.do("GET", "h"); !strings.HasPrefix(got, "-WRONGTYPE") {
+		t.Fatalf("GET on hash: got=%q", got)
+	}
+}
+
+func TestRESPScan(t *testing.T) {
+	c, rc := startRESP(t)
+	for i := range 25 {
+		_ = c.SetString(fmt.Sprintf("k%02d", i), "v", 0)
+	}
+
+	// A walk with MATCH finds every match once, a page at a time
+	var got []string
+	cursor, calls := "0", 0
+	for {
+		reply := rc.do("SCAN", cursor, "MATCH", "k?[05]", "COUNT", "10")
+		lines := strings.Split(strings.TrimSuffix(reply, "\r\n"), "\r\n")
+		cursor = lines[2]
+		for i := 5; i < len(lines); i += 2 {
+			got = append(got, lines[i])
+		}
+		if calls++; calls == 1 {
+			// Keys added mid-scan are not in the snapshot
+			_ = c.SetString("k99", "v", 0)
+			_ = c.SetString("k30", "v", 0)
+		}
+		if cursor == "0" {
+			break
+		}
+	}
+	want := []string{"k00", "k05", "k10", "k15", "k20"}
+	if !slices.Equal(got, want) || calls != 3 {
+		t.Fatalf("SCAN MATCH walk: got=%v in %d calls, want=%v in 3", got, calls, want)
+	}
+
+	// The next walk sees them
+	if reply := rc.do("SCAN", "0", "MATCH", "k[39]*", "COUNT", "100"); reply != "*2\r\n$1\r\n0\r\n*2\r\n$3\r\nk30\r\n$3\r\nk99\r\n" {
+		t.Fatalf("SCAN after the walk: got=%q", reply)
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"*", "", true},
+		{"user:*", "user:42", true},
+		{"user:*", "session:42", false},
+		{"h?llo", "hello", true},
+		{"h?llo", "hllo", false},
+		{"h[ae]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-b]llo", "hbllo", true},
+		{"*a*b", "xaxxb", true},
+		{"*a*b", "xaxxbx", false},
+		{"a\\*", "a*", true},
+		{"a\\*", "ab", false},
+		{"[abc", "a", false},
+		{"**x**", "yxy", true},
+	}
+	for _, tt := range tests {
+		if got := globMatch(tt.pattern, tt.s); got != tt.want {
+			t.Fatalf("globMatch(%q, %q): got=%v want=%v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+
+	// Each * used to try every split recursively
+	pattern, s := strings.Repeat("*a", 20)+"*b", strings.Repeat("a", 10000)
+	start := time.Now()
+	if globMatch(pattern, s) {
+		t.Fatalf("globMatch(%q, ...): expected no match", pattern)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("globMatch took %v", d)
+	}
+}
+
+func TestRESP3AndPipelining(t *testing.T) {
+	_, rc := startRESP(t)
+
+	if got := rc.do("HELLO", "3"); !strings.HasPrefix(got, "%6\r\n") {
+		t.Fatalf("HELLO 3 should reply with a map, got=%q", got)
+	}
+	if got := rc.do("GET", "missing"); got != "_\r\n" {
+		t.Fatalf("RESP3 null mismatch: got=%q", got)
+	}
+
+	// Several commands in one write, the last one inline
+	_, err := io.WriteString(rc.conn, "*3\r\n$3\r\nSET\r\n$1\r\na\r\n$1\r\n1\r\n*2\r\n$4\r\nINCR\r\n$1\r\na\r\nGET a\r\n")
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for _, want := range []string{"+OK\r\n", ":2\r\n", "$1\r\n2\r\n"} {
+		if got := rc.reply(); got != want {
+			t.Fatalf("pipelined reply mismatch: got=%q want=%q", got, want)
+		}
+	}
+}
+
+func TestRESPAuth(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	s := newRESPServer(stache.NewCache(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.token = "s3cret"
+	go s.Serve(ln)
+	defer s.Close()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	rc := &respClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+
+	if got := rc.do("GET", "k"); got != "-NOAUTH Authentication required.\r\n" {
+		t.Fatalf("GET before AUTH: got=%q", got)
+	}
+	if got := rc.do("AUTH", "wrong"); !strings.HasPrefix(got, "-WRONGPASS") {
+		t.Fatalf("AUTH with wrong token: got=%q", got)
+	}
+	if got := rc.do("HELLO", "3", "AUTH", "default", "s3cret"); !strings.HasPrefix(got, "%6\r\n") {
+		t.Fatalf("HELLO with AUTH: got=%q", got)
+	}
+	if got := rc.do("GET", "k"); got != "_\r\n" {
+		t.Fatalf("GET after AUTH: got=%q", got)
+	}
+}
+
+func TestRESPUnauthedLimits(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	s := newRESPServer(stache.NewCache(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.token = "s3cret"
+	go s.Serve(ln)
+	defer s.Close()
+
+	dial := func() *respClient {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return &respClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+	}
+
+	big := strings.Repeat("x", 100<<10)
+
+	// Before AUTH, only small requests are read at all
+	if got := dial().do(slices.Repeat([]string{"DEL"}, 11)...); got != "-ERR Protocol error: invalid multibulk length\r\n" {
+		t.Fatalf("11 args before AUTH: got=%q", got)
+	}
+	if got := dial().do("AUTH", strings.Repeat("x", 17<<10)); got != "-ERR Protocol error: invalid bulk length\r\n" {
+		t.Fatalf("17KB bulk before AUTH: got=%q", got)
+	}
+
+	// Once authenticated, the full limits apply
+	rc := dial()
+	if got := rc.do("AUTH", "s3cret"); got != "+OK\r\n" {
+		t.Fatalf("AUTH: got=%q", got)
+	}
+	if got := rc.do("SET", "k", big); got != "+OK\r\n" {
+		t.Fatalf("SET 100KB after AUTH: got=%q", got)
+	}
+	if got := rc.do("GET", "k"); got != "$"+strconv.Itoa(len(big))+"\r\n"+big+"\r\n" {
+		t.Fatalf("GET 100KB after AUTH: got %d bytes", len(got))
+	}
+}
+
+func TestRESPReadOnly(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	c := stache.NewCache()
+	_ = c.SetString("k", "v", 0)
+	s := newRESPServer(c, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.readOnly = true
+	go s.Serve(ln)
+	defer s.Close()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	rc := &respClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+
+	if got := rc.do("GET", "k"); got != "$1\r\nv\r\n" {
+		t.Fatalf("GET on replica: got=%q", got)
+	}
+	if got := rc.do("SET", "k", "w"); got != "-READONLY You can't write against a read only replica.\r\n" {
+		t.Fatalf("SET on replica: got=%q", got)
+	}
+}
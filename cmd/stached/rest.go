@@ -0,0 +1,320 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// restMaxBody caps the size of a value written through the REST API.
+const restMaxBody = 32 << 20
+
+// restServer exposes the cache as plain HTTP resources under /v1/keys, for
+// curl and browsers:
+//
+//	GET    /v1/keys?prefix=p   list entries as JSON
+//	GET    /v1/keys/{key}      read a value, with its Content-Type and ETag
+//	PUT    /v1/keys/{key}      write a value
+//	DELETE /v1/keys/{key}      remove a value
+//
+// ETags are entry versions, so If-Match and If-None-Match make writes
+// conditional. In cluster mode a key owned by another node is redirected
+// there.
+type restServer struct {
+	cache *stache.Cache
+
+	// readOnly, if set, rejects PUT and DELETE with this error.
+	readOnly error
+
+	cluster *clusterState
+}
+
+func (s *restServer) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/keys", s.list)
+	mux.HandleFunc("GET /v1/keys/{key...}", s.get)
+	mux.HandleFunc("PUT /v1/keys/{key...}", s.put)
+	mux.HandleFunc("DELETE /v1/keys/{key...}", s.delete)
+}
+
+func etag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// etagMatches reports whether an If-Match or If-None-Match header value
+// matches version, where 0 means the key does not exist.
+func etagMatches(header string, version uint64) bool {
+	for tag := range strings.SplitSeq(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" && version != 0 {
+			return true
+		}
+		if version != 0 && tag == etag(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// setEntryHeaders describes info in response headers.
+func setEntryHeaders(w http.ResponseWriter, info stache.EntryInfo) {
+	w.Header().Set("Content-Type", string(info.ContentType))
+	w.Header().Set("ETag", etag(info.Version))
+
+	if info.ExpiresAt.IsZero() {
+		return
+	}
+	secs := max(int64(time.Until(info.ExpiresAt)/time.Second), 0)
+	w.Header().Set("Cache-Control", "max-age="+strconv.FormatInt(secs, 10))
+	w.Header().Set("Expires", info.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+func restError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, stache.ErrNotFound):
+		http.Error(w, "key not found", http.StatusNotFound)
+	case errors.Is(err, stache.ErrIncorrectType):
+		http.Error(w, "key holds a structured type", http.StatusConflict)
+	case errors.Is(err, stache.ErrConflict):
+		http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// redirect sends the client to the owner of key, if that is another node,
+// and reports whether it did.
+func (s *restServer) redirect(w http.ResponseWriter, r *http.Request, key string) bool {
+	var me *misdirectedError
+	if !errors.As(s.cluster.misdirected(key), &me) {
+		return false
+	}
+
+	http.Redirect(w, r, me.owner+r.URL.RequestURI(), http.StatusTemporaryRedirect)
+	return true
+}
+
+func (s *restServer) get(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if s.redirect(w, r, key) {
+		return
+	}
+
+	value, info, err := s.cache.GetWithInfo(key)
+	if err != nil {
+		restError(w, err)
+		return
+	}
+
+	setEntryHeaders(w, info)
+	if inm := r.Header.Get("If-None-Match"); inm != "" && etagMatches(inm, info.Version) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(value)))
+	w.Write(value)
+}
+
+// requestMeta builds the entry metadata for a PUT from its headers. The TTL
+// comes from X-Stache-TTL (a duration or whole seconds) or else from
+// Cache-Control: max-age. X-Stache-Sliding: true makes it slide, and
+// X-Stache-Tags holds comma-separated tags.
+func requestMeta(h http.Header) (stache.Meta, error) {
+	meta := stache.Meta{ContentType: stache.Text}
+
+	if ct := h.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			return stache.Meta{}, fmt.Errorf("bad Content-Type: %w", err)
+		}
+
+		// Drop parameters such as charset for the types the typed getters
+		// compare exactly.
+		meta.ContentType = stache.ContentType(ct)
+		if mt == string(stache.JSON) || mt == string(stache.Text) {
+			meta.ContentType = stache.ContentType(mt)
+		}
+	}
+
+	if ttl := h.Get("X-Stache-TTL"); ttl != "" {
+		if secs, err := strconv.ParseInt(ttl, 10, 64); err == nil {
+			meta.TTL = time.Duration(secs) * time.Second
+		} else if meta.TTL, err = time.ParseDuration(ttl); err != nil {
+			return stache.Meta{}, fmt.Errorf("bad X-Stache-TTL: %w", err)
+		}
+	} else {
+		for directive := range strings.SplitSeq(h.Get("Cache-Control"), ",") {
+			v, ok := strings.CutPrefix(strings.TrimSpace(directive), "max-age=")
+			if !ok {
+				continue
+			}
+			secs, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return stache.Meta{}, fmt.Errorf("bad Cache-Control max-age: %w", err)
+			}
+			meta.TTL = time.Duration(secs) * time.Second
+		}
+	}
+
+	if sliding := h.Get("X-Stache-Sliding"); sliding != "" {
+		var err error
+		if meta.Sliding, err = strconv.ParseBool(sliding); err != nil {
+			return stache.Meta{}, fmt.Errorf("bad X-Stache-Sliding: %w", err)
+		}
+	}
+
+	for tag := range strings.SplitSeq(h.Get("X-Stache-Tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			meta.Tags = append(meta.Tags, tag)
+		}
+	}
+
+	return meta, nil
+}
+
+// checkPreconditions applies If-Match and If-None-Match to the version a
+// key has in tx.
+func checkPreconditions(tx *stache.Tx, key string, h http.Header) error {
+	version := tx.Version(key)
+
+	if im := h.Get("If-Match"); im != "" && !etagMatches(im, version) {
+		return stache.ErrConflict
+	}
+	if inm := h.Get("If-None-Match"); inm != "" && etagMatches(inm, version) {
+		return stache.ErrConflict
+	}
+
+	return nil
+}
+
+func (s *restServer) put(w http.ResponseWriter, r *http.Request) {
+	if s.readOnly != nil {
+		http.Error(w, s.readOnly.Error(), http.StatusForbidden)
+		return
+	}
+
+	key := r.PathValue("key")
+	if s.redirect(w, r, key) {
+		return
+	}
+
+	meta, err := requestMeta(r.Header)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	value, err := io.ReadAll(http.MaxBytesReader(w, r.Body, restMaxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "value too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var created bool
+	var tx *stache.Tx
+	err = s.cache.Txn(func(t *stache.Tx) error {
+		tx = t
+		if err := checkPreconditions(tx, key, r.Header); err != nil {
+			return err
+		}
+
+		created = tx.Version(key) == 0
+		return tx.Set(key, value, meta)
+	})
+	if errors.Is(err, stache.ErrIncorrectType) {
+		http.Error(w, "content type is reserved for structured types", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		restError(w, err)
+		return
+	}
+
+	if v := tx.Version(key); v != 0 {
+		w.Header().Set("ETag", etag(v))
+	}
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *restServer) delete(w http.ResponseWriter, r *http.Request) {
+	if s.readOnly != nil {
+		http.Error(w, s.readOnly.Error(), http.StatusForbidden)
+		return
+	}
+
+	key := r.PathValue("key")
+	if s.redirect(w, r, key) {
+		return
+	}
+
+	var existed bool
+	err := s.cache.Txn(func(tx *stache.Tx) error {
+		if err := checkPreconditions(tx, key, r.Header); err != nil {
+			return err
+		}
+
+		existed = tx.Delete(key)
+		return nil
+	})
+	if err != nil {
+		restError(w, err)
+		return
+	}
+
+	if !existed {
+		restError(w, stache.ErrNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// restEntry is the JSON form of an entry in a listing.
+type restEntry struct {
+	Key         string    `json:"key"`
+	Size        int       `json:"size"`
+	ContentType string    `json:"content_type"`
+	ExpiresAt   time.Time `json:"expires_at,omitzero"`
+	Tags        []string  `json:"tags,omitempty"`
+	Version     uint64    `json:"version"`
+}
+
+func (s *restServer) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+
+	out := []restEntry{}
+	for _, e := range s.cache.Entries() {
+		if !strings.HasPrefix(e.Key, prefix) {
+			continue
+		}
+		out = append(out, restEntry{
+			Key:         e.Key,
+			Size:        e.Size,
+			ContentType: string(e.ContentType),
+			ExpiresAt:   e.ExpiresAt,
+			Tags:        e.Tags,
+			Version:     e.Version,
+		})
+	}
+	slices.SortFunc(out, func(a, b restEntry) int { return strings.Compare(a.Key, b.Key) })
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func startREST(t *testing.T) (*stache.Cache, string) {
+	t.Helper()
+
+	c := stache.NewCache()
+	mux := http.NewServeMux()
+	(&restServer{cache: c}).register(mux)
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return c, ts.URL
+}
+
+func restDo(t *testing.T, method, url, body string, header map[string]string) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer res.Body.Close()
+
+	b, _ := io.ReadAll(res.Body)
+	return res, string(b)
+}
+
+func TestRESTKeys(t *testing.T) {
+	c, base := startREST(t)
+	url := base + "/v1/keys/user/42"
+
+	res, _ := restDo(t, "PUT", url, `{"name":"DaBaby"}`, map[string]string{
+		"Content-Type":  "application/json; charset=utf-8",
+		"Cache-Control": "max-age=60",
+		"X-Stache-Tags": "users, vip",
+	})
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("PUT new key status: got=%d want=201", res.StatusCode)
+	}
+	etag := res.Header.Get("ETag")
+
+	var out struct{ Name string }
+	if err := c.GetJSON("user/42", &out); err != nil || out.Name != "DaBaby" {
+		t.Fatalf("stored value mismatch: %+v err=%v", out, err)
+	}
+
+	res, body := restDo(t, "GET", url, "", nil)
+	if res.StatusCode != http.StatusOK || body != `{"name":"DaBaby"}` {
+		t.Fatalf("GET mismatch: status=%d body=%q", res.StatusCode, body)
+	}
+	if res.Header.Get("Content-Type") != "application/json" || res.Header.Get("ETag") != etag {
+		t.Fatalf("GET headers mismatch: %v", res.Header)
+	}
+	if cc := res.Header.Get("Cache-Control"); cc != "max-age=60" && cc != "max-age=59" {
+		t.Fatalf("Cache-Control mismatch: got=%q", cc)
+	}
+
+	res, _ = restDo(t, "GET", url, "", map[string]string{"If-None-Match": etag})
+	if res.StatusCode != http.StatusNotModified {
+		t.Fatalf("conditional GET status: got=%d want=304", res.StatusCode)
+	}
+
+	// Conditional writes
+	res, _ = restDo(t, "PUT", url, "x", map[string]string{"If-Match": `"12345"`})
+	if res.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("PUT with stale If-Match status: got=%d want=412", res.StatusCode)
+	}
+	res, _ = restDo(t, "PUT", url, "x", map[string]string{"If-None-Match": "*"})
+	if res.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("PUT with If-None-Match: * on existing key: got=%d want=412", res.StatusCode)
+	}
+	res, _ = restDo(t, "PUT", url, "updated", map[string]string{"If-Match": etag, "X-Stache-TTL": "0"})
+	if res.StatusCode != http.StatusNoContent || res.Header.Get("ETag") == etag {
+		t.Fatalf("PUT with current If-Match: status=%d etag=%q", res.StatusCode, res.Header.Get("ETag"))
+	}
+	if s, _ := c.GetString("user/42"); s != "updated" {
+		t.Fatalf("value after conditional PUT: got=%q", s)
+	}
+
+	_ = c.SetString("user/43", "x", 0)
+	_ = c.SetString("other", "x", 0)
+	res, body = restDo(t, "GET", base+"/v1/keys?prefix=user/", "", nil)
+	var list []restEntry
+	if err := json.Unmarshal([]byte(body), &list); err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("list: status=%d body=%q err=%v", res.StatusCode, body, err)
+	}
+	if len(list) != 2 || list[0].Key != "user/42" || list[1].Key != "user/43" {
+		t.Fatalf("list mismatch: %+v", list)
+	}
+
+	res, _ = restDo(t, "DELETE", url, "", map[string]string{"If-Match": etag})
+	if res.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("DELETE with stale If-Match status: got=%d want=412", res.StatusCode)
+	}
+	res, _ = restDo(t, "DELETE", url, "", nil)
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("DELETE status: got=%d want=204", res.StatusCode)
+	}
+	res, _ = restDo(t, "GET", url, "", nil)
+	if res.StatusCode != http.StatusNotFound {
+		t.Fatalf("GET after DELETE status: got=%d want=404", res.StatusCode)
+	}
+
+	_, _ = c.HSet("h", "f", []byte("v"))
+	res, _ = restDo(t, "GET", base+"/v1/keys/h", "", nil)
+	if res.StatusCode != http.StatusConflict {
+		t.Fatalf("GET on hash status: got=%d want=409", res.StatusCode)
+	}
+}
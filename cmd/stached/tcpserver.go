@@ -0,0 +1,93 @@
+package main
+
+import (
+	"net"
+	"sync"
+)
+
+// tcpServer runs handle on its own goroutine for every accepted connection
+// and tracks them so Close can tear everything down, for the line-based
+// protocol listeners.
+type tcpServer struct {
+	handle func(net.Conn)
+
+	mu     sync.Mutex
+	ln     net.Listener
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func newTCPServer(handle func(net.Conn)) *tcpServer {
+	return &tcpServer{handle: handle, conns: map[net.Conn]struct{}{}}
+}
+
+// Serve accepts connections on ln until Close is called.
+func (s *tcpServer) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return net.ErrClosed
+	}
+	s.ln = ln
+	s.mu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return nil
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// Close stops accepting connections, closes open ones and waits for
+// their handlers to return.
+func (s *tcpServer) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	var err error
+	if s.ln != nil {
+		err = s.ln.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+// Len returns the number of open connections.
+func (s *tcpServer) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.conns)
+}
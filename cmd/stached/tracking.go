@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+const (
+	trackingHeartbeat = time.Second
+
+	// trackingMaxPending is how many invalidations queue for a client that
+	// is not keeping up, and trackingMaxKeys how many keys a client may
+	// track, before it is sent a flush instead.
+	trackingMaxPending = 1 << 12
+	trackingMaxKeys    = 1 << 16
+)
+
+// tracker remembers which keys each client of the Invalidations stream
+// read with its tracking id, and queues an invalidation for them the next
+// time the key changes. A registration is used up by its invalidation.
+//
+// The zero value is ready to use. It subscribes to the cache only while a
+// stream is open; the cache's lock is taken before the tracker's.
+type tracker struct {
+	// subMutex serialises subscribing and unsubscribing, which lock the
+	// cache and so cannot run under mutex.
+	subMutex    sync.Mutex
+	unsubscribe func()
+
+	mutex   sync.Mutex
+	nextID  uint64
+	clients map[uint64]*trackingClient
+	keys    map[string]map[uint64]struct{}
+}
+
+type trackingClient struct {
+	keys    map[string]struct{}
+	pending map[string]struct{}
+	flush   bool
+
+	// notify has room for one signal that pending or flush changed.
+	notify chan struct{}
+}
+
+// register adds a client and returns its tracking id.
+func (t *tracker) register(c *stache.Cache) (uint64, *trackingClient) {
+	t.subMutex.Lock()
+	defer t.subMutex.Unlock()
+
+	if t.unsubscribe == nil {
+		t.unsubscribe = c.Subscribe(t.onEvent)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.clients == nil {
+		t.clients = map[uint64]*trackingClient{}
+		t.keys = map[string]map[uint64]struct{}{}
+	}
+	t.nextID++
+	tc := &trackingClient{
+		keys:    map[string]struct{}{},
+		pending: map[string]struct{}{},
+		notify:  make(chan struct{}, 1),
+	}
+	t.clients[t.nextID] = tc
+
+	return t.nextID, tc
+}
+
+// unregister drops the client's registrations, and the subscription when
+// no clients are left.
+func (t *tracker) unregister(id uint64) {
+	t.subMutex.Lock()
+	defer t.subMutex.Unlock()
+
+	t.mutex.Lock()
+	if tc, ok := t.clients[id]; ok {
+		t.forgetLocked(id, tc)
+		delete(t.clients, id)
+	}
+	last := len(t.clients) == 0
+	t.mutex.Unlock()
+
+	if last && t.unsubscribe != nil {
+		t.unsubscribe()
+		t.unsubscribe = nil
+	}
+}
+
+// track registers key for the client, and reports false if there is no
+// such client.
+func (t *tracker) track(id uint64, key string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tc, ok := t.clients[id]
+	if !ok {
+		return false
+	}
+	if _, ok := tc.keys[key]; ok {
+		return true
+	}
+
+	if len(tc.keys) >= trackingMaxKeys {
+		t.flushLocked(id, tc)
+	}
+	tc.keys[key] = struct{}{}
+	ids, ok := t.keys[key]
+	if !ok {
+		ids = map[uint64]struct{}{}
+		t.keys[key] = ids
+	}
+	ids[id] = struct{}{}
+
+	return true
+}
+
+// untrack drops a registration made by track.
+func (t *tracker) untrack(id uint64, key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tc, ok := t.clients[id]
+	if !ok {
+		return
+	}
+	delete(tc.keys, key)
+	t.dropLocked(key, id)
+}
+
+// next takes the client's queued invalidations.
+func (t *tracker) next(tc *trackingClient) (keys []string, flush bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for key := range tc.pending {
+		keys = append(keys, key)
+	}
+	clear(tc.pending)
+	flush, tc.flush = tc.flush, false
+
+	return keys, flush
+}
+
+// onEvent runs under the cache's lock. Sliding reads report an expiry
+// change on every hit, so sliding entries are never tracked and their
+// expiry changes are ignored.
+func (t *tracker) onEvent(ev stache.Event) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	switch ev.Op {
+	case stache.OpClear:
+		for id, tc := range t.clients {
+			t.flushLocked(id, tc)
+		}
+		return
+	case stache.OpExpire:
+		if ev.Item.Meta.Sliding {
+			return
+		}
+	}
+
+	key := ev.Item.Key
+	for id := range t.keys[key] {
+		tc := t.clients[id]
+		delete(tc.keys, key)
+		if !tc.flush {
+			tc.pending[key] = struct{}{}
+		}
+		if len(tc.pending) > trackingMaxPending {
+			t.flushLocked(id, tc)
+		}
+		t.signal(tc)
+	}
+	delete(t.keys, key)
+}
+
+// flushLocked replaces the client's registrations and queued keys with a
+// single flush.
+func (t *tracker) flushLocked(id uint64, tc *trackingClient) {
+	t.forgetLocked(id, tc)
+	clear(tc.pending)
+	tc.flush = true
+	t.signal(tc)
+}
+
+func (t *tracker) forgetLocked(id uint64, tc *trackingClient) {
+	for key := range tc.keys {
+		t.dropLocked(key, id)
+	}
+	clear(tc.keys)
+}
+
+func (t *tracker) dropLocked(key string, id uint64) {
+	if ids, ok := t.keys[key]; ok {
+		delete(ids, id)
+		if len(ids) == 0 {
+			delete(t.keys, key)
+		}
+	}
+}
+
+func (t *tracker) signal(tc *trackingClient) {
+	select {
+	case tc.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Invalidations sends a tracking id for use in GetRequest.tracking_id, then
+// the keys read with it as they change, with heartbeats while idle. The
+// registrations end with the stream.
+func (s *cacheServer) Invalidations(ctx context.Context, req *connect.Request[stachev1.InvalidationsRequest], stream *connect.ServerStream[stachev1.InvalidationEvent]) error {
+	id, tc := s.tracking.register(s.cache)
+	defer s.tracking.unregister(id)
+
+	if err := stream.Send(&stachev1.InvalidationEvent{TrackingId: &id}); err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(trackingHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-tc.notify:
+			keys, flush := s.tracking.next(tc)
+			if len(keys) == 0 && !flush {
+				continue
+			}
+			ev := &stachev1.InvalidationEvent{Keys: keys}
+			if flush {
+				ev.Flush = &flush
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+
+		case <-heartbeat.C:
+			if len(tc.notify) > 0 {
+				continue
+			}
+			if err := stream.Send(&stachev1.InvalidationEvent{}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+func TestInvalidations(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := stache.NewCache()
+	service := &cacheServer{cache: c}
+	client := startNode(t, service)
+
+	_ = c.SetString("a", "1", 0)
+	_ = c.SetString("b", "1", 0)
+	_ = c.Set("sliding", []byte("1"), stache.Meta{TTL: time.Minute, Sliding: true})
+
+	stream, err := client.Invalidations(ctx, connect.NewRequest(&stachev1.InvalidationsRequest{}))
+	if err != nil || !stream.Receive() {
+		t.Fatalf("Invalidations: %v %v", err, stream.Err())
+	}
+	id := stream.Msg().GetTrackingId()
+	if id == 0 {
+		t.Fatal("first event carries no tracking id")
+	}
+
+	get := func(key string) (*stachev1.GetResponse, error) {
+		res, err := client.Get(ctx, connect.NewRequest(&stachev1.GetRequest{Key: &key, TrackingId: &id}))
+		if err != nil {
+			return nil, err
+		}
+		return res.Msg, nil
+	}
+	// next returns the next event that is not a heartbeat.
+	next := func() *stachev1.InvalidationEvent {
+		t.Helper()
+		for stream.Receive() {
+			if msg := stream.Msg(); len(msg.GetKeys()) > 0 || msg.GetFlush() {
+				return msg
+			}
+		}
+		t.Fatalf("stream ended: %v", stream.Err())
+		return nil
+	}
+
+	for _, key := range []string{"a", "b"} {
+		if res, err := get(key); err != nil || !res.GetTracked() {
+			t.Fatalf("Get(%q): tracked=%v err=%v", key, res.GetTracked(), err)
+		}
+	}
+	if res, err := get("sliding"); err != nil || res.GetTracked() {
+		t.Fatalf("Get(sliding): tracked=%v err=%v", res.GetTracked(), err)
+	}
+	if _, err := get("missing"); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("Get(missing): %v", err)
+	}
+
+	// Reads of sliding entries, and missing keys, are not tracked
+	_, _ = c.GetBytes("sliding")
+	_ = c.SetString("sliding", "2", 0)
+	_ = c.SetString("missing", "2", 0)
+
+	_ = c.SetString("a", "2", 0)
+	if ev := next(); !slices.Equal(ev.GetKeys(), []string{"a"}) || ev.GetFlush() {
+		t.Fatalf("after Set: got=%v", ev)
+	}
+
+	// The registration was used up by the invalidation
+	_ = c.SetString("a", "3", 0)
+	c.Delete("b")
+	if ev := next(); !slices.Equal(ev.GetKeys(), []string{"b"}) {
+		t.Fatalf("after Delete: got=%v", ev)
+	}
+
+	if res, err := get("a"); err != nil || !res.GetTracked() {
+		t.Fatalf("Get(a) again: tracked=%v err=%v", res.GetTracked(), err)
+	}
+	c.Clear()
+	if ev := next(); !ev.GetFlush() {
+		t.Fatalf("after Clear: got=%v", ev)
+	}
+
+	// The subscription ends with the last stream
+	cancel()
+	eventually(t, "unsubscribe", func() bool {
+		service.tracking.subMutex.Lock()
+		defer service.tracking.subMutex.Unlock()
+		return service.tracking.unsubscribe == nil
+	})
+	if service.tracking.track(id, "a") {
+		t.Fatal("tracking id still registered after the stream ended")
+	}
+}
@@ -0,0 +1,41 @@
+package cluster
+
+// Rendezvous assigns keys to a fixed set of nodes by rendezvous (highest
+// random weight) hashing: each key goes to the node that scores highest
+// for it. Removing a node only moves the keys it owned, without the
+// virtual points a Ring needs for even load. It is immutable and so safe
+// for concurrent use.
+type Rendezvous struct {
+	nodes []string
+	seeds []uint64
+}
+
+// NewRendezvous returns a Rendezvous over nodes, in the given order.
+func NewRendezvous(nodes ...string) *Rendezvous {
+	r := &Rendezvous{nodes: nodes, seeds: make([]uint64, len(nodes))}
+	for i, n := range nodes {
+		r.seeds[i] = hash(n)
+	}
+	return r
+}
+
+// Index returns the position in the node list of the node owning key, or
+// -1 if there are no nodes.
+func (r *Rendezvous) Index(key string) int {
+	h := hash(key)
+	best, bestScore := -1, uint64(0)
+	for i, seed := range r.seeds {
+		if score := mix(h ^ seed); best < 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// Owner returns the node owning key, or "" if there are no nodes.
+func (r *Rendezvous) Owner(key string) string {
+	if i := r.Index(key); i >= 0 {
+		return r.nodes[i]
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestRendezvous(t *testing.T) {
+	r := NewRendezvous("n1", "n2", "n3", "n4")
+
+	const keys = 20000
+	counts := map[string]int{}
+	for i := range keys {
+		counts[r.Owner(strconv.Itoa(i))]++
+	}
+	for n, c := range counts {
+		if math.Abs(float64(c)-keys/4) > keys/4*0.1 {
+			t.Fatalf("node %s owns %d of %d keys, want about %d", n, c, keys, keys/4)
+		}
+	}
+
+	// Dropping a node only moves the keys it owned
+	less := NewRendezvous("n1", "n2", "n4")
+	for i := range keys {
+		key := strconv.Itoa(i)
+		if before := r.Owner(key); before != "n3" && less.Owner(key) != before {
+			t.Fatalf("key %q moved from %s to %s", key, before, less.Owner(key))
+		}
+	}
+
+	if owner := NewRendezvous().Owner("k"); owner != "" {
+		t.Fatalf("Owner with no nodes: got=%q want=\"\"", owner)
+	}
+}
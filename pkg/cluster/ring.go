@@ -0,0 +1,154 @@
+// Package cluster holds the building blocks stached uses to spread the
+// keyspace over several nodes.
+package cluster
+
+import (
+	"cmp"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// DefaultVnodes is the number of virtual nodes per node used when NewRing
+// is given a non-positive count.
+const DefaultVnodes = 128
+
+// Ring assigns keys to nodes by consistent hashing. Each node is placed on
+// the ring at several virtual points, so adding or removing a node only
+// moves the keys next to its points and load stays even.
+// It is safe for concurrent use.
+type Ring struct {
+	mutex  sync.RWMutex
+	vnodes int
+	points []point
+	nodes  map[string]struct{}
+}
+
+type point struct {
+	hash uint64
+	node string
+}
+
+// NewRing returns a ring holding nodes with vnodes virtual points each.
+func NewRing(vnodes int, nodes ...string) *Ring {
+	if vnodes <= 0 {
+		vnodes = DefaultVnodes
+	}
+
+	r := &Ring{vnodes: vnodes, nodes: map[string]struct{}{}}
+	r.Add(nodes...)
+	return r
+}
+
+// hash is FNV-1a followed by a 64-bit finalizer, so that similar inputs
+// such as "node#1" and "node#2" land far apart.
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return mix(h.Sum64())
+}
+
+// mix is the splitmix64 finalizer.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Vnodes returns the number of virtual points per node.
+func (r *Ring) Vnodes() int {
+	return r.vnodes
+}
+
+// Add places nodes on the ring. Nodes already present are ignored.
+func (r *Ring) Add(nodes ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, n := range nodes {
+		if _, ok := r.nodes[n]; ok {
+			continue
+		}
+
+		r.nodes[n] = struct{}{}
+		for i := range r.vnodes {
+			r.points = append(r.points, point{hash(n + "#" + strconv.Itoa(i)), n})
+		}
+	}
+
+	slices.SortFunc(r.points, func(a, b point) int {
+		if a.hash != b.hash {
+			return cmp.Compare(a.hash, b.hash)
+		}
+		// Break the (unlikely) tie the same way on every node.
+		return cmp.Compare(a.node, b.node)
+	})
+}
+
+// Remove takes nodes off the ring.
+func (r *Ring) Remove(nodes ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, n := range nodes {
+		delete(r.nodes, n)
+	}
+	r.points = slices.DeleteFunc(r.points, func(p point) bool {
+		_, ok := r.nodes[p.node]
+		return !ok
+	})
+}
+
+// Owner returns the node responsible for key, or "" if the ring is empty.
+func (r *Ring) Owner(key string) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	h := hash(key)
+	i, _ := slices.BinarySearchFunc(r.points, h, func(p point, h uint64) int {
+		return cmp.Compare(p.hash, h)
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].node
+}
+
+// Nodes returns the nodes on the ring, sorted.
+func (r *Ring) Nodes() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	nodes := make([]string, 0, len(r.nodes))
+	for n := range r.nodes {
+		nodes = append(nodes, n)
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+// Shares returns the fraction of the hash space each node owns.
+func (r *Ring) Shares() map[string]float64 {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	shares := map[string]float64{}
+	for i, p := range r.points {
+		// Each point owns the arc back to the previous point.
+		prev := r.points[(i+len(r.points)-1)%len(r.points)].hash
+		arc := p.hash - prev // wraps around for i == 0
+		if len(r.points) == 1 {
+			arc = ^uint64(0)
+		}
+		shares[p.node] += float64(arc) / (1 << 64)
+	}
+	return shares
+}
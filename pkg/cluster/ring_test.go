@@ -0,0 +1,60 @@
+package cluster
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestRingOwnerStable(t *testing.T) {
+	a := NewRing(64, "n1", "n2", "n3")
+	b := NewRing(64, "n3", "n1", "n2")
+
+	for i := range 1000 {
+		key := "key:" + strconv.Itoa(i)
+		if a.Owner(key) != b.Owner(key) {
+			t.Fatalf("Owner(%q) depends on insertion order: %q vs %q", key, a.Owner(key), b.Owner(key))
+		}
+	}
+
+	if owner := NewRing(0).Owner("k"); owner != "" {
+		t.Fatalf("Owner on empty ring: got=%q want=\"\"", owner)
+	}
+}
+
+func TestRingBalanceAndMinimalMoves(t *testing.T) {
+	r := NewRing(DefaultVnodes, "n1", "n2", "n3", "n4")
+
+	const keys = 20000
+	before := make([]string, keys)
+	counts := map[string]int{}
+	for i := range keys {
+		before[i] = r.Owner(strconv.Itoa(i))
+		counts[before[i]]++
+	}
+	for n, c := range counts {
+		if math.Abs(float64(c)-keys/4) > keys/4*0.25 {
+			t.Fatalf("node %s owns %d of %d keys, want about %d", n, c, keys, keys/4)
+		}
+	}
+
+	total := 0.0
+	for _, s := range r.Shares() {
+		total += s
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Fatalf("Shares sum mismatch: got=%v want=1", total)
+	}
+
+	// Only keys owned by the removed node may move
+	r.Remove("n2")
+	for i := range keys {
+		after := r.Owner(strconv.Itoa(i))
+		if before[i] != "n2" && after != before[i] {
+			t.Fatalf("key %d moved from %s to %s after removing n2", i, before[i], after)
+		}
+		if after == "n2" {
+			t.Fatalf("key %d still owned by removed node", i)
+		}
+	}
+}
@@ -0,0 +1,605 @@
+// Package gossip implements SWIM-style group membership and failure
+// detection. Each node probes one other member per interval; a member that
+// misses both a direct ping and pings relayed through other members is
+// suspected, and declared dead unless it refutes the suspicion in time by
+// raising its incarnation number. Membership changes spread by
+// piggybacking on the probes, with a periodic full state exchange to heal
+// partitions.
+package gossip
+
+import (
+	"cmp"
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultProbeInterval    = time.Second
+	DefaultProbeTimeout     = 300 * time.Millisecond
+	DefaultSuspicionTimeout = 5 * time.Second
+	DefaultIndirectProbes   = 3
+	DefaultSyncInterval     = 30 * time.Second
+
+	// retransmitMult scales how many messages carry each update: it is
+	// piggybacked retransmitMult * log10(n+1) times in a group of n.
+	retransmitMult = 4
+
+	// maxPiggyback caps the updates carried by one message.
+	maxPiggyback = 16
+
+	// deadRetention is how long a dead or departed member is remembered,
+	// so that stale news of it being alive is ignored.
+	deadRetention = time.Minute
+)
+
+// State is what a node believes about a member.
+type State int
+
+const (
+	Alive State = iota
+	Suspect
+	Dead
+	Left
+)
+
+func (s State) String() string {
+	switch s {
+	case Alive:
+		return "alive"
+	case Suspect:
+		return "suspect"
+	case Dead:
+		return "dead"
+	case Left:
+		return "left"
+	default:
+		return "unknown"
+	}
+}
+
+// rank orders states for the same incarnation: news that a member is
+// worse off wins.
+func (s State) rank() int {
+	return min(int(s), int(Dead))
+}
+
+// Member is one node of the group, named by the address other nodes reach
+// it at. Incarnation is raised only by the member itself, to refute a
+// suspicion; an update with a higher incarnation always wins.
+type Member struct {
+	Addr        string `json:"addr"`
+	State       State  `json:"state"`
+	Incarnation uint64 `json:"incarnation"`
+}
+
+// Live reports whether m should still be counted as part of the group.
+// Suspected members are, until they are declared dead.
+func (m Member) Live() bool {
+	return m.State == Alive || m.State == Suspect
+}
+
+// Config describes a node and how it finds the group.
+type Config struct {
+	// Self is this node's address.
+	Self string
+
+	// Seeds are addresses to join through. They are tried at start and
+	// whenever the node knows of no other live member; Self is skipped.
+	Seeds []string
+
+	// ProbeInterval is how often a member is probed.
+	ProbeInterval time.Duration
+
+	// ProbeTimeout bounds a ping, direct or relayed.
+	ProbeTimeout time.Duration
+
+	// SuspicionTimeout is how long a suspected member has to refute the
+	// suspicion before it is declared dead.
+	SuspicionTimeout time.Duration
+
+	// IndirectProbes is how many members are asked to ping a member that
+	// missed a direct ping.
+	IndirectProbes int
+
+	// SyncInterval is how often the full member list is exchanged with a
+	// random live member.
+	SyncInterval time.Duration
+
+	// Notify, if set, is called whenever a member joins or changes state,
+	// including on incarnation changes. Calls are serialized and made in
+	// order, without holding the Memberlist's lock.
+	Notify func(Member)
+
+	Logger *slog.Logger
+}
+
+type memberState struct {
+	Member
+
+	// since is when the member's state or incarnation last changed.
+	since time.Time
+}
+
+type broadcast struct {
+	member    Member
+	transmits int
+}
+
+// Memberlist is this node's view of the group. Its handlers (HandlePing,
+// HandlePingReq and HandleSync) must be wired to whatever serves the
+// Transport the other members use.
+type Memberlist struct {
+	cfg    Config
+	tr     Transport
+	logger *slog.Logger
+
+	mutex   sync.Mutex
+	members map[string]*memberState
+	leaving bool
+
+	// probeOrder is a shuffled round of members to probe, from probeNext.
+	probeOrder []string
+	probeNext  int
+
+	// queue holds the latest update per member still to be piggybacked.
+	queue map[string]*broadcast
+
+	// events holds the changes not yet passed to Notify; notifyMutex
+	// keeps deliveries in order.
+	events      []Member
+	notifyMutex sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New returns a Memberlist holding only Self, which starts probing and
+// joining once Start is called.
+func New(cfg Config, tr Transport) *Memberlist {
+	if cfg.ProbeInterval <= 0 {
+		cfg.ProbeInterval = DefaultProbeInterval
+	}
+	if cfg.ProbeTimeout <= 0 {
+		cfg.ProbeTimeout = DefaultProbeTimeout
+	}
+	if cfg.SuspicionTimeout <= 0 {
+		cfg.SuspicionTimeout = DefaultSuspicionTimeout
+	}
+	if cfg.IndirectProbes <= 0 {
+		cfg.IndirectProbes = DefaultIndirectProbes
+	}
+	if cfg.SyncInterval <= 0 {
+		cfg.SyncInterval = DefaultSyncInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	cfg.Seeds = slices.DeleteFunc(slices.Clone(cfg.Seeds), func(s string) bool { return s == cfg.Self })
+
+	m := &Memberlist{
+		cfg:     cfg,
+		tr:      tr,
+		logger:  cfg.Logger.With("gossip_self", cfg.Self),
+		members: map[string]*memberState{},
+		queue:   map[string]*broadcast{},
+	}
+	m.members[cfg.Self] = &memberState{Member: Member{Addr: cfg.Self}, since: time.Now()}
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+
+	return m
+}
+
+// Start joins through the seeds and runs the probe loop in the background.
+func (m *Memberlist) Start() {
+	m.wg.Add(1)
+	go m.run()
+}
+
+// Stop halts the node without telling the group, which will detect it as
+// failed. See Leave.
+func (m *Memberlist) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// Leave tells the live members that this node is leaving, so they drop it
+// at once instead of suspecting it, then stops it.
+func (m *Memberlist) Leave(ctx context.Context) {
+	m.mutex.Lock()
+	m.leaving = true
+	self := m.members[m.cfg.Self]
+	self.State, self.since = Left, time.Now()
+	left := self.Member
+	targets := m.liveLocked(m.cfg.Self)
+	m.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	for _, addr := range targets {
+		wg.Go(func() {
+			ctx, cancel := context.WithTimeout(ctx, m.cfg.ProbeTimeout)
+			defer cancel()
+			_, _ = m.tr.Ping(ctx, addr, &Ping{From: m.cfg.Self, Updates: []Member{left}})
+		})
+	}
+	wg.Wait()
+
+	m.Stop()
+}
+
+// Members returns every member this node knows of, itself included,
+// sorted by address. Dead members are kept for a while after they fail.
+func (m *Memberlist) Members() []Member {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.snapshotLocked()
+}
+
+// LiveMembers returns the addresses of the members that are alive or
+// suspected, itself included, sorted.
+func (m *Memberlist) LiveMembers() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	live := m.liveLocked("")
+	slices.Sort(live)
+	return live
+}
+
+func (m *Memberlist) snapshotLocked() []Member {
+	out := make([]Member, 0, len(m.members))
+	for _, ms := range m.members {
+		out = append(out, ms.Member)
+	}
+	slices.SortFunc(out, func(a, b Member) int { return cmp.Compare(a.Addr, b.Addr) })
+	return out
+}
+
+// liveLocked returns the live members other than except, in no order.
+func (m *Memberlist) liveLocked(except string) []string {
+	var out []string
+	for addr, ms := range m.members {
+		if addr != except && ms.Live() {
+			out = append(out, addr)
+		}
+	}
+	return out
+}
+
+func (m *Memberlist) HandlePing(req *Ping) *Ack {
+	m.merge(req.Updates)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return &Ack{OK: true, Updates: m.piggybackLocked(req.From)}
+}
+
+// HandlePingReq pings req.Target on behalf of req.From, for a probe whose
+// direct ping went unanswered.
+func (m *Memberlist) HandlePingReq(req *PingReq) *Ack {
+	m.merge(req.Updates)
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.cfg.ProbeTimeout)
+	defer cancel()
+	ok := m.ping(ctx, req.Target)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return &Ack{OK: ok, Updates: m.piggybackLocked(req.From)}
+}
+
+// HandleSync merges a peer's full member list and returns this node's.
+func (m *Memberlist) HandleSync(req *Sync) *Sync {
+	m.mergeSync(req.Members)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return &Sync{From: m.cfg.Self, Members: m.snapshotLocked()}
+}
+
+func (m *Memberlist) run() {
+	defer m.wg.Done()
+
+	m.join()
+
+	probe := time.NewTicker(m.cfg.ProbeInterval)
+	defer probe.Stop()
+	full := time.NewTicker(m.cfg.SyncInterval)
+	defer full.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-probe.C:
+			m.expire()
+			m.probe()
+		case <-full.C:
+			m.mutex.Lock()
+			peers := m.liveLocked(m.cfg.Self)
+			m.mutex.Unlock()
+			if len(peers) > 0 {
+				m.sync(peers[rand.N(len(peers))])
+			}
+		}
+	}
+}
+
+// join exchanges member lists with the first seed that answers or, failing
+// that, the first member believed dead, which may be on the far side of a
+// partition that has since healed.
+func (m *Memberlist) join() {
+	m.mutex.Lock()
+	candidates := slices.Clone(m.cfg.Seeds)
+	for addr, ms := range m.members {
+		if ms.State == Dead && !slices.Contains(candidates, addr) {
+			candidates = append(candidates, addr)
+		}
+	}
+	m.mutex.Unlock()
+
+	for _, addr := range candidates {
+		if m.sync(addr) {
+			return
+		}
+	}
+}
+
+func (m *Memberlist) sync(peer string) bool {
+	m.mutex.Lock()
+	req := &Sync{From: m.cfg.Self, Members: m.snapshotLocked()}
+	m.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.cfg.ProbeTimeout)
+	defer cancel()
+	res, err := m.tr.Sync(ctx, peer, req)
+	if err != nil {
+		m.logger.Debug("gossip sync failed", "peer", peer, "err", err)
+		return false
+	}
+
+	m.mergeSync(res.Members)
+	return true
+}
+
+// ping sends a direct ping to addr and reports whether it was answered.
+func (m *Memberlist) ping(ctx context.Context, addr string) bool {
+	m.mutex.Lock()
+	req := &Ping{From: m.cfg.Self, Updates: m.piggybackLocked(addr)}
+	m.mutex.Unlock()
+
+	ack, err := m.tr.Ping(ctx, addr, req)
+	if err != nil {
+		return false
+	}
+
+	m.merge(ack.Updates)
+	return ack.OK
+}
+
+// probe checks the next member in the round: directly, then through
+// IndirectProbes others, suspecting it if neither gets an answer.
+func (m *Memberlist) probe() {
+	target, helpers := m.nextTarget()
+	if target == "" {
+		m.join()
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.cfg.ProbeTimeout)
+	ok := m.ping(ctx, target)
+	cancel()
+	if ok || m.ctx.Err() != nil {
+		return
+	}
+
+	// A relayed ping takes a timeout at the helper, so allow it twice that.
+	ctx, cancel = context.WithTimeout(m.ctx, 2*m.cfg.ProbeTimeout)
+	defer cancel()
+	acks := make(chan bool, len(helpers))
+	for _, h := range helpers {
+		m.mutex.Lock()
+		req := &PingReq{From: m.cfg.Self, Target: target, Updates: m.piggybackLocked(h)}
+		m.mutex.Unlock()
+
+		go func() {
+			ack, err := m.tr.PingReq(ctx, h, req)
+			if err != nil {
+				acks <- false
+				return
+			}
+			m.merge(ack.Updates)
+			acks <- ack.OK
+		}()
+	}
+	for range helpers {
+		if <-acks {
+			return
+		}
+	}
+	if m.ctx.Err() != nil {
+		return
+	}
+
+	m.mutex.Lock()
+	if ms, ok := m.members[target]; ok && ms.State == Alive {
+		m.applyLocked(Member{Addr: target, State: Suspect, Incarnation: ms.Incarnation})
+	}
+	m.mutex.Unlock()
+	m.notify()
+}
+
+// nextTarget returns the next live member to probe, reshuffling the round
+// once it has been through every member, and up to IndirectProbes other
+// live members to relay through. It returns "" if no other member is live.
+func (m *Memberlist) nextTarget() (string, []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for range 2 {
+		for m.probeNext < len(m.probeOrder) {
+			addr := m.probeOrder[m.probeNext]
+			m.probeNext++
+			if ms, ok := m.members[addr]; ok && ms.Live() {
+				others := m.liveLocked(m.cfg.Self)
+				others = slices.DeleteFunc(others, func(a string) bool { return a == addr })
+				rand.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })
+				return addr, others[:min(len(others), m.cfg.IndirectProbes)]
+			}
+		}
+
+		m.probeOrder = m.liveLocked(m.cfg.Self)
+		m.probeNext = 0
+		rand.Shuffle(len(m.probeOrder), func(i, j int) {
+			m.probeOrder[i], m.probeOrder[j] = m.probeOrder[j], m.probeOrder[i]
+		})
+	}
+
+	return "", nil
+}
+
+// expire declares dead the suspects whose time to refute has run out, and
+// forgets members that have been dead for deadRetention.
+func (m *Memberlist) expire() {
+	m.mutex.Lock()
+	now := time.Now()
+	for addr, ms := range m.members {
+		switch {
+		case ms.State == Suspect && now.Sub(ms.since) >= m.cfg.SuspicionTimeout:
+			m.applyLocked(Member{Addr: addr, State: Dead, Incarnation: ms.Incarnation})
+		case !ms.Live() && addr != m.cfg.Self && now.Sub(ms.since) >= deadRetention:
+			delete(m.members, addr)
+			delete(m.queue, addr)
+		}
+	}
+	m.mutex.Unlock()
+
+	m.notify()
+}
+
+func (m *Memberlist) merge(updates []Member) {
+	m.mutex.Lock()
+	for _, u := range updates {
+		m.applyLocked(u)
+	}
+	m.mutex.Unlock()
+
+	m.notify()
+}
+
+// mergeSync merges a full member list. Deaths in it are taken as
+// suspicions: the list may come from the far side of a partition, and the
+// members it gave up on are probably alive and should get to refute.
+func (m *Memberlist) mergeSync(members []Member) {
+	for i, u := range members {
+		if u.State == Dead {
+			members[i].State = Suspect
+		}
+	}
+	m.merge(members)
+}
+
+// applyLocked applies an update if it is newer than what is known: a
+// higher incarnation, or the same one with a worse state. News that this
+// node is not alive is refuted by raising its incarnation past it.
+func (m *Memberlist) applyLocked(u Member) {
+	if u.Addr == "" {
+		return
+	}
+
+	if u.Addr == m.cfg.Self {
+		self := m.members[u.Addr]
+		if u.State != Alive && u.Incarnation >= self.Incarnation && !m.leaving {
+			self.Incarnation = u.Incarnation + 1
+			m.logger.Info("gossip refuting", "state", u.State.String(), "incarnation", self.Incarnation)
+			m.enqueueLocked(self.Member)
+		}
+		return
+	}
+
+	ms, ok := m.members[u.Addr]
+	if !ok {
+		// News of an unknown member's death is of no use.
+		if !u.Live() {
+			return
+		}
+		ms = &memberState{Member: u, since: time.Now()}
+		m.members[u.Addr] = ms
+		m.logger.Info("gossip member joined", "addr", u.Addr, "state", u.State.String(), "incarnation", u.Incarnation)
+		m.enqueueLocked(u)
+		m.events = append(m.events, u)
+		return
+	}
+
+	newer := u.Incarnation > ms.Incarnation ||
+		(u.Incarnation == ms.Incarnation && u.State.rank() > ms.State.rank())
+	if !newer {
+		return
+	}
+
+	ms.since = time.Now()
+	if u.State != ms.State {
+		m.logger.Info("gossip member changed", "addr", u.Addr, "state", u.State.String(), "incarnation", u.Incarnation)
+	}
+	ms.Member = u
+	m.enqueueLocked(u)
+	m.events = append(m.events, u)
+}
+
+func (m *Memberlist) enqueueLocked(u Member) {
+	m.queue[u.Addr] = &broadcast{member: u}
+}
+
+// piggybackLocked picks the updates to send to target: the least sent
+// first, each until it has gone out enough times to reach the whole group
+// with high probability. If target is not believed alive, its own entry
+// is added so that it can refute.
+func (m *Memberlist) piggybackLocked(target string) []Member {
+	queued := make([]*broadcast, 0, len(m.queue))
+	for _, b := range m.queue {
+		queued = append(queued, b)
+	}
+	slices.SortFunc(queued, func(a, b *broadcast) int { return cmp.Compare(a.transmits, b.transmits) })
+
+	limit := retransmitMult * int(math.Ceil(math.Log10(float64(len(m.members)+1))))
+	var out []Member
+	for _, b := range queued[:min(len(queued), maxPiggyback)] {
+		out = append(out, b.member)
+		if b.transmits++; b.transmits >= limit {
+			delete(m.queue, b.member.Addr)
+		}
+	}
+
+	if ms, ok := m.members[target]; ok && ms.State != Alive && !slices.ContainsFunc(out, func(u Member) bool { return u.Addr == target }) {
+		out = append(out, ms.Member)
+	}
+	return out
+}
+
+// notify passes the pending changes to Config.Notify, in order.
+func (m *Memberlist) notify() {
+	m.notifyMutex.Lock()
+	defer m.notifyMutex.Unlock()
+
+	m.mutex.Lock()
+	events := m.events
+	m.events = nil
+	m.mutex.Unlock()
+
+	if m.cfg.Notify == nil {
+		return
+	}
+	for _, e := range events {
+		m.cfg.Notify(e)
+	}
+}
@@ -0,0 +1,283 @@
+package gossip
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// network connects in-process members and can cut links between them.
+type network struct {
+	mutex   sync.Mutex
+	members map[string]*Memberlist
+	cut     map[[2]string]bool
+}
+
+type memTransport struct {
+	net  *network
+	from string
+}
+
+var errUnreachable = errors.New("unreachable")
+
+func (nw *network) peer(from, to string) (*Memberlist, error) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+
+	m, ok := nw.members[to]
+	if !ok || nw.cut[[2]string{from, to}] {
+		return nil, errUnreachable
+	}
+	return m, nil
+}
+
+// sever cuts the link between a and b, both ways.
+func (nw *network) sever(a, b string) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+
+	nw.cut[[2]string{a, b}] = true
+	nw.cut[[2]string{b, a}] = true
+}
+
+// isolate cuts every link between id and the other members.
+func (nw *network) isolate(id string) {
+	for other := range nw.members {
+		if other != id {
+			nw.sever(id, other)
+		}
+	}
+}
+
+func (nw *network) heal() {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+
+	clear(nw.cut)
+}
+
+func (t memTransport) Ping(ctx context.Context, peer string, req *Ping) (*Ack, error) {
+	m, err := t.net.peer(t.from, peer)
+	if err != nil {
+		return nil, err
+	}
+	return m.HandlePing(req), nil
+}
+
+func (t memTransport) PingReq(ctx context.Context, peer string, req *PingReq) (*Ack, error) {
+	m, err := t.net.peer(t.from, peer)
+	if err != nil {
+		return nil, err
+	}
+	return m.HandlePingReq(req), nil
+}
+
+func (t memTransport) Sync(ctx context.Context, peer string, req *Sync) (*Sync, error) {
+	m, err := t.net.peer(t.from, peer)
+	if err != nil {
+		return nil, err
+	}
+	return m.HandleSync(req), nil
+}
+
+// recorder collects what Notify reports to one member.
+type recorder struct {
+	mutex  sync.Mutex
+	events []Member
+}
+
+func (r *recorder) notify(m Member) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.events = append(r.events, m)
+}
+
+// saw reports whether addr was ever reported in state.
+func (r *recorder) saw(addr string, state State) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return slices.ContainsFunc(r.events, func(m Member) bool { return m.Addr == addr && m.State == state })
+}
+
+type testGroup struct {
+	net     *network
+	ids     []string
+	members map[string]*Memberlist
+	events  map[string]*recorder
+}
+
+// startGroup runs n members; all but the first join through the first.
+func startGroup(t *testing.T, n int) *testGroup {
+	t.Helper()
+
+	g := &testGroup{
+		net:     &network{members: map[string]*Memberlist{}, cut: map[[2]string]bool{}},
+		members: map[string]*Memberlist{},
+		events:  map[string]*recorder{},
+	}
+	for i := range n {
+		g.ids = append(g.ids, "n"+strconv.Itoa(i))
+	}
+
+	for i, id := range g.ids {
+		cfg := Config{
+			Self:             id,
+			ProbeInterval:    20 * time.Millisecond,
+			ProbeTimeout:     10 * time.Millisecond,
+			SuspicionTimeout: 200 * time.Millisecond,
+			SyncInterval:     200 * time.Millisecond,
+		}
+		if i > 0 {
+			cfg.Seeds = []string{g.ids[0]}
+		}
+		g.events[id] = &recorder{}
+		cfg.Notify = g.events[id].notify
+		g.members[id] = New(cfg, memTransport{g.net, id})
+	}
+
+	g.net.mutex.Lock()
+	maps.Copy(g.net.members, g.members)
+	g.net.mutex.Unlock()
+
+	for _, m := range g.members {
+		m.Start()
+		t.Cleanup(m.Stop)
+	}
+	return g
+}
+
+// converged waits until every member in ids sees exactly want as live.
+func (g *testGroup) converged(t *testing.T, ids []string, want []string) {
+	t.Helper()
+
+	want = slices.Sorted(slices.Values(want))
+	deadline := time.Now().Add(5 * time.Second)
+	for _, id := range ids {
+		for {
+			got := g.members[id].LiveMembers()
+			if slices.Equal(got, want) {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("%s sees %v live, want %v", id, got, want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func (g *testGroup) member(id, addr string) Member {
+	for _, m := range g.members[id].Members() {
+		if m.Addr == addr {
+			return m
+		}
+	}
+	return Member{}
+}
+
+func without(ids []string, id string) []string {
+	return slices.DeleteFunc(slices.Clone(ids), func(s string) bool { return s == id })
+}
+
+func TestJoin(t *testing.T) {
+	g := startGroup(t, 5)
+	g.converged(t, g.ids, g.ids)
+
+	// Notify told every member about every other one.
+	for _, id := range g.ids {
+		for _, other := range without(g.ids, id) {
+			if !g.events[id].saw(other, Alive) {
+				t.Fatalf("%s was not notified that %s joined", id, other)
+			}
+		}
+	}
+}
+
+func TestFailureDetection(t *testing.T) {
+	g := startGroup(t, 4)
+	g.converged(t, g.ids, g.ids)
+
+	failed := g.ids[3]
+	rest := without(g.ids, failed)
+	g.net.isolate(failed)
+	g.converged(t, rest, rest)
+	for _, id := range rest {
+		if st := g.member(id, failed).State; st != Dead {
+			t.Fatalf("%s sees %s as %v, want dead", id, failed, st)
+		}
+		if !g.events[id].saw(failed, Dead) {
+			t.Fatalf("%s was not notified that %s died", id, failed)
+		}
+	}
+
+	// Once the partition heals the member refutes its death and rejoins
+	// under a higher incarnation.
+	g.net.heal()
+	g.converged(t, g.ids, g.ids)
+	if inc := g.member(g.ids[0], failed).Incarnation; inc == 0 {
+		t.Fatalf("%s rejoined without raising its incarnation", failed)
+	}
+}
+
+func TestSuspicionRefuted(t *testing.T) {
+	g := startGroup(t, 3)
+	g.converged(t, g.ids, g.ids)
+
+	// A false suspicion reaches the suspect, which refutes it before it
+	// can be declared dead.
+	target := g.ids[2]
+	g.members[g.ids[0]].merge([]Member{{Addr: target, State: Suspect}})
+
+	deadline := time.Now().Add(3 * time.Second)
+	for g.member(g.ids[0], target).State != Alive || g.member(g.ids[1], target).Incarnation == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("suspicion of %s not refuted: %+v", target, g.members[g.ids[0]].Members())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(300 * time.Millisecond)
+	for _, id := range g.ids {
+		if g.events[id].saw(target, Dead) {
+			t.Fatalf("%s declared %s dead despite the refutation", id, target)
+		}
+	}
+}
+
+func TestIndirectProbe(t *testing.T) {
+	g := startGroup(t, 4)
+	g.converged(t, g.ids, g.ids)
+
+	// With only the link between two members cut, pings relayed through
+	// the others keep both from being suspected.
+	a, b := g.ids[1], g.ids[2]
+	g.net.sever(a, b)
+	time.Sleep(500 * time.Millisecond)
+	for _, id := range g.ids {
+		if g.events[id].saw(a, Suspect) || g.events[id].saw(b, Suspect) {
+			t.Fatalf("%s suspected a member reachable through others", id)
+		}
+	}
+	g.converged(t, g.ids, g.ids)
+}
+
+func TestLeave(t *testing.T) {
+	g := startGroup(t, 3)
+	g.converged(t, g.ids, g.ids)
+
+	leaving := g.ids[1]
+	g.members[leaving].Leave(context.Background())
+	rest := without(g.ids, leaving)
+	for _, id := range rest {
+		if st := g.member(id, leaving).State; st != Left {
+			t.Fatalf("%s sees %s as %v right after it left, want left", id, leaving, st)
+		}
+	}
+	g.converged(t, rest, rest)
+}
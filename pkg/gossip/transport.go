@@ -0,0 +1,127 @@
+package gossip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Ping asks a member whether it is alive, carrying piggybacked updates.
+type Ping struct {
+	From    string   `json:"from"`
+	Updates []Member `json:"updates"`
+}
+
+// PingReq asks a member to ping Target on From's behalf.
+type PingReq struct {
+	From    string   `json:"from"`
+	Target  string   `json:"target"`
+	Updates []Member `json:"updates"`
+}
+
+// Ack answers a Ping, or reports whether a PingReq's target answered.
+type Ack struct {
+	OK      bool     `json:"ok"`
+	Updates []Member `json:"updates"`
+}
+
+// Sync carries a node's full member list, both ways.
+type Sync struct {
+	From    string   `json:"from"`
+	Members []Member `json:"members"`
+}
+
+// Transport carries the gossip messages from a node to other members,
+// addressed by their Addr.
+type Transport interface {
+	Ping(ctx context.Context, peer string, req *Ping) (*Ack, error)
+	PingReq(ctx context.Context, peer string, req *PingReq) (*Ack, error)
+	Sync(ctx context.Context, peer string, req *Sync) (*Sync, error)
+}
+
+// HTTPPrefix is the path under which Handler serves a node's messages.
+const HTTPPrefix = "/gossip/"
+
+// HTTPTransport sends messages as JSON over HTTP to members whose
+// addresses are their base URLs, for nodes served by Handler.
+type HTTPTransport struct {
+	Client *http.Client
+
+	// Token, if set, is sent as a bearer token.
+	Token string
+}
+
+func (t *HTTPTransport) Ping(ctx context.Context, peer string, req *Ping) (*Ack, error) {
+	resp := &Ack{}
+	return resp, t.call(ctx, peer, "ping", req, resp)
+}
+
+func (t *HTTPTransport) PingReq(ctx context.Context, peer string, req *PingReq) (*Ack, error) {
+	resp := &Ack{}
+	return resp, t.call(ctx, peer, "ping-req", req, resp)
+}
+
+func (t *HTTPTransport) Sync(ctx context.Context, peer string, req *Sync) (*Sync, error) {
+	resp := &Sync{}
+	return resp, t.call(ctx, peer, "sync", req, resp)
+}
+
+func (t *HTTPTransport) call(ctx context.Context, peer, method string, req, resp any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(peer, "/") + HTTPPrefix + method
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	if t.Token != "" {
+		r.Header.Set("Authorization", "Bearer "+t.Token)
+	}
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("gossip %s to %s: %s: %s", method, peer, res.Status, bytes.TrimSpace(msg))
+	}
+	return json.NewDecoder(res.Body).Decode(resp)
+}
+
+// Handler serves m's messages under HTTPPrefix, for members using
+// HTTPTransport.
+func Handler(m *Memberlist) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+HTTPPrefix+"ping", serveJSON(m.HandlePing))
+	mux.HandleFunc("POST "+HTTPPrefix+"ping-req", serveJSON(m.HandlePingReq))
+	mux.HandleFunc("POST "+HTTPPrefix+"sync", serveJSON(m.HandleSync))
+	return mux
+}
+
+func serveJSON[Req, Resp any](handle func(*Req) *Resp) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := new(Req)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(handle(req))
+	}
+}
@@ -0,0 +1,891 @@
+// Package raft implements the Raft consensus algorithm: a fixed group of
+// nodes agree on a log of commands, which each node applies in order to
+// its own copy of a StateMachine. A command is committed, and its result
+// returned by Propose, once a majority of the group stores it, so it
+// survives the loss of any minority of nodes.
+//
+// A node keeps its term, vote and log in Config.Dir, so that it can
+// restart and rejoin its group. Membership is fixed when the group starts.
+package raft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultHeartbeatInterval = 50 * time.Millisecond
+	DefaultElectionTimeout   = 300 * time.Millisecond
+	DefaultSnapshotThreshold = 1024
+
+	// maxAppendEntries caps the entries sent in one AppendEntries call.
+	maxAppendEntries = 256
+
+	// snapshotTimeout bounds an InstallSnapshot call, which carries the
+	// whole state machine.
+	snapshotTimeout = 30 * time.Second
+
+	tickInterval = 10 * time.Millisecond
+)
+
+var (
+	// ErrNotLeader is returned by Propose on a node that is not the leader;
+	// Node.Leader names the node to send the command to instead, if known.
+	ErrNotLeader = errors.New("raft: not the leader")
+
+	// ErrLeadershipLost is returned by Propose when the node stopped being
+	// leader before the command was committed. The command may or may not
+	// have been applied.
+	ErrLeadershipLost = errors.New("raft: leadership lost before the command committed")
+
+	ErrStopped = errors.New("raft: node stopped")
+)
+
+// StateMachine is what the log is applied to. Apply, Snapshot and Restore
+// are only ever called from one goroutine at a time.
+type StateMachine interface {
+	// Apply runs a committed command and returns its result. It must be
+	// deterministic: every node applies the same commands in the same order
+	// and must end up in the same state.
+	Apply(command []byte) any
+
+	// Snapshot serializes the state reached by the commands applied so far.
+	Snapshot() ([]byte, error)
+
+	// Restore replaces the state with a snapshot.
+	Restore(snapshot []byte) error
+}
+
+// Config describes a node and the group it belongs to.
+type Config struct {
+	// ID names the node; Transport uses IDs to address peers.
+	ID string
+
+	// Peers are the IDs of the other members of the group.
+	Peers []string
+
+	// HeartbeatInterval is how often the leader contacts idle followers.
+	HeartbeatInterval time.Duration
+
+	// ElectionTimeout is the minimum time a follower waits to hear from a
+	// leader before starting an election; each wait is randomized up to
+	// twice that.
+	ElectionTimeout time.Duration
+
+	// SnapshotThreshold is how many applied entries the log may hold
+	// before it is compacted into a snapshot.
+	SnapshotThreshold uint64
+
+	// Dir is where the node saves its term, vote and log before acting on
+	// them; a restarted node picks up from there. If Dir is empty they are
+	// kept in memory only, and the node must not rejoin its group after a
+	// restart: it could vote twice in a term, or lose entries it stored.
+	// A node that fails to save to Dir panics.
+	Dir string
+
+	Logger *slog.Logger
+}
+
+// State is the role a node currently plays in the group.
+type State int
+
+const (
+	Follower State = iota
+	Candidate
+	Leader
+)
+
+func (s State) String() string {
+	switch s {
+	case Follower:
+		return "follower"
+	case Candidate:
+		return "candidate"
+	case Leader:
+		return "leader"
+	default:
+		return "unknown"
+	}
+}
+
+// Entry is one log entry. A nil Command marks the no-op a new leader
+// appends to commit entries from earlier terms.
+type Entry struct {
+	Index   uint64 `json:"index"`
+	Term    uint64 `json:"term"`
+	Command []byte `json:"command"`
+}
+
+// Status is a point-in-time view of a node, as returned by Node.Status.
+type Status struct {
+	ID            string
+	State         State
+	Term          uint64
+	Leader        string
+	Peers         []string
+	LastIndex     uint64
+	CommitIndex   uint64
+	AppliedIndex  uint64
+	SnapshotIndex uint64
+}
+
+// Node is one member of a Raft group. Its RPC handlers (HandleRequestVote,
+// HandleAppendEntries and HandleInstallSnapshot) must be wired to whatever
+// serves the Transport the other members use.
+type Node struct {
+	cfg    Config
+	sm     StateMachine
+	tr     Transport
+	logger *slog.Logger
+
+	mutex    sync.Mutex
+	state    State
+	term     uint64
+	votedFor string
+	leader   string
+
+	// log[0] stands for the last entry covered by snapshot and carries only
+	// its index and term; the entries after it are held in full.
+	log      []Entry
+	snapshot []byte
+
+	commitIndex uint64
+	lastApplied uint64
+
+	// restorePending tells the applier to restore snapshot, installed by
+	// the leader or loaded from store, before applying anything else.
+	restorePending bool
+
+	// store is nil unless Config.Dir is set.
+	store *storage
+
+	// Leader only: per peer, the next entry to send and the last entry
+	// known to be stored there, and a channel to wake its replicator.
+	nextIndex  map[string]uint64
+	matchIndex map[string]uint64
+	triggers   map[string]chan struct{}
+
+	electionDeadline time.Time
+	waiters          map[uint64]waiter
+
+	applied *sync.Cond
+	stopped bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+type waiter struct {
+	term uint64
+	ch   chan result
+}
+
+type result struct {
+	value any
+	err   error
+}
+
+// NewNode returns a node that starts taking part in the group once Start
+// is called. With Config.Dir, it resumes from the state saved there.
+func NewNode(cfg Config, sm StateMachine, tr Transport) (*Node, error) {
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if cfg.ElectionTimeout <= 0 {
+		cfg.ElectionTimeout = DefaultElectionTimeout
+	}
+	if cfg.SnapshotThreshold == 0 {
+		cfg.SnapshotThreshold = DefaultSnapshotThreshold
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	cfg.Peers = slices.Clone(cfg.Peers)
+
+	n := &Node{
+		cfg:        cfg,
+		sm:         sm,
+		tr:         tr,
+		logger:     cfg.Logger.With("raft_id", cfg.ID),
+		log:        []Entry{{}},
+		nextIndex:  map[string]uint64{},
+		matchIndex: map[string]uint64{},
+		triggers:   map[string]chan struct{}{},
+		waiters:    map[uint64]waiter{},
+		done:       make(chan struct{}),
+	}
+	n.applied = sync.NewCond(&n.mutex)
+
+	if cfg.Dir != "" {
+		store, saved, err := openStorage(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		n.store = store
+		n.term, n.votedFor = saved.term, saved.vote
+		n.log, n.snapshot = saved.log, saved.snapshot
+		n.commitIndex = n.snapIndex()
+		n.restorePending = saved.snapshot != nil
+	}
+
+	return n, nil
+}
+
+// Start runs the node's timers and applier in the background.
+func (n *Node) Start() {
+	n.mutex.Lock()
+	n.resetElectionLocked()
+	n.mutex.Unlock()
+
+	n.wg.Add(2)
+	go n.tick()
+	go n.apply()
+}
+
+// Stop halts the node and fails pending proposals with ErrStopped.
+func (n *Node) Stop() {
+	n.mutex.Lock()
+	if n.stopped {
+		n.mutex.Unlock()
+		return
+	}
+	n.stopped = true
+	close(n.done)
+	for idx, w := range n.waiters {
+		w.ch <- result{err: ErrStopped}
+		delete(n.waiters, idx)
+	}
+	n.applied.Broadcast()
+	n.mutex.Unlock()
+
+	n.wg.Wait()
+	if n.store != nil {
+		_ = n.store.close()
+	}
+}
+
+// Propose appends command to the log and waits until it is committed and
+// applied on this node, returning what StateMachine.Apply returned. Only
+// the leader accepts proposals; others return ErrNotLeader.
+//
+// If ctx ends first, Propose returns its error but the command may still
+// be committed later.
+func (n *Node) Propose(ctx context.Context, command []byte) (any, error) {
+	if command == nil {
+		command = []byte{}
+	}
+
+	n.mutex.Lock()
+	if n.stopped {
+		n.mutex.Unlock()
+		return nil, ErrStopped
+	}
+	if n.state != Leader {
+		n.mutex.Unlock()
+		return nil, ErrNotLeader
+	}
+
+	idx := n.appendLocked(command)
+	ch := make(chan result, 1)
+	n.waiters[idx] = waiter{term: n.term, ch: ch}
+	n.advanceCommitLocked()
+	n.triggerLocked()
+	n.mutex.Unlock()
+
+	select {
+	case r := <-ch:
+		return r.value, r.err
+	case <-ctx.Done():
+		n.mutex.Lock()
+		delete(n.waiters, idx)
+		n.mutex.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// Leader returns the ID of the current leader, or "" if unknown.
+func (n *Node) Leader() string {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.leader
+}
+
+func (n *Node) Status() Status {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return Status{
+		ID:            n.cfg.ID,
+		State:         n.state,
+		Term:          n.term,
+		Leader:        n.leader,
+		Peers:         slices.Clone(n.cfg.Peers),
+		LastIndex:     n.lastIndex(),
+		CommitIndex:   n.commitIndex,
+		AppliedIndex:  n.lastApplied,
+		SnapshotIndex: n.snapIndex(),
+	}
+}
+
+func (n *Node) snapIndex() uint64 { return n.log[0].Index }
+func (n *Node) lastIndex() uint64 { return n.log[len(n.log)-1].Index }
+func (n *Node) lastTerm() uint64  { return n.log[len(n.log)-1].Term }
+
+// termAt returns the term of the entry at idx, if the log still covers it.
+func (n *Node) termAt(idx uint64) (uint64, bool) {
+	if idx < n.snapIndex() || idx > n.lastIndex() {
+		return 0, false
+	}
+	return n.log[idx-n.snapIndex()].Term, true
+}
+
+func (n *Node) quorum() int {
+	return (len(n.cfg.Peers)+1)/2 + 1
+}
+
+func (n *Node) resetElectionLocked() {
+	t := n.cfg.ElectionTimeout
+	n.electionDeadline = time.Now().Add(t + rand.N(t))
+}
+
+func (n *Node) appendLocked(command []byte) uint64 {
+	e := Entry{Index: n.lastIndex() + 1, Term: n.term, Command: command}
+	n.log = append(n.log, e)
+	n.saveEntriesLocked(e)
+	return e.Index
+}
+
+// saveStateLocked saves the term and vote, which must happen before the
+// node replies to or sends any RPC that depends on them.
+func (n *Node) saveStateLocked() {
+	if n.store == nil {
+		return
+	}
+	if err := n.store.saveState(n.term, n.votedFor); err != nil {
+		n.storageFailed(err)
+	}
+}
+
+// saveEntriesLocked saves entries just appended to the log.
+func (n *Node) saveEntriesLocked(entries ...Entry) {
+	if n.store == nil {
+		return
+	}
+	if err := n.store.append(entries); err != nil {
+		n.storageFailed(err)
+	}
+}
+
+// saveLogLocked saves the whole log and the snapshot, after either changed.
+func (n *Node) saveLogLocked() {
+	if n.store == nil {
+		return
+	}
+	if err := n.store.saveLog(n.snapshot, n.log); err != nil {
+		n.storageFailed(err)
+	}
+}
+
+// storageFailed stops the process: a node that cannot save its state may
+// not go on voting or storing entries, and has no way to leave the group.
+func (n *Node) storageFailed(err error) {
+	panic(fmt.Sprintf("raft: saving to %s: %v", n.cfg.Dir, err))
+}
+
+// triggerLocked wakes every replicator to send what is new.
+func (n *Node) triggerLocked() {
+	for _, ch := range n.triggers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// stepDownLocked makes the node a follower, adopting term if it is newer.
+func (n *Node) stepDownLocked(term uint64) {
+	if term > n.term {
+		n.term = term
+		n.votedFor = ""
+		n.leader = ""
+	}
+	if n.state == Leader {
+		n.logger.Info("raft stepping down", "term", n.term)
+	}
+	n.state = Follower
+	clear(n.triggers)
+}
+
+func (n *Node) tick() {
+	defer n.wg.Done()
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.done:
+			return
+		case <-ticker.C:
+		}
+
+		n.mutex.Lock()
+		if n.state != Leader && time.Now().After(n.electionDeadline) {
+			n.startElectionLocked()
+		}
+		n.mutex.Unlock()
+	}
+}
+
+func (n *Node) startElectionLocked() {
+	n.state = Candidate
+	n.term++
+	n.votedFor = n.cfg.ID
+	n.leader = ""
+	n.resetElectionLocked()
+	n.saveStateLocked()
+
+	term := n.term
+	req := &VoteRequest{Term: term, Candidate: n.cfg.ID, LastLogIndex: n.lastIndex(), LastLogTerm: n.lastTerm()}
+	votes := 1
+	if votes >= n.quorum() {
+		n.becomeLeaderLocked()
+		return
+	}
+
+	for _, peer := range n.cfg.Peers {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), n.cfg.ElectionTimeout)
+			resp, err := n.tr.RequestVote(ctx, peer, req)
+			cancel()
+			if err != nil {
+				return
+			}
+
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+
+			if resp.Term > n.term {
+				n.stepDownLocked(resp.Term)
+				return
+			}
+			if n.stopped || n.state != Candidate || n.term != term || !resp.Granted {
+				return
+			}
+
+			votes++
+			if votes >= n.quorum() {
+				n.becomeLeaderLocked()
+			}
+		}()
+	}
+}
+
+func (n *Node) becomeLeaderLocked() {
+	n.state = Leader
+	n.leader = n.cfg.ID
+	n.logger.Info("raft elected leader", "term", n.term)
+
+	next := n.lastIndex() + 1
+	for _, peer := range n.cfg.Peers {
+		n.nextIndex[peer] = next
+		n.matchIndex[peer] = 0
+	}
+
+	n.appendLocked(nil)
+	n.advanceCommitLocked()
+
+	for _, peer := range n.cfg.Peers {
+		trigger := make(chan struct{}, 1)
+		n.triggers[peer] = trigger
+		n.wg.Add(1)
+		go n.replicate(peer, n.term, trigger)
+	}
+}
+
+// advanceCommitLocked commits the newest entry of the current term stored
+// on a majority, and with it every entry before it.
+func (n *Node) advanceCommitLocked() {
+	for idx := n.lastIndex(); idx > n.commitIndex; idx-- {
+		if term, _ := n.termAt(idx); term != n.term {
+			return
+		}
+
+		count := 1
+		for _, peer := range n.cfg.Peers {
+			if n.matchIndex[peer] >= idx {
+				count++
+			}
+		}
+		if count >= n.quorum() {
+			n.commitIndex = idx
+			n.applied.Broadcast()
+			n.triggerLocked()
+			return
+		}
+	}
+}
+
+// replicate keeps peer's log in step with the leader's for as long as this
+// node leads in term.
+func (n *Node) replicate(peer string, term uint64, trigger chan struct{}) {
+	defer n.wg.Done()
+
+	for {
+		n.mutex.Lock()
+		if n.stopped || n.state != Leader || n.term != term {
+			n.mutex.Unlock()
+			return
+		}
+
+		var err error
+		if n.nextIndex[peer] <= n.snapIndex() {
+			err = n.sendSnapshotLocked(peer, term)
+		} else {
+			err = n.sendEntriesLocked(peer, term)
+		}
+		more := err == nil && n.state == Leader && n.nextIndex[peer] <= n.lastIndex()
+		n.mutex.Unlock()
+
+		if more {
+			continue
+		}
+
+		select {
+		case <-n.done:
+			return
+		case <-trigger:
+		case <-time.After(n.cfg.HeartbeatInterval):
+		}
+	}
+}
+
+// sendEntriesLocked sends peer the entries it is missing, or a heartbeat.
+// It releases n.mutex during the call.
+func (n *Node) sendEntriesLocked(peer string, term uint64) error {
+	next := n.nextIndex[peer]
+	prevTerm, _ := n.termAt(next - 1)
+	start := next - n.snapIndex()
+	end := min(uint64(len(n.log)), start+maxAppendEntries)
+	req := &AppendRequest{
+		Term:         term,
+		Leader:       n.cfg.ID,
+		PrevLogIndex: next - 1,
+		PrevLogTerm:  prevTerm,
+		Entries:      slices.Clone(n.log[start:end]),
+		LeaderCommit: n.commitIndex,
+	}
+
+	n.mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), n.cfg.ElectionTimeout)
+	resp, err := n.tr.AppendEntries(ctx, peer, req)
+	cancel()
+	n.mutex.Lock()
+
+	if err != nil {
+		return err
+	}
+	if resp.Term > n.term {
+		n.stepDownLocked(resp.Term)
+		return nil
+	}
+	if n.state != Leader || n.term != term {
+		return nil
+	}
+
+	if resp.Success {
+		n.matchIndex[peer] = max(n.matchIndex[peer], resp.MatchIndex)
+		n.nextIndex[peer] = n.matchIndex[peer] + 1
+		n.advanceCommitLocked()
+		return nil
+	}
+
+	// Back up to where the follower's log diverges.
+	back := next - 1
+	if resp.ConflictIndex > 0 {
+		back = min(back, resp.ConflictIndex)
+	}
+	n.nextIndex[peer] = max(back, 1)
+	return nil
+}
+
+// sendSnapshotLocked sends peer the snapshot, for a follower so far behind
+// that the entries it needs were compacted. It releases n.mutex during the
+// call.
+func (n *Node) sendSnapshotLocked(peer string, term uint64) error {
+	req := &SnapshotRequest{
+		Term:      term,
+		Leader:    n.cfg.ID,
+		LastIndex: n.snapIndex(),
+		LastTerm:  n.log[0].Term,
+		Data:      n.snapshot,
+	}
+
+	n.mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+	resp, err := n.tr.InstallSnapshot(ctx, peer, req)
+	cancel()
+	n.mutex.Lock()
+
+	if err != nil {
+		return err
+	}
+	if resp.Term > n.term {
+		n.stepDownLocked(resp.Term)
+		return nil
+	}
+	if n.state != Leader || n.term != term {
+		return nil
+	}
+
+	n.matchIndex[peer] = max(n.matchIndex[peer], req.LastIndex)
+	n.nextIndex[peer] = n.matchIndex[peer] + 1
+	n.advanceCommitLocked()
+	return nil
+}
+
+// apply hands committed entries to the state machine in order, and
+// compacts the log once enough of them have been applied.
+func (n *Node) apply() {
+	defer n.wg.Done()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for {
+		for !n.stopped && !n.restorePending && n.lastApplied >= n.commitIndex {
+			n.applied.Wait()
+		}
+		if n.stopped {
+			return
+		}
+
+		if n.restorePending {
+			n.restoreLocked()
+			continue
+		}
+
+		start, end := n.lastApplied+1-n.snapIndex(), n.commitIndex+1-n.snapIndex()
+		entries := slices.Clone(n.log[start:end])
+
+		n.mutex.Unlock()
+		for _, e := range entries {
+			var value any
+			if e.Command != nil {
+				value = n.sm.Apply(e.Command)
+			}
+
+			n.mutex.Lock()
+			interrupted := n.stopped || n.restorePending
+			if !interrupted {
+				n.lastApplied = e.Index
+				if w, ok := n.waiters[e.Index]; ok {
+					delete(n.waiters, e.Index)
+					if w.term == e.Term {
+						w.ch <- result{value: value}
+					} else {
+						w.ch <- result{err: ErrLeadershipLost}
+					}
+				}
+			}
+			n.mutex.Unlock()
+
+			if interrupted {
+				break
+			}
+		}
+		n.mutex.Lock()
+
+		if !n.stopped && !n.restorePending && n.lastApplied-n.snapIndex() >= n.cfg.SnapshotThreshold {
+			n.compactLocked()
+		}
+	}
+}
+
+// restoreLocked loads an installed snapshot into the state machine. Any
+// proposal it covers has an unknown outcome.
+func (n *Node) restoreLocked() {
+	data, idx := n.snapshot, n.snapIndex()
+	n.restorePending = false
+
+	n.mutex.Unlock()
+	err := n.sm.Restore(data)
+	n.mutex.Lock()
+
+	if err != nil {
+		n.logger.Error("raft snapshot restore failed", "index", idx, "err", err)
+	}
+	n.lastApplied = max(n.lastApplied, idx)
+	for i, w := range n.waiters {
+		if i <= idx {
+			w.ch <- result{err: ErrLeadershipLost}
+			delete(n.waiters, i)
+		}
+	}
+}
+
+// compactLocked snapshots the state machine and drops the log entries the
+// snapshot covers.
+func (n *Node) compactLocked() {
+	idx := n.lastApplied
+
+	n.mutex.Unlock()
+	data, err := n.sm.Snapshot()
+	n.mutex.Lock()
+
+	if err != nil {
+		n.logger.Error("raft snapshot failed", "index", idx, "err", err)
+		return
+	}
+	// An installed snapshot may have overtaken this one meanwhile.
+	if n.restorePending || idx <= n.snapIndex() {
+		return
+	}
+
+	term, _ := n.termAt(idx)
+	n.log = append([]Entry{{Index: idx, Term: term}}, n.log[idx-n.snapIndex()+1:]...)
+	n.snapshot = data
+	n.saveLogLocked()
+}
+
+func (n *Node) HandleRequestVote(req *VoteRequest) *VoteResponse {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	defer n.saveStateLocked()
+
+	// A stopped node may have closed its storage, so it takes no part.
+	if n.stopped {
+		return &VoteResponse{Term: n.term}
+	}
+	if req.Term > n.term {
+		n.stepDownLocked(req.Term)
+	}
+
+	resp := &VoteResponse{Term: n.term}
+	if req.Term < n.term {
+		return resp
+	}
+
+	upToDate := req.LastLogTerm > n.lastTerm() ||
+		(req.LastLogTerm == n.lastTerm() && req.LastLogIndex >= n.lastIndex())
+	if (n.votedFor == "" || n.votedFor == req.Candidate) && upToDate {
+		n.votedFor = req.Candidate
+		n.resetElectionLocked()
+		resp.Granted = true
+	}
+
+	return resp
+}
+
+func (n *Node) HandleAppendEntries(req *AppendRequest) *AppendResponse {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	defer n.saveStateLocked()
+
+	if n.stopped || req.Term < n.term {
+		return &AppendResponse{Term: n.term}
+	}
+	if req.Term > n.term || n.state != Follower {
+		n.stepDownLocked(req.Term)
+	}
+	n.leader = req.Leader
+	n.resetElectionLocked()
+
+	resp := &AppendResponse{Term: n.term}
+	prev, prevTerm, entries := req.PrevLogIndex, req.PrevLogTerm, req.Entries
+
+	// Entries up to the snapshot are committed and already compacted.
+	if snap := n.snapIndex(); prev < snap {
+		skip := snap - prev
+		if uint64(len(entries)) <= skip {
+			resp.Success = true
+			resp.MatchIndex = prev + uint64(len(entries))
+			return resp
+		}
+		entries = entries[skip:]
+		prev, prevTerm = snap, n.log[0].Term
+	}
+
+	if prev > n.lastIndex() {
+		resp.ConflictIndex = n.lastIndex() + 1
+		return resp
+	}
+	if term, _ := n.termAt(prev); term != prevTerm {
+		// Skip the whole conflicting term in one round trip.
+		idx := prev
+		for idx-1 > n.snapIndex() {
+			if t, _ := n.termAt(idx - 1); t != term {
+				break
+			}
+			idx--
+		}
+		resp.ConflictIndex = idx
+		return resp
+	}
+
+	for i, e := range entries {
+		if term, ok := n.termAt(e.Index); ok && term == e.Term {
+			continue
+		}
+		if e.Index <= n.lastIndex() {
+			n.log = n.log[:e.Index-n.snapIndex()]
+		}
+		n.log = append(n.log, entries[i:]...)
+		n.saveEntriesLocked(entries[i:]...)
+		break
+	}
+
+	match := prev + uint64(len(entries))
+	if commit := min(req.LeaderCommit, match); commit > n.commitIndex {
+		n.commitIndex = commit
+		n.applied.Broadcast()
+	}
+
+	resp.Success = true
+	resp.MatchIndex = match
+	return resp
+}
+
+func (n *Node) HandleInstallSnapshot(req *SnapshotRequest) *SnapshotResponse {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	defer n.saveStateLocked()
+
+	if n.stopped || req.Term < n.term {
+		return &SnapshotResponse{Term: n.term}
+	}
+	if req.Term > n.term || n.state != Follower {
+		n.stepDownLocked(req.Term)
+	}
+	n.leader = req.Leader
+	n.resetElectionLocked()
+
+	resp := &SnapshotResponse{Term: n.term}
+	if req.LastIndex <= n.commitIndex {
+		return resp
+	}
+
+	// Keep any entries past the snapshot that agree with it.
+	base := Entry{Index: req.LastIndex, Term: req.LastTerm}
+	if term, ok := n.termAt(req.LastIndex); ok && term == req.LastTerm {
+		n.log = append([]Entry{base}, n.log[req.LastIndex-n.snapIndex()+1:]...)
+	} else {
+		n.log = []Entry{base}
+	}
+
+	n.snapshot = req.Data
+	n.saveLogLocked()
+	n.commitIndex = req.LastIndex
+	n.restorePending = true
+	n.applied.Broadcast()
+	n.logger.Info("raft snapshot installed", "index", req.LastIndex, "leader", req.Leader)
+
+	return resp
+}
@@ -0,0 +1,457 @@
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// network connects in-process nodes and can cut links between them.
+type network struct {
+	mutex sync.Mutex
+	nodes map[string]*Node
+	cut   map[[2]string]bool
+}
+
+type memTransport struct {
+	net  *network
+	from string
+}
+
+var errUnreachable = errors.New("unreachable")
+
+func (nw *network) peer(from, to string) (*Node, error) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+
+	if nw.cut[[2]string{from, to}] {
+		return nil, errUnreachable
+	}
+	return nw.nodes[to], nil
+}
+
+// isolate cuts every link between ids and the other nodes.
+func (nw *network) isolate(ids ...string) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+
+	for a := range nw.nodes {
+		for _, b := range ids {
+			if a != b && !slices.Contains(ids, a) {
+				nw.cut[[2]string{a, b}] = true
+				nw.cut[[2]string{b, a}] = true
+			}
+		}
+	}
+}
+
+func (nw *network) heal() {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+
+	clear(nw.cut)
+}
+
+func (t memTransport) RequestVote(ctx context.Context, peer string, req *VoteRequest) (*VoteResponse, error) {
+	n, err := t.net.peer(t.from, peer)
+	if err != nil {
+		return nil, err
+	}
+	return n.HandleRequestVote(req), nil
+}
+
+func (t memTransport) AppendEntries(ctx context.Context, peer string, req *AppendRequest) (*AppendResponse, error) {
+	n, err := t.net.peer(t.from, peer)
+	if err != nil {
+		return nil, err
+	}
+	return n.HandleAppendEntries(req), nil
+}
+
+func (t memTransport) InstallSnapshot(ctx context.Context, peer string, req *SnapshotRequest) (*SnapshotResponse, error) {
+	n, err := t.net.peer(t.from, peer)
+	if err != nil {
+		return nil, err
+	}
+	return n.HandleInstallSnapshot(req), nil
+}
+
+// kv is a state machine applying "key=value" commands; Apply returns how
+// many commands it has applied.
+type kv struct {
+	mutex    sync.Mutex
+	data     map[string]string
+	count    int
+	restores int
+}
+
+func (m *kv) Apply(cmd []byte) any {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var k, v string
+	fmt.Sscanf(string(cmd), "%s = %s", &k, &v)
+	m.data[k] = v
+	m.count++
+	return m.count
+}
+
+func (m *kv) Snapshot() ([]byte, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return json.Marshal(struct {
+		Data  map[string]string
+		Count int
+	}{m.data, m.count})
+}
+
+func (m *kv) Restore(b []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var s struct {
+		Data  map[string]string
+		Count int
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	m.data, m.count = s.Data, s.Count
+	m.restores++
+	return nil
+}
+
+func (m *kv) get(k string) (string, int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.data[k], m.count
+}
+
+type testGroup struct {
+	net   *network
+	ids   []string
+	nodes map[string]*Node
+	sms   map[string]*kv
+}
+
+func startGroup(t *testing.T, n int, snapshotThreshold uint64) *testGroup {
+	t.Helper()
+
+	g := &testGroup{
+		net:   &network{nodes: map[string]*Node{}, cut: map[[2]string]bool{}},
+		nodes: map[string]*Node{},
+		sms:   map[string]*kv{},
+	}
+	for i := range n {
+		g.ids = append(g.ids, "n"+strconv.Itoa(i))
+	}
+
+	for _, id := range g.ids {
+		cfg := Config{
+			ID:                id,
+			Peers:             slices.DeleteFunc(slices.Clone(g.ids), func(p string) bool { return p == id }),
+			HeartbeatInterval: 10 * time.Millisecond,
+			ElectionTimeout:   60 * time.Millisecond,
+			SnapshotThreshold: snapshotThreshold,
+			Dir:               filepath.Join(t.TempDir(), id),
+		}
+		g.sms[id] = &kv{data: map[string]string{}}
+		node, err := NewNode(cfg, g.sms[id], memTransport{g.net, id})
+		if err != nil {
+			t.Fatalf("NewNode(%s): %v", id, err)
+		}
+		g.nodes[id] = node
+	}
+
+	g.net.mutex.Lock()
+	maps.Copy(g.net.nodes, g.nodes)
+	g.net.mutex.Unlock()
+
+	for _, node := range g.nodes {
+		node.Start()
+		t.Cleanup(node.Stop)
+	}
+	return g
+}
+
+// restart stops the node id and starts it again from its Dir, with an
+// empty state machine.
+func (g *testGroup) restart(t *testing.T, id string) {
+	t.Helper()
+
+	old := g.nodes[id]
+	old.Stop()
+
+	g.sms[id] = &kv{data: map[string]string{}}
+	node, err := NewNode(old.cfg, g.sms[id], memTransport{g.net, id})
+	if err != nil {
+		t.Fatalf("NewNode(%s) on restart: %v", id, err)
+	}
+	g.nodes[id] = node
+
+	g.net.mutex.Lock()
+	g.net.nodes[id] = node
+	g.net.mutex.Unlock()
+
+	node.Start()
+	t.Cleanup(node.Stop)
+}
+
+// leader waits for exactly one leader among ids and returns it.
+func (g *testGroup) leader(t *testing.T, ids ...string) string {
+	t.Helper()
+
+	if len(ids) == 0 {
+		ids = g.ids
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		var leaders []string
+		for _, id := range ids {
+			if g.nodes[id].Status().State == Leader {
+				leaders = append(leaders, id)
+			}
+		}
+		if len(leaders) == 1 {
+			return leaders[0]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("no single leader elected among %v", ids)
+	return ""
+}
+
+func (g *testGroup) propose(t *testing.T, id, cmd string) any {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	v, err := g.nodes[id].Propose(ctx, []byte(cmd))
+	if err != nil {
+		t.Fatalf("Propose(%q) on %s: %v", cmd, id, err)
+	}
+	return v
+}
+
+// converged waits until every node has applied count commands and holds
+// key=want.
+func (g *testGroup) converged(t *testing.T, key, want string, count int) {
+	t.Helper()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for _, id := range g.ids {
+		for {
+			v, n := g.sms[id].get(key)
+			if v == want && n == count {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("%s: %s=%q after %d commands, want %q after %d", id, key, v, n, want, count)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestElectionAndReplication(t *testing.T) {
+	g := startGroup(t, 3, 0)
+	leader := g.leader(t)
+
+	for i := range 10 {
+		if got := g.propose(t, leader, "k = "+strconv.Itoa(i)); got != i+1 {
+			t.Fatalf("Apply result mismatch: got=%v want=%d", got, i+1)
+		}
+	}
+	g.converged(t, "k", "9", 10)
+
+	for _, id := range g.ids {
+		if id == leader {
+			continue
+		}
+		if _, err := g.nodes[id].Propose(context.Background(), []byte("x = 1")); !errors.Is(err, ErrNotLeader) {
+			t.Fatalf("Propose on follower: got=%v want ErrNotLeader", err)
+		}
+		if got := g.nodes[id].Leader(); got != leader {
+			t.Fatalf("follower %s reports leader %q, want %q", id, got, leader)
+		}
+	}
+}
+
+func TestLeaderPartition(t *testing.T) {
+	g := startGroup(t, 3, 0)
+	old := g.leader(t)
+	g.propose(t, old, "k = before")
+	oldTerm := g.nodes[old].Status().Term
+
+	// Cut the leader off: it can no longer commit, and the majority side
+	// elects a new leader in a later term.
+	g.net.isolate(old)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	_, err := g.nodes[old].Propose(ctx, []byte("k = lost"))
+	cancel()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Propose on isolated leader: got=%v want DeadlineExceeded", err)
+	}
+
+	rest := slices.DeleteFunc(slices.Clone(g.ids), func(id string) bool { return id == old })
+	leader := g.leader(t, rest...)
+	if term := g.nodes[leader].Status().Term; term <= oldTerm {
+		t.Fatalf("new leader's term: got=%d want > %d", term, oldTerm)
+	}
+	g.propose(t, leader, "k = after")
+
+	// Once healed, the old leader steps down and its uncommitted entry is
+	// replaced by the majority's log.
+	g.net.heal()
+	g.leader(t)
+	g.converged(t, "k", "after", 2)
+}
+
+func TestFollowerPartitionCatchesUpFromSnapshot(t *testing.T) {
+	g := startGroup(t, 3, 5)
+	leader := g.leader(t)
+
+	var lagging string
+	for _, id := range g.ids {
+		if id != leader {
+			lagging = id
+			break
+		}
+	}
+
+	g.net.isolate(lagging)
+	for i := range 20 {
+		g.propose(t, leader, "k = "+strconv.Itoa(i))
+	}
+	if st := g.nodes[leader].Status(); st.SnapshotIndex == 0 {
+		t.Fatalf("leader should have compacted its log: %+v", st)
+	}
+
+	g.net.heal()
+	g.converged(t, "k", "19", 20)
+
+	g.sms[lagging].mutex.Lock()
+	restores := g.sms[lagging].restores
+	g.sms[lagging].mutex.Unlock()
+	if restores == 0 {
+		t.Fatal("lagging follower should have been sent a snapshot")
+	}
+}
+
+func TestMinorityCannotCommit(t *testing.T) {
+	g := startGroup(t, 3, 0)
+	leader := g.leader(t)
+
+	// With both followers cut off from everyone, no node has a majority.
+	var followers []string
+	for _, id := range g.ids {
+		if id != leader {
+			followers = append(followers, id)
+		}
+	}
+	g.net.isolate(followers[0])
+	g.net.isolate(followers[1])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := g.nodes[leader].Propose(ctx, []byte("k = v")); err == nil {
+		t.Fatal("a minority committed a command")
+	}
+	for _, id := range g.ids {
+		if v, _ := g.sms[id].get("k"); v != "" {
+			t.Fatalf("%s applied an uncommitted command", id)
+		}
+	}
+}
+
+func TestRestart(t *testing.T) {
+	g := startGroup(t, 3, 5)
+	leader := g.leader(t)
+	for i := range 8 {
+		g.propose(t, leader, "k = "+strconv.Itoa(i))
+	}
+	g.converged(t, "k", "7", 8)
+
+	terms := map[string]uint64{}
+	for _, id := range g.ids {
+		terms[id] = g.nodes[id].Status().Term
+	}
+
+	// Every node restarts from its snapshot and log, keeping its term
+	for _, id := range g.ids {
+		g.restart(t, id)
+		if st := g.nodes[id].Status(); st.Term < terms[id] || st.LastIndex < 8 {
+			t.Fatalf("%s after restart: %+v, want term >= %d", id, st, terms[id])
+		}
+	}
+	g.converged(t, "k", "7", 8)
+
+	leader = g.leader(t)
+	g.propose(t, leader, "k = after")
+	g.converged(t, "k", "after", 9)
+}
+
+func TestStorage(t *testing.T) {
+	dir := t.TempDir()
+
+	s, saved, err := openStorage(dir)
+	if err != nil {
+		t.Fatalf("openStorage: %v", err)
+	}
+	if saved.term != 0 || len(saved.log) != 1 || saved.snapshot != nil {
+		t.Fatalf("new storage: %+v", saved)
+	}
+
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	must(s.saveState(2, "n1"))
+	must(s.append([]Entry{{1, 1, nil}, {2, 1, []byte("a")}, {3, 1, []byte{}}}))
+	// A leader of term 2 replaces entry 3 on
+	must(s.append([]Entry{{3, 2, []byte("b")}, {4, 2, []byte("c")}}))
+	must(s.close())
+
+	// A record cut short by a crash is dropped
+	f, err := os.OpenFile(filepath.Join(dir, logFile), os.O_WRONLY|os.O_APPEND, 0)
+	must(err)
+	_, err = f.Write(encodeRecord(nil, Entry{5, 2, []byte("torn")})[:10])
+	must(err)
+	must(f.Close())
+
+	s, saved, err = openStorage(dir)
+	must(err)
+	want := []Entry{{}, {1, 1, nil}, {2, 1, []byte("a")}, {3, 2, []byte("b")}, {4, 2, []byte("c")}}
+	if saved.term != 2 || saved.vote != "n1" || !reflect.DeepEqual(saved.log, want) {
+		t.Fatalf("reopened: term=%d vote=%q log=%v", saved.term, saved.vote, saved.log)
+	}
+
+	// Compaction rewrites the log from the snapshot on
+	must(s.saveLog([]byte("snap"), []Entry{{3, 2, nil}, {4, 2, []byte("c")}}))
+	must(s.append([]Entry{{5, 3, []byte("d")}}))
+	must(s.close())
+
+	_, saved, err = openStorage(dir)
+	must(err)
+	want = []Entry{{3, 2, nil}, {4, 2, []byte("c")}, {5, 3, []byte("d")}}
+	if string(saved.snapshot) != "snap" || !reflect.DeepEqual(saved.log, want) {
+		t.Fatalf("after compaction: snapshot=%q log=%v", saved.snapshot, saved.log)
+	}
+}
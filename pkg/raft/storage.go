@@ -0,0 +1,278 @@
+package raft
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+const (
+	stateFile = "state"
+	logFile   = "log"
+
+	// recordHeader is the size of a log record's index, term and data
+	// length, and recordTrailer that of its checksum.
+	recordHeader  = 20
+	recordTrailer = 4
+
+	// nilData is the data length that marks a nil command.
+	nilData = math.MaxUint32
+)
+
+var errCorruptLog = errors.New("raft: corrupt log")
+
+// storage keeps what a node must not forget across a restart, in Config.Dir:
+// its term and vote, and its log. Every change is synced to disk before the
+// node acts on it, so that it never votes twice in a term or forgets an
+// entry it told the leader it stored.
+//
+// The state file holds the term and vote as JSON and is replaced whole.
+// The log file is a sequence of records, each an index, a term and some
+// data with a checksum. The first record is the snapshot the log starts
+// from, and the others are entries; an entry whose index the log already
+// holds replaces it and every entry after it. The file is rewritten when
+// the log is compacted.
+type storage struct {
+	dir  string
+	file *os.File
+
+	// term and vote are as last saved.
+	term uint64
+	vote string
+}
+
+// savedState is what openStorage found in the directory.
+type savedState struct {
+	term     uint64
+	vote     string
+	log      []Entry
+	snapshot []byte
+}
+
+type hardState struct {
+	Term uint64 `json:"term"`
+	Vote string `json:"vote"`
+}
+
+// openStorage opens the storage in dir, creating it if need be, and
+// returns what it holds. A log record cut short by a crash is dropped: it
+// was never synced, so the node never acted on it.
+func openStorage(dir string) (*storage, savedState, error) {
+	saved := savedState{log: []Entry{{}}}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, saved, err
+	}
+	s := &storage{dir: dir}
+
+	b, err := os.ReadFile(filepath.Join(dir, stateFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, saved, err
+	default:
+		var hs hardState
+		if err := json.Unmarshal(b, &hs); err != nil {
+			return nil, saved, fmt.Errorf("raft: corrupt state file: %w", err)
+		}
+		s.term, s.vote = hs.Term, hs.Vote
+		saved.term, saved.vote = hs.Term, hs.Vote
+	}
+
+	path := filepath.Join(dir, logFile)
+	b, err = os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, saved, err
+	}
+
+	off := 0
+	for first := true; off < len(b); first = false {
+		e, n, ok := decodeRecord(b[off:])
+		if !ok {
+			break
+		}
+		off += n
+
+		if first {
+			saved.log[0] = Entry{Index: e.Index, Term: e.Term}
+			saved.snapshot = e.Command
+			continue
+		}
+
+		snap, last := saved.log[0].Index, saved.log[len(saved.log)-1].Index
+		switch {
+		case e.Index <= snap:
+			continue
+		case e.Index > last+1:
+			return nil, saved, errCorruptLog
+		case e.Index <= last:
+			saved.log = saved.log[:e.Index-snap]
+		}
+		saved.log = append(saved.log, e)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, saved, err
+	}
+	if off == 0 {
+		// A new log starts with an empty snapshot record.
+		head := encodeRecord(nil, Entry{})
+		_, err = f.WriteAt(head, 0)
+		off = len(head)
+	}
+	if err == nil {
+		err = f.Truncate(int64(off))
+	}
+	if err == nil {
+		_, err = f.Seek(int64(off), io.SeekStart)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		f.Close()
+		return nil, saved, err
+	}
+	s.file = f
+
+	return s, saved, nil
+}
+
+// saveState saves term and vote, if they changed.
+func (s *storage) saveState(term uint64, vote string) error {
+	if term == s.term && vote == s.vote {
+		return nil
+	}
+
+	b, err := json.Marshal(hardState{Term: term, Vote: vote})
+	if err != nil {
+		return err
+	}
+	if err := s.replace(stateFile, b); err != nil {
+		return err
+	}
+
+	s.term, s.vote = term, vote
+	return nil
+}
+
+// append saves entries at the end of the log, replacing any the log holds
+// from the first one's index on.
+func (s *storage) append(entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var buf []byte
+	for _, e := range entries {
+		buf = encodeRecord(buf, e)
+	}
+	if _, err := s.file.Write(buf); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// saveLog replaces the log with snapshot and log, whose first entry is the
+// one snapshot covers up to.
+func (s *storage) saveLog(snapshot []byte, log []Entry) error {
+	buf := encodeRecord(nil, Entry{Index: log[0].Index, Term: log[0].Term, Command: snapshot})
+	for _, e := range log[1:] {
+		buf = encodeRecord(buf, e)
+	}
+	if err := s.replace(logFile, buf); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, logFile), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	s.file.Close()
+	s.file = f
+	return nil
+}
+
+// replace atomically sets the contents of the named file to b.
+func (s *storage) replace(name string, b []byte) error {
+	path := filepath.Join(s.dir, name)
+	tmp, err := os.CreateTemp(s.dir, name+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(b)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.Open(s.dir)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+func (s *storage) close() error {
+	return s.file.Close()
+}
+
+func encodeRecord(buf []byte, e Entry) []byte {
+	start := len(buf)
+	size := uint32(nilData)
+	if e.Command != nil {
+		size = uint32(len(e.Command))
+	}
+
+	buf = binary.LittleEndian.AppendUint64(buf, e.Index)
+	buf = binary.LittleEndian.AppendUint64(buf, e.Term)
+	buf = binary.LittleEndian.AppendUint32(buf, size)
+	buf = append(buf, e.Command...)
+	return binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf[start:]))
+}
+
+// decodeRecord decodes the record at the start of b and returns its size,
+// or false if b does not start with a whole, intact record.
+func decodeRecord(b []byte) (Entry, int, bool) {
+	if len(b) < recordHeader+recordTrailer {
+		return Entry{}, 0, false
+	}
+
+	e := Entry{
+		Index: binary.LittleEndian.Uint64(b[0:]),
+		Term:  binary.LittleEndian.Uint64(b[8:]),
+	}
+	size := binary.LittleEndian.Uint32(b[16:])
+	data := 0
+	if size != nilData {
+		data = int(size)
+	}
+
+	n := recordHeader + data + recordTrailer
+	if len(b) < n || crc32.ChecksumIEEE(b[:n-recordTrailer]) != binary.LittleEndian.Uint32(b[n-recordTrailer:]) {
+		return Entry{}, 0, false
+	}
+	if size != nilData {
+		e.Command = append([]byte{}, b[recordHeader:recordHeader+data]...)
+	}
+
+	return e, n, true
+}
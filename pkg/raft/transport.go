@@ -0,0 +1,144 @@
+package raft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type VoteRequest struct {
+	Term         uint64 `json:"term"`
+	Candidate    string `json:"candidate"`
+	LastLogIndex uint64 `json:"last_log_index"`
+	LastLogTerm  uint64 `json:"last_log_term"`
+}
+
+type VoteResponse struct {
+	Term    uint64 `json:"term"`
+	Granted bool   `json:"granted"`
+}
+
+type AppendRequest struct {
+	Term         uint64  `json:"term"`
+	Leader       string  `json:"leader"`
+	PrevLogIndex uint64  `json:"prev_log_index"`
+	PrevLogTerm  uint64  `json:"prev_log_term"`
+	Entries      []Entry `json:"entries"`
+	LeaderCommit uint64  `json:"leader_commit"`
+}
+
+type AppendResponse struct {
+	Term    uint64 `json:"term"`
+	Success bool   `json:"success"`
+
+	// MatchIndex is the last entry known to agree with the leader, on success.
+	MatchIndex uint64 `json:"match_index"`
+
+	// ConflictIndex is where the leader should resume sending, on failure.
+	ConflictIndex uint64 `json:"conflict_index"`
+}
+
+type SnapshotRequest struct {
+	Term      uint64 `json:"term"`
+	Leader    string `json:"leader"`
+	LastIndex uint64 `json:"last_index"`
+	LastTerm  uint64 `json:"last_term"`
+	Data      []byte `json:"data"`
+}
+
+type SnapshotResponse struct {
+	Term uint64 `json:"term"`
+}
+
+// Transport carries the Raft RPCs from a node to its peers, addressed by ID.
+type Transport interface {
+	RequestVote(ctx context.Context, peer string, req *VoteRequest) (*VoteResponse, error)
+	AppendEntries(ctx context.Context, peer string, req *AppendRequest) (*AppendResponse, error)
+	InstallSnapshot(ctx context.Context, peer string, req *SnapshotRequest) (*SnapshotResponse, error)
+}
+
+// HTTPPrefix is the path under which Handler serves a node's RPCs.
+const HTTPPrefix = "/raft/"
+
+// HTTPTransport sends RPCs as JSON over HTTP to peers whose IDs are their
+// base URLs, for nodes served by Handler.
+type HTTPTransport struct {
+	Client *http.Client
+
+	// Token, if set, is sent as a bearer token.
+	Token string
+}
+
+func (t *HTTPTransport) RequestVote(ctx context.Context, peer string, req *VoteRequest) (*VoteResponse, error) {
+	resp := &VoteResponse{}
+	return resp, t.call(ctx, peer, "vote", req, resp)
+}
+
+func (t *HTTPTransport) AppendEntries(ctx context.Context, peer string, req *AppendRequest) (*AppendResponse, error) {
+	resp := &AppendResponse{}
+	return resp, t.call(ctx, peer, "append", req, resp)
+}
+
+func (t *HTTPTransport) InstallSnapshot(ctx context.Context, peer string, req *SnapshotRequest) (*SnapshotResponse, error) {
+	resp := &SnapshotResponse{}
+	return resp, t.call(ctx, peer, "snapshot", req, resp)
+}
+
+func (t *HTTPTransport) call(ctx context.Context, peer, method string, req, resp any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(peer, "/") + HTTPPrefix + method
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	if t.Token != "" {
+		r.Header.Set("Authorization", "Bearer "+t.Token)
+	}
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("raft %s to %s: %s: %s", method, peer, res.Status, bytes.TrimSpace(msg))
+	}
+	return json.NewDecoder(res.Body).Decode(resp)
+}
+
+// Handler serves n's RPCs under HTTPPrefix, for peers using HTTPTransport.
+func Handler(n *Node) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+HTTPPrefix+"vote", serveJSON(n.HandleRequestVote))
+	mux.HandleFunc("POST "+HTTPPrefix+"append", serveJSON(n.HandleAppendEntries))
+	mux.HandleFunc("POST "+HTTPPrefix+"snapshot", serveJSON(n.HandleInstallSnapshot))
+	return mux
+}
+
+func serveJSON[Req, Resp any](handle func(*Req) *Resp) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := new(Req)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(handle(req))
+	}
+}
@@ -0,0 +1,404 @@
+package stache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/maphash"
+	"iter"
+	"time"
+)
+
+const (
+	// arenaSlabSize is the size of a slab. A larger record gets a slab of
+	// its own.
+	arenaSlabSize = 4 << 20
+
+	// arenaHeader is the size of the fixed part of a record: its length,
+	// expiry, sliding window and version, then the lengths of its key,
+	// content type, tags and value, which follow in that order.
+	arenaHeader = 4 + 8 + 8 + 8 + 4*4
+
+	// arenaSpareSlabs is how many emptied slabs are kept for reuse rather
+	// than left to the garbage collector.
+	arenaSpareSlabs = 1
+)
+
+// arenaStore is the store of EngineArena. Each entry is a record written
+// at the end of the current slab; an overwrite writes a new record and
+// leaves the old one dead, as does a delete. A slab that ends up mostly
+// dead has its live records moved to the current slab, and is emptied.
+type arenaStore struct {
+	seed maphash.Seed
+
+	// index maps the hash of a key to the location of its record: the
+	// slab in the upper 32 bits and the offset in the lower ones.
+	index map[uint64]uint64
+
+	// others holds the entries that cannot go in a slab: structured
+	// types, and keys whose hash is taken in index by another key.
+	others map[string]cacheEntry
+
+	slabs []slab
+
+	// cur is the slab being written, or -1.
+	cur int
+
+	// free holds the indices of emptied slabs, and spares the buffers
+	// kept from them.
+	free   []int
+	spares [][]byte
+}
+
+type slab struct {
+	buf        []byte
+	used, dead int
+}
+
+func newArenaStore() *arenaStore {
+	return &arenaStore{
+		seed:   maphash.MakeSeed(),
+		index:  map[uint64]uint64{},
+		others: map[string]cacheEntry{},
+		cur:    -1,
+	}
+}
+
+func (a *arenaStore) get(key string) (cacheEntry, bool) {
+	if e, ok := a.others[key]; ok {
+		return e, true
+	}
+
+	_, loc, ok := a.find(key)
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	e := decodeRecord(a.record(loc))
+	e.value = bytes.Clone(e.value)
+	return e, true
+}
+
+func (a *arenaStore) meta(key string) (cacheEntry, bool) {
+	if e, ok := a.others[key]; ok {
+		return e, true
+	}
+
+	_, loc, ok := a.find(key)
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	e := decodeRecord(a.record(loc))
+	e.value = nil
+	return e, true
+}
+
+func (a *arenaStore) view(key string) (cacheEntry, bool) {
+	if e, ok := a.others[key]; ok {
+		return e, true
+	}
+
+	_, loc, ok := a.find(key)
+	if !ok {
+		return cacheEntry{}, false
+	}
+	return decodeRecord(a.record(loc)), true
+}
+
+// find returns the hash of key and, if the record for key is in a slab,
+// its location.
+func (a *arenaStore) find(key string) (h uint64, loc uint64, ok bool) {
+	h = maphash.String(a.seed, key)
+	loc, ok = a.index[h]
+	if ok && string(recordKey(a.record(loc))) != key {
+		return h, 0, false
+	}
+	return h, loc, ok
+}
+
+func (a *arenaStore) set(key string, e cacheEntry) {
+	h, old, mine := a.find(key)
+	_, taken := a.index[h]
+
+	if e.object != nil || (taken && !mine) {
+		if mine {
+			delete(a.index, h)
+			a.kill(old)
+		}
+		a.others[key] = e
+		return
+	}
+
+	// The old record goes first, lest a compaction move it
+	if mine {
+		delete(a.index, h)
+		a.kill(old)
+	}
+	delete(a.others, key)
+	loc, rec := a.alloc(recordSize(key, e))
+	encodeRecord(rec, key, e)
+	a.index[h] = loc
+}
+
+func (a *arenaStore) touch(key string, expiresAt time.Time, sliding time.Duration) {
+	if e, ok := a.others[key]; ok {
+		e.expiresAt, e.sliding = expiresAt, sliding
+		a.others[key] = e
+		return
+	}
+
+	if _, loc, ok := a.find(key); ok {
+		rec := a.record(loc)
+		binary.LittleEndian.PutUint64(rec[4:], uint64(unixNano(expiresAt)))
+		binary.LittleEndian.PutUint64(rec[12:], uint64(sliding))
+	}
+}
+
+func (a *arenaStore) delete(key string) (cacheEntry, bool) {
+	if e, ok := a.others[key]; ok {
+		delete(a.others, key)
+		return e, true
+	}
+
+	h, loc, ok := a.find(key)
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	e := decodeRecord(a.record(loc))
+	e.value = bytes.Clone(e.value)
+	delete(a.index, h)
+	a.kill(loc)
+
+	return e, true
+}
+
+func (a *arenaStore) len() int {
+	return len(a.index) + len(a.others)
+}
+
+func (a *arenaStore) all() iter.Seq2[string, cacheEntry] {
+	return func(yield func(string, cacheEntry) bool) {
+		for k, e := range a.others {
+			if !yield(k, e) {
+				return
+			}
+		}
+
+		for i := range a.slabs {
+			for rec := range a.live(i) {
+				if !yield(string(recordKey(rec)), decodeRecord(rec)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (a *arenaStore) clear() {
+	*a = arenaStore{
+		seed:   a.seed,
+		index:  map[uint64]uint64{},
+		others: map[string]cacheEntry{},
+		cur:    -1,
+	}
+}
+
+// record returns the record at loc, which shares the slab's memory.
+func (a *arenaStore) record(loc uint64) []byte {
+	buf := a.slabs[loc>>32].buf[uint32(loc):]
+	return buf[:binary.LittleEndian.Uint32(buf)]
+}
+
+// live yields every live record in slab i.
+func (a *arenaStore) live(i int) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		used := a.slabs[i].used
+		for off := 0; off < used; {
+			loc := uint64(i)<<32 | uint64(off)
+			rec := a.record(loc)
+			off += len(rec)
+
+			if cur, ok := a.index[maphash.Bytes(a.seed, recordKey(rec))]; !ok || cur != loc {
+				continue
+			}
+			if !yield(rec) {
+				return
+			}
+		}
+	}
+}
+
+// alloc reserves n bytes for a record and returns their location.
+func (a *arenaStore) alloc(n int) (uint64, []byte) {
+	if n > arenaSlabSize {
+		i := a.newSlab(n)
+		a.slabs[i].used = n
+		return uint64(i) << 32, a.slabs[i].buf
+	}
+
+	if a.cur < 0 || len(a.slabs[a.cur].buf)-a.slabs[a.cur].used < n {
+		prev := a.cur
+		a.cur = a.newSlab(arenaSlabSize)
+		if prev >= 0 && 2*a.slabs[prev].dead > a.slabs[prev].used {
+			a.compact(prev)
+		}
+	}
+
+	s := &a.slabs[a.cur]
+	off := s.used
+	s.used += n
+	return uint64(a.cur)<<32 | uint64(off), s.buf[off : off+n]
+}
+
+// kill marks the record at loc dead. It must no longer be in index. A
+// slab left empty is released, and one left mostly dead is compacted.
+func (a *arenaStore) kill(loc uint64) {
+	i := int(loc >> 32)
+	s := &a.slabs[i]
+	s.dead += len(a.record(loc))
+
+	switch {
+	case s.dead == s.used && i == a.cur:
+		s.used, s.dead = 0, 0
+	case s.dead == s.used:
+		a.release(i)
+	case i != a.cur && 2*s.dead > s.used:
+		a.compact(i)
+	}
+}
+
+// compact moves the live records of slab i to the current slab, and
+// releases it.
+func (a *arenaStore) compact(i int) {
+	for rec := range a.live(i) {
+		to, buf := a.alloc(len(rec))
+		copy(buf, rec)
+		a.index[maphash.Bytes(a.seed, recordKey(rec))] = to
+	}
+	a.release(i)
+}
+
+func (a *arenaStore) newSlab(size int) int {
+	var buf []byte
+	if n := len(a.spares); size == arenaSlabSize && n > 0 {
+		buf, a.spares = a.spares[n-1], a.spares[:n-1]
+	} else {
+		buf = make([]byte, size)
+	}
+
+	if n := len(a.free); n > 0 {
+		i := a.free[n-1]
+		a.free = a.free[:n-1]
+		a.slabs[i] = slab{buf: buf}
+		return i
+	}
+
+	a.slabs = append(a.slabs, slab{buf: buf})
+	return len(a.slabs) - 1
+}
+
+func (a *arenaStore) release(i int) {
+	if buf := a.slabs[i].buf; len(buf) == arenaSlabSize && len(a.spares) < arenaSpareSlabs {
+		a.spares = append(a.spares, buf)
+	}
+	a.slabs[i] = slab{}
+	a.free = append(a.free, i)
+}
+
+func recordSize(key string, e cacheEntry) int {
+	n := arenaHeader + len(key) + len(e.contentType) + len(e.value)
+	for _, t := range e.tags {
+		n += 4 + len(t)
+	}
+	return n
+}
+
+// encodeRecord writes the record for key and e to rec, which must be
+// recordSize bytes long.
+func encodeRecord(rec []byte, key string, e cacheEntry) {
+	le := binary.LittleEndian
+
+	tagsLen := 0
+	for _, t := range e.tags {
+		tagsLen += 4 + len(t)
+	}
+
+	le.PutUint32(rec[0:], uint32(len(rec)))
+	le.PutUint64(rec[4:], uint64(unixNano(e.expiresAt)))
+	le.PutUint64(rec[12:], uint64(e.sliding))
+	le.PutUint64(rec[20:], e.version)
+	le.PutUint32(rec[28:], uint32(len(key)))
+	le.PutUint32(rec[32:], uint32(len(e.contentType)))
+	le.PutUint32(rec[36:], uint32(tagsLen))
+	le.PutUint32(rec[40:], uint32(len(e.value)))
+
+	off := arenaHeader
+	off += copy(rec[off:], key)
+	off += copy(rec[off:], e.contentType)
+	for _, t := range e.tags {
+		le.PutUint32(rec[off:], uint32(len(t)))
+		off += 4
+		off += copy(rec[off:], t)
+	}
+	copy(rec[off:], e.value)
+}
+
+// decodeRecord returns the entry rec holds, whose value shares rec's
+// memory.
+func decodeRecord(rec []byte) cacheEntry {
+	le := binary.LittleEndian
+
+	e := cacheEntry{
+		sliding: time.Duration(le.Uint64(rec[12:])),
+		version: le.Uint64(rec[20:]),
+	}
+	if ns := int64(le.Uint64(rec[4:])); ns != 0 {
+		e.expiresAt = time.Unix(0, ns)
+	}
+
+	off := arenaHeader + int(le.Uint32(rec[28:]))
+	ctLen := int(le.Uint32(rec[32:]))
+	e.contentType = contentTypeOf(rec[off : off+ctLen])
+	off += ctLen
+
+	tags := rec[off : off+int(le.Uint32(rec[36:]))]
+	for len(tags) > 0 {
+		n := 4 + int(le.Uint32(tags))
+		e.tags = append(e.tags, string(tags[4:n]))
+		tags = tags[n:]
+	}
+	off += int(le.Uint32(rec[36:]))
+
+	n := int(le.Uint32(rec[40:]))
+	e.value = rec[off : off+n : off+n]
+
+	return e
+}
+
+func recordKey(rec []byte) []byte {
+	return rec[arenaHeader : arenaHeader+binary.LittleEndian.Uint32(rec[28:])]
+}
+
+// contentTypeOf returns b as a ContentType, without allocating for the
+// common ones.
+func contentTypeOf(b []byte) ContentType {
+	switch string(b) {
+	case "":
+		return ""
+	case string(Text):
+		return Text
+	case string(JSON):
+		return JSON
+	default:
+		return ContentType(b)
+	}
+}
+
+// unixNano returns t in nanoseconds since the epoch, or 0 if t is zero.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
@@ -0,0 +1,201 @@
+package stache
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArenaEngine(t *testing.T) {
+	c := NewCacheWithOptions(Options{Engine: EngineArena})
+	want := NewCache()
+
+	// Enough data to fill several slabs, rewritten so that they compact
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range 20000 {
+		key := fmt.Sprint("k", r.IntN(2000))
+		switch r.IntN(10) {
+		case 0:
+			c.Delete(key)
+			want.Delete(key)
+		default:
+			value := []byte(strings.Repeat(string(rune('a'+i%26)), r.IntN(8<<10)))
+			meta := Meta{ContentType: Text, Tags: []string{fmt.Sprint("t", i%3)}}
+			_ = c.Set(key, value, meta)
+			_ = want.Set(key, value, meta)
+		}
+	}
+
+	if c.Len() != want.Len() {
+		t.Fatalf("Len() mismatch: got=%d want=%d", c.Len(), want.Len())
+	}
+	for _, it := range want.Items("") {
+		got, err := c.GetBytes(it.Key)
+		if err != nil || !slices.Equal(got, it.Value) {
+			t.Fatalf("GetBytes(%q) mismatch: len=%d want=%d err=%v", it.Key, len(got), len(it.Value), err)
+		}
+		if info, _ := c.GetEntry(it.Key); !slices.Equal(info.Tags, it.Meta.Tags) {
+			t.Fatalf("GetEntry(%q) tags mismatch: got=%v want=%v", it.Key, info.Tags, it.Meta.Tags)
+		}
+	}
+	if got, want := c.Stats().Bytes, want.Stats().Bytes; got != want {
+		t.Fatalf("Stats().Bytes mismatch: got=%d want=%d", got, want)
+	}
+
+	// Compaction keeps the slabs in use within twice the live data, plus
+	// the slab being written
+	a := c.index.(*arenaStore)
+	if slabs := len(a.slabs) - len(a.free); slabs*arenaSlabSize > 2*c.Stats().Bytes+2*arenaSlabSize {
+		t.Fatalf("slabs not compacted: %d slabs for %d bytes", slabs, c.Stats().Bytes)
+	}
+
+	if n := c.InvalidateTag("t0"); n != want.InvalidateTag("t0") || c.Len() != want.Len() {
+		t.Fatalf("InvalidateTag mismatch: removed=%d Len()=%d want=%d", n, c.Len(), want.Len())
+	}
+}
+
+func TestArenaEngineMetadata(t *testing.T) {
+	c := NewCacheWithOptions(Options{Engine: EngineArena})
+	expiresAt := time.Now().Add(time.Hour)
+
+	_ = c.Set("a", []byte(`{"n":1}`), Meta{ContentType: JSON, ExpiresAt: expiresAt, Tags: []string{"x", "y"}})
+	_ = c.Set("empty", nil, Meta{ContentType: "application/octet-stream"})
+	_ = c.Set("sliding", []byte("s"), Meta{TTL: time.Minute, Sliding: true})
+	_, _ = c.HSet("h", "f", []byte("v"))
+
+	info, err := c.GetEntry("a")
+	if err != nil || info.ContentType != JSON || !info.ExpiresAt.Equal(expiresAt) ||
+		!slices.Equal(info.Tags, []string{"x", "y"}) || info.Size != 7 {
+		t.Fatalf("GetEntry(a) mismatch: %+v err=%v", info, err)
+	}
+	var out map[string]int
+	if err := c.GetJSON("a", &out); err != nil || out["n"] != 1 {
+		t.Fatalf("GetJSON(a): got=%v err=%v", out, err)
+	}
+	if b, err := c.GetBytes("empty"); err != nil || b == nil || len(b) != 0 {
+		t.Fatalf("GetBytes(empty): got=%v err=%v", b, err)
+	}
+	if info, _ := c.GetEntry("empty"); info.ContentType != "application/octet-stream" {
+		t.Fatalf("custom content type mismatch: got=%q", info.ContentType)
+	}
+
+	// Expiry changes are written in place
+	if err := c.Persist("a"); err != nil {
+		t.Fatal(err)
+	}
+	if ttl, err := c.TTL("a"); err != nil || ttl != 0 {
+		t.Fatalf("TTL(a) after Persist: got=%v err=%v", ttl, err)
+	}
+	before, _ := c.GetEntry("sliding")
+	time.Sleep(10 * time.Millisecond)
+	_, _ = c.GetBytes("sliding")
+	if after, _ := c.GetEntry("sliding"); !after.ExpiresAt.After(before.ExpiresAt) || !after.Sliding {
+		t.Fatalf("sliding expiry not extended: before=%v after=%v", before.ExpiresAt, after.ExpiresAt)
+	}
+
+	// Structured types share the key space
+	if _, err := c.GetBytes("h"); err != ErrIncorrectType {
+		t.Fatalf("GetBytes(h): %v", err)
+	}
+	_ = c.SetString("h", "now a string", 0)
+	if s, err := c.GetString("h"); err != nil || s != "now a string" || c.Len() != 4 {
+		t.Fatalf("overwritten hash: got=%q err=%v Len()=%d", s, err, c.Len())
+	}
+	if _, err := c.HGet("a", "f"); err != ErrIncorrectType {
+		t.Fatalf("HGet(a): %v", err)
+	}
+
+	if n := c.Clear(); n != 4 || c.Len() != 0 {
+		t.Fatalf("Clear(): got=%d Len()=%d", n, c.Len())
+	}
+	if _, err := c.GetBytes("a"); err != ErrNotFound {
+		t.Fatalf("GetBytes(a) after Clear: %v", err)
+	}
+}
+
+// benchEngines runs fn with a cache of each engine.
+func benchEngines(b *testing.B, fn func(b *testing.B, c *Cache)) {
+	for _, engine := range []struct {
+		name   string
+		engine Engine
+	}{{"map", EngineMap}, {"arena", EngineArena}} {
+		b.Run(engine.name, func(b *testing.B) {
+			fn(b, NewCacheWithOptions(Options{Engine: engine.engine}))
+		})
+	}
+}
+
+// fill stores n entries of 128 bytes in c.
+func fill(c *Cache, n int) {
+	value := make([]byte, 128)
+	for i := range n {
+		_ = c.Set(fmt.Sprint("key:", i), value, Meta{ContentType: Text})
+	}
+}
+
+// BenchmarkEngineGC measures a full garbage collection with a million
+// entries in the cache, which is dominated by marking them with EngineMap,
+// and reports the heap objects each entry costs.
+func BenchmarkEngineGC(b *testing.B) {
+	benchEngines(b, func(b *testing.B, c *Cache) {
+		const n = 1 << 20
+
+		var empty, full runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&empty)
+		fill(c, n)
+		runtime.GC()
+		runtime.ReadMemStats(&full)
+
+		var before, after debug.GCStats
+		debug.ReadGCStats(&before)
+		for b.Loop() {
+			runtime.GC()
+		}
+		debug.ReadGCStats(&after)
+
+		pause := after.PauseTotal - before.PauseTotal
+		b.ReportMetric(float64(pause.Nanoseconds())/float64(b.N), "pause-ns/op")
+		b.ReportMetric(float64(full.HeapObjects-empty.HeapObjects)/n, "objects/entry")
+		runtime.KeepAlive(c)
+	})
+}
+
+func BenchmarkEngineSet(b *testing.B) {
+	benchEngines(b, func(b *testing.B, c *Cache) {
+		keys := make([]string, 1<<16)
+		for i := range keys {
+			keys[i] = fmt.Sprint("key:", i)
+		}
+		value := make([]byte, 128)
+
+		b.ReportAllocs()
+		i := 0
+		for b.Loop() {
+			_ = c.Set(keys[i%len(keys)], value, Meta{ContentType: Text})
+			i++
+		}
+	})
+}
+
+func BenchmarkEngineGet(b *testing.B) {
+	benchEngines(b, func(b *testing.B, c *Cache) {
+		fill(c, 1<<16)
+		keys := make([]string, 1<<16)
+		for i := range keys {
+			keys[i] = fmt.Sprint("key:", i)
+		}
+
+		b.ReportAllocs()
+		i := 0
+		for b.Loop() {
+			_, _ = c.GetBytes(keys[i%len(keys)])
+			i++
+		}
+	})
+}
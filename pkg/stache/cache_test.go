@@ -1,8 +1,15 @@
 package stache
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -12,7 +19,7 @@ func TestSetGetBytes(t *testing.T) {
 	c := NewCache()
 	val := []byte("dababy")
 
-	if err := c.Set("k1", val, Meta{time.Second, Text}); err != nil {
+	if err := c.Set("k1", val, Meta{TTL: time.Second, ContentType: Text}); err != nil {
 		t.Fatalf("Set error: %v", err)
 	}
 
@@ -108,6 +115,38 @@ func TestTTLExpiryOnAccess(t *testing.T) {
 	}
 }
 
+func TestKeepExpired(t *testing.T) {
+	c := NewCacheWithOptions(Options{KeepExpired: true})
+
+	start := time.Now()
+	_ = c.SetString("e", "deez", 30*time.Millisecond)
+	_ = c.SetString("f", "forever", 0)
+	var version uint64
+	_ = c.TxnAt(start, func(tx *Tx) error { version = tx.Version("e"); return nil })
+	time.Sleep(60 * time.Millisecond)
+
+	// Reads miss the expired entry but leave it in place
+	if _, err := c.GetString("e"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after expiry, got %v", err)
+	}
+	if _, err := c.GetEntry("e"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound from GetEntry after expiry, got %v", err)
+	}
+	if n := c.Len(); n != 2 {
+		t.Fatalf("expected the expired entry kept, Len()=%d", n)
+	}
+	_ = c.TxnAt(start, func(tx *Tx) error {
+		if v := tx.Version("e"); v != version {
+			t.Fatalf("version before expiry: got=%d want=%d", v, version)
+		}
+		return nil
+	})
+
+	if n := c.RemoveExpired(time.Now()); n != 1 || c.Len() != 1 {
+		t.Fatalf("RemoveExpired: removed=%d Len()=%d", n, c.Len())
+	}
+}
+
 func TestDelete(t *testing.T) {
 	c := NewCache()
 
@@ -184,3 +223,1003 @@ func TestConcurrencyBasic(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestSlidingExpiry(t *testing.T) {
+	c := NewCache()
+
+	if err := c.Set("s", []byte("sesh"), Meta{TTL: 50 * time.Millisecond, ContentType: Text, Sliding: true}); err != nil {
+		t.Fatalf("Set error: %v", err)
+	}
+
+	// Each read within the window should push the expiry forward
+	for range 4 {
+		time.Sleep(30 * time.Millisecond)
+		if _, err := c.GetString("s"); err != nil {
+			t.Fatalf("GetString error while sliding: %v", err)
+		}
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if _, err := c.GetString("s"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound once idle past TTL, got %v", err)
+	}
+}
+
+func TestTouchPersistTTL(t *testing.T) {
+	c := NewCache()
+
+	if err := c.SetString("t", "x", 30*time.Millisecond); err != nil {
+		t.Fatalf("SetString error: %v", err)
+	}
+
+	if err := c.Touch("t", time.Second); err != nil {
+		t.Fatalf("Touch error: %v", err)
+	}
+	ttl, err := c.TTL("t")
+	if err != nil {
+		t.Fatalf("TTL error: %v", err)
+	}
+	if ttl <= 500*time.Millisecond || ttl > time.Second {
+		t.Fatalf("TTL after Touch out of range: %v", ttl)
+	}
+
+	if err := c.Persist("t"); err != nil {
+		t.Fatalf("Persist error: %v", err)
+	}
+	if ttl, _ := c.TTL("t"); ttl != 0 {
+		t.Fatalf("expected TTL()=0 after Persist, got %v", ttl)
+	}
+
+	if err := c.Touch("missing", time.Second); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound touching missing key, got %v", err)
+	}
+}
+
+func TestSetExpiresAt(t *testing.T) {
+	c := NewCache()
+
+	at := time.Now().Add(40 * time.Millisecond)
+	if err := c.Set("abs", []byte("x"), Meta{ContentType: Text, ExpiresAt: at}); err != nil {
+		t.Fatalf("Set error: %v", err)
+	}
+
+	info, err := c.GetEntry("abs")
+	if err != nil {
+		t.Fatalf("GetEntry error: %v", err)
+	}
+	if !info.ExpiresAt.Equal(at) {
+		t.Fatalf("ExpiresAt mismatch: got=%v want=%v", info.ExpiresAt, at)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := c.GetBytes("abs"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after absolute expiry, got %v", err)
+	}
+
+	// An expiry in the past removes the key outright
+	_ = c.SetString("old", "x", 0)
+	if err := c.Set("old", []byte("y"), Meta{ContentType: Text, ExpiresAt: time.Now().Add(-time.Second)}); err != nil {
+		t.Fatalf("Set error: %v", err)
+	}
+	if n := c.Len(); n != 0 {
+		t.Fatalf("expected Len()=0 after past ExpiresAt, got %d", n)
+	}
+}
+
+func TestInvalidateTag(t *testing.T) {
+	c := NewCache()
+	_ = c.Set("user:42:profile", []byte("p"), Meta{ContentType: Text, Tags: []string{"user:42"}})
+	_ = c.Set("feed:7", []byte("f"), Meta{ContentType: Text, Tags: []string{"user:42", "feed", "user:42"}})
+	_ = c.Set("feed:8", []byte("g"), Meta{ContentType: Text, Tags: []string{"feed"}})
+
+	info, err := c.GetEntry("feed:7")
+	if err != nil {
+		t.Fatalf("GetEntry error: %v", err)
+	}
+	if !reflect.DeepEqual(info.Tags, []string{"feed", "user:42"}) {
+		t.Fatalf("tags not normalized: %v", info.Tags)
+	}
+
+	if n := c.InvalidateTag("user:42"); n != 2 {
+		t.Fatalf("InvalidateTag count: got=%d want=2", n)
+	}
+	if n := c.Len(); n != 1 {
+		t.Fatalf("Len() after invalidation: got=%d want=1", n)
+	}
+
+	// Overwriting an entry drops its old tags from the index
+	_ = c.SetString("feed:8", "h", 0)
+	if n := c.InvalidateTag("feed"); n != 0 {
+		t.Fatalf("expected stale tag to match nothing, got %d", n)
+	}
+}
+
+func TestHash(t *testing.T) {
+	c := NewCache()
+
+	created, err := c.HSet("u:1", "name", []byte("DaBaby"))
+	if err != nil || !created {
+		t.Fatalf("HSet new field: created=%v err=%v", created, err)
+	}
+	if created, _ = c.HSet("u:1", "name", []byte("Jonathan")); created {
+		t.Fatalf("HSet existing field reported created")
+	}
+
+	v, err := c.HGet("u:1", "name")
+	if err != nil || string(v) != "Jonathan" {
+		t.Fatalf("HGet mismatch: got=%q err=%v", v, err)
+	}
+
+	if n, err := c.HIncrBy("u:1", "visits", 5); err != nil || n != 5 {
+		t.Fatalf("HIncrBy: got=%d err=%v", n, err)
+	}
+	if _, err := c.HIncrBy("u:1", "name", 1); !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("expected ErrNotInteger, got %v", err)
+	}
+
+	// An overflowing add fails and leaves the field as it was
+	if _, err := c.HIncrBy("ctr", "n", math.MaxInt64); err != nil {
+		t.Fatalf("HIncrBy to MaxInt64: %v", err)
+	}
+	if _, err := c.HIncrBy("ctr", "n", 1); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("expected ErrOverflow above MaxInt64, got %v", err)
+	}
+	if _, err := c.HIncrBy("ctr", "n", math.MinInt64); err != nil {
+		t.Fatalf("HIncrBy back to -1: %v", err)
+	}
+	if _, err := c.HIncrBy("ctr", "n", math.MinInt64); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("expected ErrOverflow below MinInt64, got %v", err)
+	}
+	if v, _ := c.HGet("ctr", "n"); string(v) != "-1" {
+		t.Fatalf("HIncrBy overflow changed the field: got=%q want=-1", v)
+	}
+
+	all, err := c.HGetAll("u:1")
+	if err != nil {
+		t.Fatalf("HGetAll error: %v", err)
+	}
+	want := map[string][]byte{"name": []byte("Jonathan"), "visits": []byte("5")}
+	if !reflect.DeepEqual(all, want) {
+		t.Fatalf("HGetAll mismatch: got=%q want=%q", all, want)
+	}
+
+	if _, err := c.GetBytes("u:1"); !errors.Is(err, ErrIncorrectType) {
+		t.Fatalf("expected ErrIncorrectType from GetBytes on hash, got %v", err)
+	}
+
+	_ = c.SetString("s", "x", 0)
+	if _, err := c.HSet("s", "f", nil); !errors.Is(err, ErrIncorrectType) {
+		t.Fatalf("expected ErrIncorrectType from HSet on text, got %v", err)
+	}
+
+	if n, _ := c.HDel("u:1", "name", "visits", "nope"); n != 2 {
+		t.Fatalf("HDel count: got=%d want=2", n)
+	}
+	if _, err := c.HGetAll("u:1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected empty hash to be removed, got %v", err)
+	}
+}
+
+func TestHashConcurrentEntry(t *testing.T) {
+	c := NewCache()
+	const N = 1000
+
+	_, _ = c.HSet("h", "f", []byte("v"))
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// writer
+	wg.Go(func() {
+		defer close(done)
+		for i := range N {
+			_, _ = c.HSet("h", fmt.Sprint(i), []byte("v"))
+		}
+	})
+
+	// reader
+	wg.Go(func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_, _ = c.GetEntry("h")
+			}
+		}
+	})
+
+	wg.Wait()
+
+	info, err := c.GetEntry("h")
+	if err != nil || info.Size == 0 {
+		t.Fatalf("GetEntry after HSet: info=%+v err=%v", info, err)
+	}
+}
+
+func TestListPushPopRange(t *testing.T) {
+	c := NewCache()
+
+	if n, err := c.RPush("q", []byte("b"), []byte("c")); err != nil || n != 2 {
+		t.Fatalf("RPush: n=%d err=%v", n, err)
+	}
+	if n, _ := c.LPush("q", []byte("a")); n != 3 {
+		t.Fatalf("LPush length: got=%d want=3", n)
+	}
+
+	// Push enough to force the ring buffer to wrap and grow
+	for i := range 20 {
+		_, _ = c.RPush("q", []byte{byte('d' + i)})
+	}
+
+	vals, err := c.LRange("q", 0, 2)
+	if err != nil {
+		t.Fatalf("LRange error: %v", err)
+	}
+	if !reflect.DeepEqual(vals, [][]byte{[]byte("a"), []byte("b"), []byte("c")}) {
+		t.Fatalf("LRange mismatch: got=%q", vals)
+	}
+	if tail, _ := c.LRange("q", -1, -1); len(tail) != 1 || tail[0][0] != 'd'+19 {
+		t.Fatalf("LRange negative index mismatch: got=%q", tail)
+	}
+
+	if v, _ := c.LPop("q"); string(v) != "a" {
+		t.Fatalf("LPop mismatch: got=%q want=%q", v, "a")
+	}
+	if v, _ := c.RPop("q"); v[0] != 'd'+19 {
+		t.Fatalf("RPop mismatch: got=%q", v)
+	}
+	if n, _ := c.LLen("q"); n != 21 {
+		t.Fatalf("LLen mismatch: got=%d want=21", n)
+	}
+
+	if _, err := c.LPop("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound popping missing list, got %v", err)
+	}
+
+	// Pushing nothing creates nothing to pop
+	if n, err := c.LPush("empty"); err != nil || n != 0 {
+		t.Fatalf("LPush without values: n=%d err=%v", n, err)
+	}
+	if n, err := c.RPush("empty"); err != nil || n != 0 {
+		t.Fatalf("RPush without values: n=%d err=%v", n, err)
+	}
+	if _, err := c.LPop("empty"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound popping after an empty push, got %v", err)
+	}
+	if _, err := c.BPop(context.Background(), "empty", 10*time.Millisecond); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound from BPop after an empty push, got %v", err)
+	}
+	_ = c.SetString("s", "x", 0)
+	if _, err := c.RPush("s", []byte("x")); !errors.Is(err, ErrIncorrectType) {
+		t.Fatalf("expected ErrIncorrectType pushing onto text, got %v", err)
+	}
+}
+
+func TestBPop(t *testing.T) {
+	c := NewCache()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = c.RPush("jobs", []byte("job-1"))
+	}()
+
+	v, err := c.BPop(context.Background(), "jobs", time.Second)
+	if err != nil || string(v) != "job-1" {
+		t.Fatalf("BPop: got=%q err=%v", v, err)
+	}
+
+	// Empty list: the timeout elapses
+	if _, err := c.BPop(context.Background(), "jobs", 20*time.Millisecond); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after timeout, got %v", err)
+	}
+
+	// Cancelled context wins over a longer timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.BPop(ctx, "jobs", time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestBPopWaiters(t *testing.T) {
+	c := NewCache()
+
+	waiting := func() int {
+		c.mutex.RLock()
+		defer c.mutex.RUnlock()
+		if w, ok := c.waiters["jobs"]; ok {
+			return w.n
+		}
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	var got []byte
+	wg.Go(func() {
+		got, _ = c.BPop(context.Background(), "jobs", time.Second)
+	})
+	for waiting() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A waiter that gives up leaves the other one blocked
+	if _, err := c.BPop(context.Background(), "jobs", 20*time.Millisecond); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after timeout, got %v", err)
+	}
+	if n := waiting(); n != 1 {
+		t.Fatalf("waiters after timeout: got=%d want=1", n)
+	}
+
+	_, _ = c.RPush("jobs", []byte("job-1"))
+	wg.Wait()
+	if string(got) != "job-1" {
+		t.Fatalf("BPop: got=%q want=job-1", got)
+	}
+
+	// Nobody left waiting, whether woken, timed out or cancelled
+	_, _ = c.BPop(context.Background(), "jobs", 10*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _ = c.BPop(ctx, "jobs", 0)
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if len(c.waiters) != 0 {
+		t.Fatalf("expected no waiters left, got %d keys", len(c.waiters))
+	}
+}
+
+func TestSortedSet(t *testing.T) {
+	c := NewCache()
+
+	for i, m := range []string{"e", "d", "c", "b", "a"} {
+		if added, err := c.ZAdd("lb", float64(i*10), m); err != nil || !added {
+			t.Fatalf("ZAdd %s: added=%v err=%v", m, added, err)
+		}
+	}
+
+	// Re-scoring moves the member rather than adding it
+	if added, _ := c.ZAdd("lb", 25, "e"); added {
+		t.Fatalf("ZAdd existing member reported added")
+	}
+
+	if r, err := c.ZRank("lb", "e"); err != nil || r != 2 {
+		t.Fatalf("ZRank: got=%d err=%v want=2", r, err)
+	}
+	if s, _ := c.ZScore("lb", "e"); s != 25 {
+		t.Fatalf("ZScore: got=%v want=25", s)
+	}
+
+	got, err := c.ZRangeByScore("lb", 10, 30)
+	if err != nil {
+		t.Fatalf("ZRangeByScore error: %v", err)
+	}
+	want := []ZMember{{"d", 10}, {"c", 20}, {"e", 25}, {"b", 30}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ZRangeByScore mismatch: got=%v want=%v", got, want)
+	}
+
+	top, _ := c.ZRangeByRank("lb", -2, -1)
+	if !reflect.DeepEqual(top, []ZMember{{"b", 30}, {"a", 40}}) {
+		t.Fatalf("ZRangeByRank mismatch: got=%v", top)
+	}
+
+	if n, _ := c.ZRem("lb", "c", "nope"); n != 1 {
+		t.Fatalf("ZRem count: got=%d want=1", n)
+	}
+	if _, err := c.ZRank("lb", "c"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for removed member, got %v", err)
+	}
+
+	if _, err := c.ZAdd("lb", math.NaN(), "nan"); !errors.Is(err, ErrNotFloat) {
+		t.Fatalf("expected ErrNotFloat for NaN score, got %v", err)
+	}
+	if _, err := c.ZScore("lb", "nan"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected NaN member to be rejected, got %v", err)
+	}
+}
+
+func TestSortedSetRanksLarge(t *testing.T) {
+	c := NewCache()
+	const N = 1000
+
+	// Insert in a scrambled order, then check every rank matches its score
+	for i := range N {
+		j := (i * 7919) % N
+		_, _ = c.ZAdd("z", float64(j), fmt.Sprintf("m%04d", j))
+	}
+	for j := 0; j < N; j += 97 {
+		if r, _ := c.ZRank("z", fmt.Sprintf("m%04d", j)); r != j {
+			t.Fatalf("ZRank m%04d: got=%d want=%d", j, r, j)
+		}
+		if page, _ := c.ZRangeByRank("z", j, j); len(page) != 1 || page[0].Score != float64(j) {
+			t.Fatalf("ZRangeByRank %d: got=%v", j, page)
+		}
+	}
+
+	for j := 0; j < N; j += 2 {
+		_, _ = c.ZRem("z", fmt.Sprintf("m%04d", j))
+	}
+	if r, _ := c.ZRank("z", "m0999"); r != N/2-1 {
+		t.Fatalf("ZRank after removals: got=%d want=%d", r, N/2-1)
+	}
+}
+
+func TestSortedSetReads(t *testing.T) {
+	c := NewCacheWithOptions(Options{MaxEntries: 2})
+
+	_, _ = c.ZAdd("z", 1, "m")
+	_ = c.SetString("a", "A", 0)
+	_, _ = c.ZScore("z", "m") // a is now least recently used
+	_ = c.SetString("b", "B", 0)
+
+	if _, err := c.GetString("a"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("least recently used entry kept: err=%v", err)
+	}
+	if s, err := c.ZScore("z", "m"); err != nil || s != 1 {
+		t.Fatalf("ZScore after eviction: got=%v err=%v", s, err)
+	}
+
+	// An expired set is removed by the read that finds it
+	_ = c.Touch("z", 20*time.Millisecond)
+	time.Sleep(40 * time.Millisecond)
+	if page, err := c.ZRangeByRank("z", 0, -1); err != nil || len(page) != 0 {
+		t.Fatalf("ZRangeByRank on expired set: got=%v err=%v", page, err)
+	}
+	if n := c.Len(); n != 1 {
+		t.Fatalf("Len() after expired read: got=%d want=1", n)
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	c := NewCache()
+
+	// Adding nothing neither creates nor deletes the key
+	var events []Event
+	cancel := c.Subscribe(func(ev Event) { events = append(events, ev) })
+	if n, err := c.SAdd("none"); err != nil || n != 0 || len(events) != 0 {
+		t.Fatalf("SAdd without members: n=%d err=%v events=%v", n, err, events)
+	}
+	cancel()
+
+	if n, _ := c.SAdd("beta", "ann", "bob", "cat", "bob"); n != 3 {
+		t.Fatalf("SAdd count: got=%d want=3", n)
+	}
+	_, _ = c.SAdd("staff", "bob", "dan")
+
+	if ok, _ := c.SIsMember("beta", "cat"); !ok {
+		t.Fatalf("SIsMember: expected cat in beta")
+	}
+	if n, _ := c.SCard("beta"); n != 3 {
+		t.Fatalf("SCard: got=%d want=3", n)
+	}
+
+	cases := []struct {
+		op   SetOp
+		want []string
+	}{
+		{Union, []string{"ann", "bob", "cat", "dan"}},
+		{Inter, []string{}}, // "missing" is an empty set
+		{Diff, []string{"ann", "cat"}},
+	}
+	for _, tc := range cases {
+		got, err := c.SCombine(tc.op, "beta", "staff", "missing")
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("SCombine(%d): got=%v err=%v want=%v", tc.op, got, err, tc.want)
+		}
+	}
+
+	if n, err := c.SCombineStore("cohort", Diff, "beta", "staff"); err != nil || n != 2 {
+		t.Fatalf("SCombineStore: n=%d err=%v", n, err)
+	}
+	if m, _ := c.SMembers("cohort"); !reflect.DeepEqual(m, []string{"ann", "cat"}) {
+		t.Fatalf("SMembers of stored result: got=%v", m)
+	}
+
+	_ = c.SetString("s", "x", 0)
+	if _, err := c.SUnion("beta", "s"); !errors.Is(err, ErrIncorrectType) {
+		t.Fatalf("expected ErrIncorrectType combining with text, got %v", err)
+	}
+
+	if n, _ := c.SRem("staff", "bob", "dan"); n != 2 {
+		t.Fatalf("SRem count: got=%d want=2", n)
+	}
+	if _, err := c.GetEntry("staff"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected empty set to be removed, got %v", err)
+	}
+}
+
+func TestTxn(t *testing.T) {
+	c := NewCache()
+	_ = c.SetString("val", "v1", 0)
+	info, _ := c.GetEntry("val")
+
+	// Value and index change together
+	err := c.Txn(func(tx *Tx) error {
+		if err := tx.Expect("val", info.Version); err != nil {
+			return err
+		}
+		if err := tx.Expect("idx:v2", 0); err != nil {
+			return err
+		}
+
+		_ = tx.Set("val", []byte("v2"), Meta{ContentType: Text})
+		_ = tx.Set("idx:v2", []byte("val"), Meta{ContentType: Text})
+		tx.Delete("idx:v1")
+
+		// Reads see the transaction's own writes
+		if b, _, err := tx.Get("val"); err != nil || string(b) != "v2" {
+			t.Errorf("tx.Get inside txn: got=%q err=%v", b, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Txn error: %v", err)
+	}
+	if s, _ := c.GetString("idx:v2"); s != "val" {
+		t.Fatalf("index not written: got=%q", s)
+	}
+
+	after, _ := c.GetEntry("val")
+	if after.Version == info.Version {
+		t.Fatalf("expected version to change after commit")
+	}
+
+	// A stale precondition aborts everything
+	err = c.Txn(func(tx *Tx) error {
+		_ = tx.Set("other", []byte("x"), Meta{ContentType: Text})
+		return tx.Expect("val", info.Version)
+	})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	if _, err := c.GetString("other"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected aborted write to be discarded, got %v", err)
+	}
+}
+
+func TestSetManyDeleteMany(t *testing.T) {
+	c := NewCache()
+
+	errs := c.SetMany([]Item{
+		{Key: "a", Value: []byte("A"), Meta: Meta{ContentType: Text}},
+		{Key: "b", Value: []byte(`{"b":1}`), Meta: Meta{ContentType: JSON, TTL: time.Second}},
+		{Key: "h", Value: []byte("x"), Meta: Meta{ContentType: Hash}},
+	})
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("SetMany unexpected errors: %v", errs)
+	}
+	if !errors.Is(errs[2], ErrIncorrectType) {
+		t.Fatalf("expected ErrIncorrectType for structured content type, got %v", errs[2])
+	}
+	if n := c.Len(); n != 2 {
+		t.Fatalf("Len() after SetMany: got=%d want=2", n)
+	}
+
+	existed := c.DeleteMany("a", "missing", "b")
+	if !reflect.DeepEqual(existed, []bool{true, false, true}) {
+		t.Fatalf("DeleteMany mismatch: got=%v", existed)
+	}
+	if n := c.Len(); n != 0 {
+		t.Fatalf("Len() after DeleteMany: got=%d want=0", n)
+	}
+
+	_ = c.SetString("x", "1", 0)
+	_ = c.Set("y", []byte("2"), Meta{ContentType: Text, Tags: []string{"t"}})
+	if n := c.Clear(); n != 2 || c.Len() != 0 {
+		t.Fatalf("Clear mismatch: got=%d len=%d", n, c.Len())
+	}
+	if n := c.InvalidateTag("t"); n != 0 {
+		t.Fatalf("Clear left tag index behind: removed=%d", n)
+	}
+}
+
+func TestItemsRoundTrip(t *testing.T) {
+	src := NewCache()
+	exp := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	_ = src.Set("app:a", []byte("A"), Meta{ContentType: Text, ExpiresAt: exp, Tags: []string{"t"}})
+	_ = src.Set("app:s", []byte("S"), Meta{ContentType: Text, TTL: time.Minute, Sliding: true})
+	_ = src.SetString("other", "x", 0)
+	_, _ = src.HSet("app:h", "f", []byte("v"))
+
+	items := src.Items("app:")
+	if len(items) != 2 || items[0].Key != "app:a" || items[1].Key != "app:s" {
+		t.Fatalf("Items mismatch: got=%+v", items)
+	}
+
+	dst := NewCache()
+	for _, err := range dst.SetMany(items) {
+		if err != nil {
+			t.Fatalf("SetMany error: %v", err)
+		}
+	}
+
+	a, _ := dst.GetEntry("app:a")
+	if !a.ExpiresAt.Equal(exp) || !reflect.DeepEqual(a.Tags, []string{"t"}) {
+		t.Fatalf("restored entry lost metadata: %+v", a)
+	}
+
+	// The sliding window survives the round trip
+	_ = dst.Touch("app:s", time.Millisecond*50)
+	time.Sleep(30 * time.Millisecond)
+	_, _ = dst.GetString("app:s")
+	if ttl, _ := dst.TTL("app:s"); ttl < 40*time.Millisecond {
+		t.Fatalf("expected restored entry to keep sliding, TTL=%v", ttl)
+	}
+}
+
+func TestAddReplaceIncr(t *testing.T) {
+	c := NewCache()
+
+	if ok, _ := c.Replace("k", []byte("1"), Meta{ContentType: Text}); ok {
+		t.Fatalf("Replace stored a missing key")
+	}
+	if ok, _ := c.Add("k", []byte("1"), Meta{ContentType: Text, TTL: time.Minute}); !ok {
+		t.Fatalf("Add did not store a missing key")
+	}
+	if ok, _ := c.Add("k", []byte("2"), Meta{ContentType: Text}); ok {
+		t.Fatalf("Add overwrote an existing key")
+	}
+
+	n, err := c.Incr("k", 41)
+	if err != nil || n != 42 {
+		t.Fatalf("Incr mismatch: got=%d err=%v want=42", n, err)
+	}
+	if ttl, _ := c.TTL("k"); ttl <= 0 {
+		t.Fatalf("Incr dropped the expiry")
+	}
+
+	if n, _ := c.Incr("fresh", -1); n != -1 {
+		t.Fatalf("Incr on missing key: got=%d want=-1", n)
+	}
+
+	_ = c.SetString("s", "abc", 0)
+	if _, err := c.Incr("s", 1); !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("expected ErrNotInteger, got %v", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	c := NewCache()
+	_ = c.SetString("a", "abc", 0)
+	_ = c.SetString("b", "de", 0)
+
+	_, _ = c.GetString("a")
+	_, _ = c.GetBytes("b")
+	_, _ = c.GetBytes("missing")
+
+	want := Stats{Entries: 2, Bytes: 5, Hits: 2, Misses: 1}
+	if got := c.Stats(); got != want {
+		t.Fatalf("Stats mismatch: got=%+v want=%+v", got, want)
+	}
+}
+
+func TestSubscribeApply(t *testing.T) {
+	src, dst := NewCache(), NewCache()
+
+	var events []Event
+	cancel := src.Subscribe(func(ev Event) { events = append(events, ev) })
+
+	_ = src.Set("a", []byte("A"), Meta{ContentType: Text, Tags: []string{"t"}})
+	_ = src.Set("s", []byte("S"), Meta{ContentType: JSON, TTL: time.Minute, Sliding: true})
+	_ = src.Touch("s", time.Hour)
+	_ = src.SetString("gone", "x", 0)
+	src.Delete("gone")
+	_, _ = src.HSet("h", "f", []byte("v"))
+	_, _ = src.Incr("n", 3)
+
+	cancel()
+	_ = src.SetString("after", "x", 0)
+
+	ops := []Op{}
+	for i, ev := range events {
+		if ev.Seq != uint64(i+1) {
+			t.Fatalf("event %d Seq mismatch: got=%d", i, ev.Seq)
+		}
+		ops = append(ops, ev.Op)
+		if err := dst.Apply(ev); err != nil {
+			t.Fatalf("Apply(%v): %v", ev.Op, err)
+		}
+	}
+
+	want := []Op{OpSet, OpSet, OpExpire, OpSet, OpDelete, OpSet}
+	if !reflect.DeepEqual(ops, want) {
+		t.Fatalf("event ops mismatch: got=%v want=%v", ops, want)
+	}
+
+	if n := dst.Len(); n != 3 {
+		t.Fatalf("replayed Len() mismatch: got=%d want=3", n)
+	}
+	for _, key := range []string{"a", "s", "n"} {
+		a, _ := src.GetEntry(key)
+		b, err := dst.GetEntry(key)
+		if err != nil || a.ContentType != b.ContentType || !a.ExpiresAt.Equal(b.ExpiresAt) || !reflect.DeepEqual(a.Tags, b.Tags) {
+			t.Fatalf("replayed %q mismatch: src=%+v dst=%+v err=%v", key, a, b, err)
+		}
+	}
+	if _, err := dst.GetEntry("after"); err == nil {
+		t.Fatalf("event delivered after unsubscribing")
+	}
+
+	// The sliding window survives replay
+	ttl, _ := dst.TTL("s")
+	_, _ = dst.GetBytes("s")
+	if ttl2, _ := dst.TTL("s"); ttl2 < time.Hour-time.Second || ttl < 59*time.Minute {
+		t.Fatalf("replayed sliding entry mismatch: ttl=%v after read=%v", ttl, ttl2)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	src := NewCache()
+	_ = src.Set("a", []byte("A"), Meta{ContentType: JSON, TTL: time.Hour, Tags: []string{"t"}})
+	_ = src.Set("s", []byte("S"), Meta{TTL: time.Minute, Sliding: true})
+	_ = src.SetString("empty", "", 0)
+	_, _ = src.HSet("h", "f", []byte("v"))
+
+	var buf bytes.Buffer
+	if err := src.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+
+	dst := NewCache()
+	_ = dst.SetString("stale", "x", 0)
+	if err := dst.ReadSnapshot(&buf); err != nil {
+		t.Fatalf("ReadSnapshot: %v", err)
+	}
+
+	if n := dst.Len(); n != 3 {
+		t.Fatalf("restored Len() mismatch: got=%d want=3", n)
+	}
+	for _, key := range []string{"a", "s", "empty"} {
+		a, _ := src.GetEntry(key)
+		a.ExpiresAt = a.ExpiresAt.Round(0) // drop the monotonic reading
+		b, err := dst.GetEntry(key)
+		if err != nil || !reflect.DeepEqual(a, b) {
+			t.Fatalf("restored %q mismatch: src=%+v dst=%+v err=%v", key, a, b, err)
+		}
+	}
+	if got := dst.InvalidateTag("t"); got != 1 {
+		t.Fatalf("restored tag index mismatch: got=%d want=1", got)
+	}
+
+	// New writes continue the restored version sequence
+	_ = src.SetString("next", "x", 0)
+	_ = dst.SetString("next", "x", 0)
+	a, _ := src.GetEntry("next")
+	b, _ := dst.GetEntry("next")
+	if a.Version != b.Version {
+		t.Fatalf("version after restore mismatch: src=%d dst=%d", a.Version, b.Version)
+	}
+}
+
+func TestMaxEntries(t *testing.T) {
+	c := NewCacheWithOptions(Options{MaxEntries: 2})
+
+	_ = c.SetString("a", "A", 0)
+	_ = c.SetString("b", "B", 0)
+	_, _ = c.GetString("a") // b is now least recently used
+	_ = c.SetString("c", "C", 0)
+
+	if _, err := c.GetString("b"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("least recently used entry kept: err=%v", err)
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, err := c.GetString(key); err != nil {
+			t.Fatalf("GetString(%q) after eviction: %v", key, err)
+		}
+	}
+
+	// Overwriting a key does not evict
+	_ = c.SetString("a", "A2", 0)
+	if n := c.Len(); n != 2 {
+		t.Fatalf("Len() after overwrite: got=%d want=2", n)
+	}
+	if st := c.Stats(); st.Evictions != 1 {
+		t.Fatalf("Evictions mismatch: got=%d want=1", st.Evictions)
+	}
+
+	// Deleted keys leave the bound
+	c.Delete("a")
+	_ = c.SetString("d", "D", 0)
+	if n := c.Len(); n != 2 || c.Stats().Evictions != 1 {
+		t.Fatalf("after delete: Len()=%d Evictions=%d", n, c.Stats().Evictions)
+	}
+}
+
+func TestDiskTier(t *testing.T) {
+	disk, err := OpenDiskTier(filepath.Join(t.TempDir(), "tier"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer disk.Close()
+
+	c := NewCacheWithOptions(Options{MaxEntries: 2, Disk: disk})
+	_ = c.Set("a", []byte("A"), Meta{ContentType: Text, Tags: []string{"t"}})
+	_ = c.SetString("b", "B", 0)
+	_ = c.SetString("short", "S", 50*time.Millisecond)
+	_ = c.SetString("c", "C", 0)
+
+	// a and b spilled, in that order
+	tier := func(key string) Tier {
+		t.Helper()
+		info, err := c.GetEntry(key)
+		if err != nil {
+			t.Fatalf("GetEntry(%q): %v", key, err)
+		}
+		return info.Tier
+	}
+	if tier("a") != TierDisk || tier("b") != TierDisk || tier("c") != TierMemory {
+		t.Fatalf("tiers mismatch: a=%v b=%v c=%v", tier("a"), tier("b"), tier("c"))
+	}
+	if info, _ := c.GetEntry("a"); info.Size != 1 || !slices.Equal(info.Tags, []string{"t"}) {
+		t.Fatalf("spilled entry info mismatch: %+v", info)
+	}
+	if st := c.Stats(); st.Entries != 4 || st.DiskEntries != 2 || st.Evictions != 0 || c.Len() != 4 {
+		t.Fatalf("Stats() mismatch: %+v Len()=%d", st, c.Len())
+	}
+
+	// A read promotes the entry, spilling the least recently used one
+	if v, err := c.GetString("a"); err != nil || v != "A" {
+		t.Fatalf("GetString(a) from disk: got=%q err=%v", v, err)
+	}
+	if tier("a") != TierMemory || tier("short") != TierDisk {
+		t.Fatalf("after promotion: a=%v short=%v", tier("a"), tier("short"))
+	}
+
+	// Expiry, Delete and tags reach the disk tier
+	time.Sleep(100 * time.Millisecond)
+	if _, err := c.GetString("short"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expired spilled entry: err=%v", err)
+	}
+	if _, ok := c.Delete("b"); !ok {
+		t.Fatal("Delete(b) of a spilled entry reported missing")
+	}
+	if _, err := c.GetEntry("b"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("spilled entry kept after Delete: err=%v", err)
+	}
+	_ = c.SetString("d", "D", 0)
+	_ = c.SetString("e", "E", 0)
+	if tier("a") != TierDisk {
+		t.Fatal("a not spilled")
+	}
+	if n := c.InvalidateTag("t"); n != 1 {
+		t.Fatalf("InvalidateTag of a spilled entry: got=%d want=1", n)
+	}
+
+	// Writes to a spilled key replace it
+	if _, err := c.Incr("c", 1); !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("Incr of a spilled entry: %v", err)
+	}
+	_ = c.SetString("c", "C2", 0)
+	if v, _ := c.GetString("c"); v != "C2" || c.Len() != 3 {
+		t.Fatalf("after overwrite: got=%q Len()=%d", v, c.Len())
+	}
+
+	var buf bytes.Buffer
+	if err := c.WriteSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	restored := NewCache()
+	if err := restored.ReadSnapshot(&buf); err != nil || restored.Len() != 3 {
+		t.Fatalf("snapshot of both tiers: Len()=%d err=%v", restored.Len(), err)
+	}
+
+	if n := c.Clear(); n != 3 || c.Stats().DiskEntries != 0 {
+		t.Fatalf("Clear() across tiers: got=%d", n)
+	}
+}
+
+func TestDiskTierBound(t *testing.T) {
+	// Each record holds 13 bytes besides its value
+	disk, err := OpenDiskTier(filepath.Join(t.TempDir(), "tier"), 2*(13+10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer disk.Close()
+
+	c := NewCacheWithOptions(Options{MaxEntries: 1, Disk: disk})
+	for _, key := range []string{"a", "b", "c", "d"} {
+		_ = c.SetString(key, strings.Repeat(key, 10), 0)
+	}
+
+	// a, spilled first, was dropped to make room for c
+	if _, err := c.GetString("a"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("oldest spilled entry kept: err=%v", err)
+	}
+	if st := c.Stats(); st.Evictions != 1 || st.DiskEntries != 2 {
+		t.Fatalf("Stats() mismatch: %+v", st)
+	}
+
+	// Values larger than the tier are dropped rather than spilled
+	_ = c.SetString("big", strings.Repeat("x", 100), 0)
+	_ = c.SetString("e", "e", 0)
+	if _, err := c.GetEntry("big"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("oversized entry spilled: err=%v", err)
+	}
+}
+
+func TestGetWithInfo(t *testing.T) {
+	c := NewCache()
+	_ = c.Set("k", []byte("v1"), Meta{ContentType: Text, TTL: time.Minute, Sliding: true, Tags: []string{"t"}})
+	_, _ = c.HSet("h", "f", []byte("v"))
+
+	before, _ := c.GetEntry("k")
+	time.Sleep(10 * time.Millisecond)
+	b, info, err := c.GetWithInfo("k")
+	if err != nil || string(b) != "v1" || info.ContentType != Text || info.Version != before.Version ||
+		!slices.Equal(info.Tags, []string{"t"}) {
+		t.Fatalf("GetWithInfo(k): got=%q %+v err=%v", b, info, err)
+	}
+	if !info.ExpiresAt.After(before.ExpiresAt) {
+		t.Fatalf("GetWithInfo did not slide the expiry: before=%v after=%v", before.ExpiresAt, info.ExpiresAt)
+	}
+
+	// The copy is the caller's
+	b[0] = 'x'
+	if s, _ := c.GetString("k"); s != "v1" {
+		t.Fatalf("value changed through the returned slice: got=%q", s)
+	}
+
+	if _, _, err := c.GetWithInfo("h"); !errors.Is(err, ErrIncorrectType) {
+		t.Fatalf("GetWithInfo(h): %v", err)
+	}
+	if _, _, err := c.GetWithInfo("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetWithInfo(missing): %v", err)
+	}
+	if st := c.Stats(); st.Hits != 3 || st.Misses != 1 {
+		t.Fatalf("Stats() mismatch: hits=%d misses=%d", st.Hits, st.Misses)
+	}
+}
+
+func TestView(t *testing.T) {
+	for _, engine := range []Engine{EngineMap, EngineArena} {
+		c := NewCacheWithOptions(Options{Engine: engine})
+		_ = c.SetString("k", "value", 0)
+		_, _ = c.HSet("h", "f", []byte("v"))
+
+		var n int
+		if err := c.View("k", func(v []byte) error { n = len(v); return nil }); err != nil || n != 5 {
+			t.Fatalf("View(k) with engine %d: n=%d err=%v", engine, n, err)
+		}
+
+		errStop := errors.New("stop")
+		if err := c.View("k", func([]byte) error { return errStop }); err != errStop {
+			t.Fatalf("View did not return fn's error: %v", err)
+		}
+		if err := c.View("h", func([]byte) error { return nil }); !errors.Is(err, ErrIncorrectType) {
+			t.Fatalf("View(h): %v", err)
+		}
+		if err := c.View("missing", func([]byte) error { return nil }); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("View(missing): %v", err)
+		}
+	}
+}
+
+// BenchmarkRead compares reading a value and its metadata with two calls,
+// in one with GetWithInfo, and without a copy with View.
+func BenchmarkRead(b *testing.B) {
+	benchEngines(b, func(b *testing.B, c *Cache) {
+		_ = c.Set("k", make([]byte, 1024), Meta{ContentType: Text})
+
+		b.Run("GetBytes+GetEntry", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = c.GetBytes("k")
+				_, _ = c.GetEntry("k")
+			}
+		})
+		b.Run("GetWithInfo", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _, _ = c.GetWithInfo("k")
+			}
+		})
+		b.Run("View", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = c.View("k", func([]byte) error { return nil })
+			}
+		})
+	})
+}
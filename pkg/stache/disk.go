@@ -0,0 +1,308 @@
+package stache
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// diskHeader is the size of a record's key and value lengths, and
+	// diskTrailer that of its checksum.
+	diskHeader  = 8
+	diskTrailer = 4
+
+	// diskMinCompact is the least amount of dead data that makes the log
+	// worth rewriting.
+	diskMinCompact = 1 << 20
+)
+
+var (
+	errDiskCorrupt  = errors.New("cache: corrupt disk record")
+	errDiskTooLarge = errors.New("cache: entry larger than the disk tier")
+)
+
+// DiskTier is a local file holding the entries a bounded Cache evicts from
+// memory; see Options.Disk. It is a log: every spilled entry is appended
+// as a record, and an in-memory index maps each key to its record and
+// metadata, so only values are read back from the file. Records left dead
+// by promotions and removals are reclaimed by rewriting the log once they
+// outweigh the live ones.
+//
+// The tier is scratch space for one Cache: its file is truncated when it
+// is opened, and removed by Close.
+type DiskTier struct {
+	mutex    sync.Mutex
+	path     string
+	file     *os.File
+	maxBytes int64
+
+	// end is the size of the log, and live the bytes of it that hold
+	// indexed records.
+	end, live int64
+
+	index map[string]*diskEntry
+
+	// order holds the keys in the order they were written, oldest first.
+	order *list.List
+}
+
+type diskEntry struct {
+	// meta is the entry without its value.
+	meta cacheEntry
+	size int
+
+	// off and n locate the record in the log.
+	off  int64
+	n    int64
+	elem *list.Element
+}
+
+// diskDrop is an entry the tier let go of to stay within its bound.
+type diskDrop struct {
+	key  string
+	meta cacheEntry
+}
+
+// OpenDiskTier creates, or truncates, the file at path for use as a disk
+// tier. If maxBytes is positive, the records of the oldest spilled entries
+// are dropped to keep the live part of the log within it.
+func OpenDiskTier(path string, maxBytes int64) (*DiskTier, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiskTier{
+		path:     path,
+		file:     f,
+		maxBytes: maxBytes,
+		index:    map[string]*diskEntry{},
+		order:    list.New(),
+	}, nil
+}
+
+// Close closes and removes the file. The Cache using the tier must not be
+// used afterwards.
+func (t *DiskTier) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return errors.Join(t.file.Close(), os.Remove(t.path))
+}
+
+// put appends the record for e under key, and returns the entries dropped
+// to make room for it.
+func (t *DiskTier) put(key string, e cacheEntry) ([]diskDrop, error) {
+	n := int64(diskHeader + len(key) + len(e.value) + diskTrailer)
+	if t.maxBytes > 0 && n > t.maxBytes {
+		return nil, errDiskTooLarge
+	}
+
+	buf := make([]byte, n)
+	binary.LittleEndian.PutUint32(buf[0:], uint32(len(key)))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(len(e.value)))
+	copy(buf[diskHeader:], key)
+	copy(buf[diskHeader+len(key):], e.value)
+	binary.LittleEndian.PutUint32(buf[n-diskTrailer:], crc32.ChecksumIEEE(buf[:n-diskTrailer]))
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, err := t.file.WriteAt(buf, t.end); err != nil {
+		return nil, err
+	}
+
+	t.removeLocked(key)
+	size := len(e.value)
+	e.value = nil
+	t.index[key] = &diskEntry{meta: e, size: size, off: t.end, n: n, elem: t.order.PushBack(key)}
+	t.end += n
+	t.live += n
+
+	var dropped []diskDrop
+	for t.maxBytes > 0 && t.live > t.maxBytes {
+		oldest := t.order.Front().Value.(string)
+		d, _ := t.removeLocked(oldest)
+		dropped = append(dropped, diskDrop{oldest, d.meta})
+	}
+
+	if dead := t.end - t.live; dead > max(t.live, diskMinCompact) {
+		// A failed rewrite leaves the log as it was
+		_ = t.compactLocked()
+	}
+
+	return dropped, nil
+}
+
+// take returns the entry for key, value included, and removes it from the
+// tier. The entry is removed even if its record cannot be read back.
+func (t *DiskTier) take(key string) (cacheEntry, bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	d, ok := t.removeLocked(key)
+	if !ok {
+		return cacheEntry{}, false, nil
+	}
+
+	e := d.meta
+	value, err := t.readLocked(key, d)
+	e.value = value
+	return e, true, err
+}
+
+// load returns the entry for key, value included, leaving it in the tier.
+func (t *DiskTier) load(key string) (cacheEntry, bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	d, ok := t.index[key]
+	if !ok {
+		return cacheEntry{}, false, nil
+	}
+
+	e := d.meta
+	value, err := t.readLocked(key, d)
+	e.value = value
+	return e, true, err
+}
+
+// stat returns the entry for key, without its value, and the value's size.
+func (t *DiskTier) stat(key string) (cacheEntry, int, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	d, ok := t.index[key]
+	if !ok {
+		return cacheEntry{}, 0, false
+	}
+	return d.meta, d.size, true
+}
+
+// remove drops the entry for key and returns it, without its value.
+func (t *DiskTier) remove(key string) (cacheEntry, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	d, ok := t.removeLocked(key)
+	if !ok {
+		return cacheEntry{}, false
+	}
+	return d.meta, true
+}
+
+func (t *DiskTier) removeLocked(key string) (*diskEntry, bool) {
+	d, ok := t.index[key]
+	if !ok {
+		return nil, false
+	}
+
+	delete(t.index, key)
+	t.order.Remove(d.elem)
+	t.live -= d.n
+
+	return d, true
+}
+
+// each calls fn with every entry, without its value, and the value's
+// size. fn must not call methods on the tier.
+func (t *DiskTier) each(fn func(key string, e cacheEntry, size int)) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for el := t.order.Front(); el != nil; el = el.Next() {
+		key := el.Value.(string)
+		d := t.index[key]
+		fn(key, d.meta, d.size)
+	}
+}
+
+// usage returns the number of entries and the total size of their values.
+func (t *DiskTier) usage() (entries int, bytes int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, d := range t.index {
+		bytes += d.size
+	}
+	return len(t.index), bytes
+}
+
+func (t *DiskTier) clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	clear(t.index)
+	t.order.Init()
+	t.end, t.live = 0, 0
+
+	// Records past end are never read, so a failed truncate only wastes
+	// space
+	_ = t.file.Truncate(0)
+}
+
+// readLocked reads back the value of the record d holds for key.
+func (t *DiskTier) readLocked(key string, d *diskEntry) ([]byte, error) {
+	buf := make([]byte, d.n)
+	if _, err := t.file.ReadAt(buf, d.off); err != nil {
+		return nil, err
+	}
+
+	keyLen := int64(binary.LittleEndian.Uint32(buf[0:]))
+	valueLen := int64(binary.LittleEndian.Uint32(buf[4:]))
+	sum := binary.LittleEndian.Uint32(buf[d.n-diskTrailer:])
+	if keyLen != int64(len(key)) || diskHeader+keyLen+valueLen+diskTrailer != d.n ||
+		crc32.ChecksumIEEE(buf[:d.n-diskTrailer]) != sum ||
+		string(buf[diskHeader:diskHeader+keyLen]) != key {
+		return nil, errDiskCorrupt
+	}
+
+	start := diskHeader + keyLen
+	return buf[start : start+valueLen : start+valueLen], nil
+}
+
+// compactLocked rewrites the live records, oldest first, to a new log
+// that replaces the current one.
+func (t *DiskTier) compactLocked() error {
+	f, err := os.CreateTemp(filepath.Dir(t.path), filepath.Base(t.path)+".*")
+	if err != nil {
+		return err
+	}
+
+	offsets := make([]int64, 0, t.order.Len())
+	var end int64
+	for el := t.order.Front(); el != nil; el = el.Next() {
+		d := t.index[el.Value.(string)]
+		section := io.NewSectionReader(t.file, d.off, d.n)
+		if _, err = io.Copy(io.NewOffsetWriter(f, end), section); err != nil {
+			break
+		}
+		offsets = append(offsets, end)
+		end += d.n
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), t.path)
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	i := 0
+	for el := t.order.Front(); el != nil; el = el.Next() {
+		t.index[el.Value.(string)].off = offsets[i]
+		i++
+	}
+	_ = t.file.Close()
+	t.file = f
+	t.end = end
+
+	return nil
+}
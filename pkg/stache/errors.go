@@ -8,4 +8,16 @@ var (
 
 	// ErrIncorrectType is returned when a value is requested with the wrong content type (e.g. GetString on a JSON entry).
 	ErrIncorrectType = errors.New("cache: incorrect data type")
+
+	// ErrNotInteger is returned when incrementing a value that is not a base-10 integer.
+	ErrNotInteger = errors.New("cache: value is not an integer")
+
+	// ErrOverflow is returned when an increment would overflow an int64.
+	ErrOverflow = errors.New("cache: increment or decrement would overflow")
+
+	// ErrNotFloat is returned when a score is not a number (NaN).
+	ErrNotFloat = errors.New("cache: value is not a valid float")
+
+	// ErrConflict is returned when a version precondition fails in Txn.
+	ErrConflict = errors.New("cache: version conflict")
 )
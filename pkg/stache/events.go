@@ -0,0 +1,172 @@
+package stache
+
+import (
+	"slices"
+	"time"
+)
+
+// Op is the kind of change an Event describes.
+type Op int
+
+const (
+	// OpSet stores Event.Item under its key.
+	OpSet Op = iota + 1
+
+	// OpDelete removes the key, whether deleted, invalidated or found expired.
+	OpDelete
+
+	// OpExpire changes the expiry of the key without touching its value:
+	// Item.Meta.ExpiresAt is the new expiry (zero for none) and, for sliding
+	// entries, Item.Meta.TTL the window.
+	OpExpire
+
+	// OpClear removes every key.
+	OpClear
+)
+
+func (op Op) String() string {
+	switch op {
+	case OpSet:
+		return "set"
+	case OpDelete:
+		return "delete"
+	case OpExpire:
+		return "expire"
+	case OpClear:
+		return "clear"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes one change to the cache, in a form Apply can replay on
+// another cache. Changes to structured types such as Hash are not reported.
+type Event struct {
+	Op Op
+
+	// Item carries the key and, depending on Op, the value and metadata in
+	// the same form Items returns.
+	Item Item
+
+	// Seq numbers the events delivered to subscribers in the order they
+	// happened, starting from 1.
+	Seq uint64
+
+	// Time is when the change was made.
+	Time time.Time
+}
+
+// Subscribe registers fn to be called with every subsequent change, in
+// order. fn runs while the cache is locked: it must be quick and must not
+// call methods on the Cache. The returned function unsubscribes.
+func (c *Cache) Subscribe(fn func(Event)) (cancel func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.nextSubscriber++
+	id := c.nextSubscriber
+	c.subscribers[id] = fn
+
+	return func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		delete(c.subscribers, id)
+	}
+}
+
+// emitLocked numbers ev and hands it to subscribers.
+// c.mutex must be held for writing.
+func (c *Cache) emitLocked(ev Event) {
+	if len(c.subscribers) == 0 {
+		return
+	}
+
+	c.seq++
+	ev.Seq = c.seq
+	ev.Time = time.Now()
+	for _, fn := range c.subscribers {
+		fn(ev)
+	}
+}
+
+// entryItem returns e in the form used by Items and events.
+func entryItem(key string, e cacheEntry) Item {
+	return Item{
+		Key:   key,
+		Value: slices.Clone(e.value),
+		Meta: Meta{
+			TTL:         e.sliding,
+			ContentType: e.contentType,
+			Sliding:     e.sliding > 0,
+			ExpiresAt:   e.expiresAt,
+			Tags:        slices.Clone(e.tags),
+		},
+	}
+}
+
+// expiryChangedLocked reports a change to the expiry of the entry for key.
+// c.mutex must be held for writing.
+func (c *Cache) expiryChangedLocked(key string, e cacheEntry) {
+	if e.object != nil || len(c.subscribers) == 0 {
+		return
+	}
+
+	c.emitLocked(Event{Op: OpExpire, Item: Item{Key: key, Meta: Meta{
+		TTL:       e.sliding,
+		Sliding:   e.sliding > 0,
+		ExpiresAt: e.expiresAt,
+	}}})
+}
+
+// Apply replays an event produced by Subscribe on another cache, so the
+// entry ends up exactly as it was there, expiry included. Events for
+// structured content types return ErrIncorrectType.
+func (c *Cache) Apply(ev Event) error {
+	now := time.Now()
+	key := ev.Item.Key
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch ev.Op {
+	case OpSet:
+		meta := ev.Item.Meta
+		if meta.ContentType.structured() {
+			return ErrIncorrectType
+		}
+
+		if e, live := newEntry(ev.Item.Value, meta, now); live {
+			c.storeLocked(key, e)
+		} else {
+			c.removeLocked(key)
+		}
+
+	case OpDelete:
+		c.removeLocked(key)
+
+	case OpExpire:
+		e, ok := c.entryLocked(key)
+		if !ok {
+			return nil
+		}
+
+		e.expiresAt = ev.Item.Meta.ExpiresAt
+		e.sliding = 0
+		if ev.Item.Meta.Sliding {
+			e.sliding = ev.Item.Meta.TTL
+		}
+
+		if e.expired(now) {
+			c.removeLocked(key)
+			return nil
+		}
+		c.index.touch(key, e.expiresAt, e.sliding)
+		c.expiryChangedLocked(key, e)
+
+	case OpClear:
+		c.clearLocked()
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package stache
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+func newHash() map[string][]byte {
+	return map[string][]byte{}
+}
+
+// HSet stores value under field in the hash at key, creating the hash if needed.
+// It reports whether the field is new.
+// If the key holds a non-hash value, ErrIncorrectType is returned.
+func (c *Cache) HSet(key string, field string, value []byte) (bool, error) {
+	buf := make([]byte, len(value))
+	copy(buf, value)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	h, err := objectLocked(c, key, Hash, newHash)
+	if err != nil {
+		return false, err
+	}
+
+	_, exists := h[field]
+	h[field] = buf
+
+	return !exists, nil
+}
+
+// HGet returns the value of field in the hash at key.
+// If the key or the field does not exist, ErrNotFound is returned.
+// If the key holds a non-hash value, ErrIncorrectType is returned.
+func (c *Cache) HGet(key string, field string) ([]byte, error) {
+	e, ok := c.lookup(key, time.Now())
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	h, isHash := e.object.(map[string][]byte)
+	if e.contentType != Hash || !isHash {
+		return nil, ErrIncorrectType
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	v, ok := h[field]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	bytes := make([]byte, len(v))
+	copy(bytes, v)
+
+	return bytes, nil
+}
+
+// HDel removes the given fields from the hash at key and returns how many
+// existed. The key itself is removed once its last field is gone.
+// If the key holds a non-hash value, ErrIncorrectType is returned.
+func (c *Cache) HDel(key string, fields ...string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	h, err := objectLocked[map[string][]byte](c, key, Hash, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, f := range fields {
+		if _, ok := h[f]; ok {
+			delete(h, f)
+			n++
+		}
+	}
+
+	if len(h) == 0 {
+		c.removeLocked(key)
+	}
+
+	return n, nil
+}
+
+// HGetAll returns a copy of every field in the hash at key.
+// If the key does not exist, ErrNotFound is returned.
+// If the key holds a non-hash value, ErrIncorrectType is returned.
+func (c *Cache) HGetAll(key string) (map[string][]byte, error) {
+	e, ok := c.lookup(key, time.Now())
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	h, isHash := e.object.(map[string][]byte)
+	if e.contentType != Hash || !isHash {
+		return nil, ErrIncorrectType
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	out := make(map[string][]byte, len(h))
+	for f, v := range h {
+		out[f] = append([]byte(nil), v...)
+	}
+
+	return out, nil
+}
+
+// HIncrBy adds delta to the integer stored in field of the hash at key and
+// returns the new value. A missing key or field starts from 0.
+// If the field does not hold a base-10 integer, ErrNotInteger is returned.
+// If the result would overflow an int64, ErrOverflow is returned and the
+// field is left unchanged.
+// If the key holds a non-hash value, ErrIncorrectType is returned.
+func (c *Cache) HIncrBy(key string, field string, delta int64) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	h, err := objectLocked(c, key, Hash, newHash)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	if v, ok := h[field]; ok {
+		n, err = strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return 0, ErrNotInteger
+		}
+	}
+
+	if (delta > 0 && n > math.MaxInt64-delta) || (delta < 0 && n < math.MinInt64-delta) {
+		return 0, ErrOverflow
+	}
+
+	n += delta
+	h[field] = strconv.AppendInt(nil, n, 10)
+
+	return n, nil
+}
@@ -0,0 +1,293 @@
+package stache
+
+import (
+	"context"
+	"time"
+)
+
+// deque is a ring buffer of values, giving O(1) pushes and pops at both
+// ends and O(1) indexed access for LRange.
+type deque struct {
+	buf  [][]byte
+	head int
+	n    int
+}
+
+func newDeque() *deque {
+	return &deque{}
+}
+
+func (d *deque) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+
+	buf := make([][]byte, max(2*len(d.buf), 8))
+	for i := range d.n {
+		buf[i] = d.at(i)
+	}
+	d.buf, d.head = buf, 0
+}
+
+func (d *deque) at(i int) []byte {
+	return d.buf[(d.head+i)%len(d.buf)]
+}
+
+func (d *deque) pushFront(v []byte) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = v
+	d.n++
+}
+
+func (d *deque) pushBack(v []byte) {
+	d.grow()
+	d.buf[(d.head+d.n)%len(d.buf)] = v
+	d.n++
+}
+
+func (d *deque) popFront() []byte {
+	v := d.buf[d.head]
+	d.buf[d.head] = nil
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+	return v
+}
+
+func (d *deque) popBack() []byte {
+	i := (d.head + d.n - 1) % len(d.buf)
+	v := d.buf[i]
+	d.buf[i] = nil
+	d.n--
+	return v
+}
+
+func (d *deque) size() int {
+	n := 0
+	for i := range d.n {
+		n += len(d.at(i))
+	}
+	return n
+}
+
+// LPush inserts values at the head of the list at key, creating the list if
+// needed, and returns its new length. Values are pushed one after another,
+// so LPush(k, a, b) leaves b at the head. With no values it does nothing
+// and returns 0.
+// If the key holds a non-list value, ErrIncorrectType is returned.
+func (c *Cache) LPush(key string, values ...[]byte) (int, error) {
+	return c.push(key, true, values)
+}
+
+// RPush appends values to the tail of the list at key, creating the list if
+// needed, and returns its new length. With no values it does nothing and
+// returns 0.
+// If the key holds a non-list value, ErrIncorrectType is returned.
+func (c *Cache) RPush(key string, values ...[]byte) (int, error) {
+	return c.push(key, false, values)
+}
+
+func (c *Cache) push(key string, front bool, values [][]byte) (int, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	d, err := objectLocked(c, key, List, newDeque)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, v := range values {
+		buf := make([]byte, len(v))
+		copy(buf, v)
+
+		if front {
+			d.pushFront(buf)
+		} else {
+			d.pushBack(buf)
+		}
+	}
+
+	c.wakeLocked(key)
+
+	return d.n, nil
+}
+
+// LPop removes and returns the value at the head of the list at key.
+// The key is removed once its last value is popped.
+// If the key does not exist, ErrNotFound is returned.
+// If the key holds a non-list value, ErrIncorrectType is returned.
+func (c *Cache) LPop(key string) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.popLocked(key, true)
+}
+
+// RPop removes and returns the value at the tail of the list at key.
+// The key is removed once its last value is popped.
+// If the key does not exist, ErrNotFound is returned.
+// If the key holds a non-list value, ErrIncorrectType is returned.
+func (c *Cache) RPop(key string) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.popLocked(key, false)
+}
+
+func (c *Cache) popLocked(key string, front bool) ([]byte, error) {
+	d, err := objectLocked[*deque](c, key, List, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v []byte
+	if front {
+		v = d.popFront()
+	} else {
+		v = d.popBack()
+	}
+
+	if d.n == 0 {
+		c.removeLocked(key)
+	}
+
+	return v, nil
+}
+
+// BPop removes and returns the value at the head of the list at key,
+// waiting for one to be pushed if the list is empty or missing.
+// It gives up after timeout, returning ErrNotFound, or when ctx is done,
+// returning ctx.Err(). If timeout <= 0, only ctx bounds the wait.
+// If the key holds a non-list value, ErrIncorrectType is returned.
+func (c *Cache) BPop(ctx context.Context, key string, timeout time.Duration) ([]byte, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		c.mutex.Lock()
+		v, err := c.popLocked(key, true)
+		if err != ErrNotFound {
+			c.mutex.Unlock()
+			return v, err
+		}
+
+		w, ok := c.waiters[key]
+		if !ok {
+			w = &waiter{wake: make(chan struct{})}
+			c.waiters[key] = w
+		}
+		w.n++
+		c.mutex.Unlock()
+
+		select {
+		case <-w.wake:
+		case <-expired:
+			c.leave(key, w)
+			return nil, ErrNotFound
+		case <-ctx.Done():
+			c.leave(key, w)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// waiter is the set of BPop calls blocked on a list key. wake is closed
+// on the next push, and n counts the calls still waiting on it.
+type waiter struct {
+	wake chan struct{}
+	n    int
+}
+
+// leave drops a BPop call that gave up on w without being woken, and
+// forgets w once nobody waits on it anymore.
+func (c *Cache) leave(key string, w *waiter) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.waiters[key] != w {
+		return
+	}
+
+	w.n--
+	if w.n == 0 {
+		delete(c.waiters, key)
+	}
+}
+
+// wakeLocked releases every BPop waiting on key so they retry their pop.
+// c.mutex must be held for writing.
+func (c *Cache) wakeLocked(key string) {
+	if w, ok := c.waiters[key]; ok {
+		close(w.wake)
+		delete(c.waiters, key)
+	}
+}
+
+// LRange returns the values of the list at key between start and stop,
+// inclusive. Negative indices count from the tail, so LRange(k, 0, -1)
+// returns the whole list. Out of range indices are clamped.
+// A missing key yields an empty slice.
+// If the key holds a non-list value, ErrIncorrectType is returned.
+func (c *Cache) LRange(key string, start, stop int) ([][]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	d, err := objectLocked[*deque](c, key, List, nil)
+	if err == ErrNotFound {
+		return [][]byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	start, stop, ok := clampRange(start, stop, d.n)
+	if !ok {
+		return [][]byte{}, nil
+	}
+
+	out := make([][]byte, 0, stop-start+1)
+	for i := start; i <= stop; i++ {
+		out = append(out, append([]byte(nil), d.at(i)...))
+	}
+
+	return out, nil
+}
+
+// LLen returns the length of the list at key, or 0 if it does not exist.
+// If the key holds a non-list value, ErrIncorrectType is returned.
+func (c *Cache) LLen(key string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	d, err := objectLocked[*deque](c, key, List, nil)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return d.n, nil
+}
+
+// clampRange resolves Redis-style inclusive, possibly negative, indices
+// against a sequence of length n. ok is false if the range is empty.
+func clampRange(start, stop, n int) (int, int, bool) {
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if stop < 0 {
+		stop = n + stop
+	}
+	stop = min(stop, n-1)
+
+	return start, stop, start <= stop && start < n
+}
@@ -0,0 +1,192 @@
+package stache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Options configures a Cache made by NewCacheWithOptions.
+type Options struct {
+	// MaxEntries, if positive, bounds the number of entries: storing a new
+	// key in a full cache evicts the least recently used entry.
+	MaxEntries int
+
+	// Disk, if set, turns the cache into two tiers: byte-valued entries
+	// evicted from memory spill to Disk instead of being dropped, and are
+	// promoted back to memory when read. The least recently spilled entries
+	// are dropped once Disk is full. Disk is ignored if MaxEntries is 0, and
+	// must not be shared between caches.
+	Disk *DiskTier
+
+	// Engine selects how entries are stored in memory.
+	Engine Engine
+
+	// KeepExpired stops reads removing the expired entries they come
+	// across: they are reported missing but stay stored, with their
+	// versions, until a write replaces or removes them or RemoveExpired
+	// is called. A cache kept in step with others by replaying the same
+	// writes through TxnAt sets it, so that its contents depend on those
+	// writes only and not on when it was read.
+	KeepExpired bool
+}
+
+// NewCacheWithOptions returns a pointer to an empty Cache configured by opts.
+func NewCacheWithOptions(opts Options) *Cache {
+	c := NewCache()
+	if opts.Engine == EngineArena {
+		c.index = newArenaStore()
+	}
+	c.keepExpired = opts.KeepExpired
+	if opts.MaxEntries > 0 {
+		c.maxEntries = opts.MaxEntries
+		c.lru = &lru{order: list.New(), elems: map[string]*list.Element{}}
+		c.disk = opts.Disk
+	}
+	return c
+}
+
+// lru orders keys by last use, most recent first. It has its own mutex so
+// that reads holding c.mutex for reading can record a use; c.mutex is
+// always taken first.
+type lru struct {
+	mutex sync.Mutex
+	order *list.List
+	elems map[string]*list.Element
+}
+
+// touch marks key as just used, adding it if needed.
+func (l *lru) touch(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if el, ok := l.elems[key]; ok {
+		l.order.MoveToFront(el)
+		return
+	}
+	l.elems[key] = l.order.PushFront(key)
+}
+
+func (l *lru) remove(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if el, ok := l.elems[key]; ok {
+		l.order.Remove(el)
+		delete(l.elems, key)
+	}
+}
+
+// oldest returns the least recently used key.
+func (l *lru) oldest() (string, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	el := l.order.Back()
+	if el == nil {
+		return "", false
+	}
+	return el.Value.(string), true
+}
+
+func (l *lru) reset() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.order.Init()
+	clear(l.elems)
+}
+
+// usedLocked records a use of key, on a bounded cache. c.mutex must be
+// held, for reading at least.
+func (c *Cache) usedLocked(key string) {
+	if c.lru != nil {
+		c.lru.touch(key)
+	}
+}
+
+// evictLocked moves least recently used entries out of memory until the
+// cache is within its bound, spilling them to the disk tier if possible and
+// removing them otherwise. c.mutex must be held for writing.
+func (c *Cache) evictLocked() {
+	if c.lru == nil {
+		return
+	}
+
+	for c.index.len() > c.maxEntries {
+		key, ok := c.lru.oldest()
+		if !ok {
+			return
+		}
+		if c.spillLocked(key) {
+			continue
+		}
+		c.removeLocked(key)
+		c.evictions.Add(1)
+	}
+}
+
+// spillLocked moves the entry for key to the disk tier and reports whether
+// it did. Structured and expired entries are not spilled. c.mutex must be
+// held for writing.
+func (c *Cache) spillLocked(key string) bool {
+	e, _ := c.index.get(key)
+	if c.disk == nil || e.object != nil || e.expired(time.Now()) {
+		return false
+	}
+
+	dropped, err := c.disk.put(key, e)
+	if err != nil {
+		return false
+	}
+
+	// The entry keeps its tags and version, and subscribers see no change
+	c.index.delete(key)
+	c.lru.remove(key)
+
+	for _, d := range dropped {
+		c.untagLocked(d.key, d.meta.tags)
+		c.emitLocked(Event{Op: OpDelete, Item: Item{Key: d.key}})
+		c.evictions.Add(1)
+	}
+
+	return true
+}
+
+// entryLocked returns the entry for key like c.index.get, first promoting
+// it to memory if it was spilled to disk. A spilled entry that has expired
+// or cannot be read back is removed instead. c.mutex must be held for
+// writing.
+func (c *Cache) entryLocked(key string) (cacheEntry, bool) {
+	if e, ok := c.index.get(key); ok || c.disk == nil {
+		return e, ok
+	}
+
+	e, ok, err := c.disk.take(key)
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if err != nil || e.expired(time.Now()) {
+		c.untagLocked(key, e.tags)
+		c.emitLocked(Event{Op: OpDelete, Item: Item{Key: key}})
+		return cacheEntry{}, false
+	}
+
+	c.index.set(key, e)
+	c.usedLocked(key)
+	c.evictLocked()
+
+	return e, true
+}
+
+// peekLocked returns the entry for key from either tier, without promoting
+// it; a spilled entry comes without its value. c.mutex must be held, for
+// reading at least.
+func (c *Cache) peekLocked(key string) (cacheEntry, bool) {
+	if e, ok := c.index.meta(key); ok || c.disk == nil {
+		return e, ok
+	}
+
+	e, _, ok := c.disk.stat(key)
+	return e, ok
+}
@@ -6,33 +6,75 @@ package stache
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // NewCache returns a pointer to an empty instance of Cache.
 func NewCache() *Cache {
-	return &Cache{index: map[string]cacheEntry{}}
+	return &Cache{
+		index:       mapStore{},
+		tags:        map[string]map[string]struct{}{},
+		waiters:     map[string]*waiter{},
+		subscribers: map[uint64]func(Event){},
+	}
 }
 
 // Set stores data in the cache under the given key, with the provided metadata.
-// If TTL <= 0, the entry never expires.
+// If TTL <= 0 and ExpiresAt is zero, the entry never expires.
+// Setting an ExpiresAt in the past removes the key.
+// If meta names a structured content type such as Hash, ErrIncorrectType is returned.
 func (c *Cache) Set(key string, data []byte, meta Meta) error {
-	now := time.Now()
+	if meta.ContentType.structured() {
+		return ErrIncorrectType
+	}
+
+	e, live := newEntry(data, meta, time.Now())
+
+	c.mutex.Lock()
+	if live {
+		c.storeLocked(key, e)
+	} else {
+		c.removeLocked(key)
+	}
+	c.mutex.Unlock()
+
+	return nil
+}
+
+// newEntry builds the entry stored for data and meta, copying data.
+// live is false if meta puts the expiry in the past.
+func newEntry(data []byte, meta Meta, now time.Time) (e cacheEntry, live bool) {
 	meta.TTL = max(meta.TTL, 0)
 
 	var expiresAt time.Time
+	var sliding time.Duration
 	if meta.TTL > 0 {
 		expiresAt = now.Add(meta.TTL)
+		if meta.Sliding {
+			sliding = meta.TTL
+		}
+	}
+	if !meta.ExpiresAt.IsZero() {
+		expiresAt = meta.ExpiresAt
+	}
+
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return cacheEntry{}, false
 	}
 
 	buf := make([]byte, len(data))
 	copy(buf, data)
 
-	c.mutex.Lock()
-	c.index[key] = cacheEntry{buf, meta.ContentType, expiresAt}
-	c.mutex.Unlock()
-
-	return nil
+	return cacheEntry{
+		value:       buf,
+		contentType: meta.ContentType,
+		expiresAt:   expiresAt,
+		sliding:     sliding,
+		tags:        normalizeTags(meta.Tags),
+	}, true
 }
 
 // SetJSON marshals the given value to JSON and stores it under the given key.
@@ -43,109 +85,501 @@ func (c *Cache) SetJSON(key string, data any, ttl time.Duration) error {
 		return err
 	}
 
-	return c.Set(key, bytes, Meta{ttl, JSON})
+	return c.Set(key, bytes, Meta{TTL: ttl, ContentType: JSON})
 }
 
 // SetString stores a string value in the cache under the given key.
 // The entry will expire after ttl, unless ttl <= 0 (no expiry).
 func (c *Cache) SetString(key string, data string, ttl time.Duration) error {
-	return c.Set(key, []byte(data), Meta{ttl, Text})
+	return c.Set(key, []byte(data), Meta{TTL: ttl, ContentType: Text})
+}
+
+// Add stores data under key like Set, but only if the key does not already
+// exist. It reports whether the value was stored.
+func (c *Cache) Add(key string, data []byte, meta Meta) (bool, error) {
+	return c.setIf(key, data, meta, false)
+}
+
+// Replace stores data under key like Set, but only if the key already
+// exists. It reports whether the value was stored.
+func (c *Cache) Replace(key string, data []byte, meta Meta) (bool, error) {
+	return c.setIf(key, data, meta, true)
+}
+
+func (c *Cache) setIf(key string, data []byte, meta Meta, exists bool) (bool, error) {
+	if meta.ContentType.structured() {
+		return false, ErrIncorrectType
+	}
+
+	now := time.Now()
+	e, live := newEntry(data, meta, now)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cur, ok := c.peekLocked(key); (ok && !cur.expired(now)) != exists {
+		return false, nil
+	}
+
+	if live {
+		c.storeLocked(key, e)
+	} else {
+		c.removeLocked(key)
+	}
+
+	return true, nil
 }
 
-func (c *Cache) get(key string) (cacheEntry, error) {
+// Incr adds delta to the integer stored under key and returns the new value.
+// A missing key starts from 0 and is stored as Text without expiry; an
+// existing entry keeps its content type, expiry and tags.
+// If the value is not a base-10 integer, ErrNotInteger is returned.
+// If the key holds a structured type such as Hash, ErrIncorrectType is returned.
+func (c *Cache) Incr(key string, delta int64) (int64, error) {
 	now := time.Now()
 
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entryLocked(key)
+	if !ok || e.expired(now) {
+		e = cacheEntry{contentType: Text}
+	}
+
+	if e.object != nil {
+		return 0, ErrIncorrectType
+	}
+
+	var n int64
+	if e.value != nil {
+		var err error
+		n, err = strconv.ParseInt(string(e.value), 10, 64)
+		if err != nil {
+			return 0, ErrNotInteger
+		}
+	}
+
+	n += delta
+	e.value = strconv.AppendInt(nil, n, 10)
+	c.storeLocked(key, e)
+
+	return n, nil
+}
+
+// storeLocked writes e under key with a fresh version, keeping the tag
+// index in sync. c.mutex must be held for writing.
+func (c *Cache) storeLocked(key string, e cacheEntry) {
+	old, ok := c.index.meta(key)
+	if !ok && c.disk != nil {
+		old, ok = c.disk.remove(key)
+	}
+	if ok {
+		c.untagLocked(key, old.tags)
+	}
+
+	c.version++
+	e.version = c.version
+	c.index.set(key, e)
+	c.tagLocked(key, e.tags)
+	c.usedLocked(key)
+	c.evictLocked()
+
+	if e.object == nil && len(c.subscribers) > 0 {
+		c.emitLocked(Event{Op: OpSet, Item: entryItem(key, e)})
+	}
+}
+
+// removeLocked deletes the entry for key from either tier, keeping the tag
+// index in sync. A spilled entry is returned without its value.
+// c.mutex must be held for writing.
+func (c *Cache) removeLocked(key string) (cacheEntry, bool) {
+	e, ok := c.index.delete(key)
+	if ok {
+		if c.lru != nil {
+			c.lru.remove(key)
+		}
+	} else if c.disk != nil {
+		e, ok = c.disk.remove(key)
+	}
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	c.untagLocked(key, e.tags)
+	c.emitLocked(Event{Op: OpDelete, Item: Item{Key: key}})
+
+	return e, true
+}
+
+// lookup returns the live entry for key, promoting it from the disk tier
+// if needed. An expired entry is deleted on the spot and reported as
+// missing.
+func (c *Cache) lookup(key string, now time.Time) (cacheEntry, bool) {
 	c.mutex.RLock()
-	entry, ok := c.index[key]
+	entry, ok := c.index.get(key)
+	if ok && !entry.expired(now) {
+		c.usedLocked(key)
+	}
 	c.mutex.RUnlock()
 
+	if !ok && c.disk != nil {
+		c.mutex.Lock()
+		entry, ok = c.entryLocked(key)
+		c.mutex.Unlock()
+	}
+
 	if !ok {
-		return cacheEntry{}, ErrNotFound
+		return cacheEntry{}, false
 	}
 
-	if entry.expiresAt.Before(now) && !entry.expiresAt.IsZero() {
+	if entry.expired(now) {
+		if c.keepExpired {
+			return cacheEntry{}, false
+		}
+
 		c.mutex.Lock()
-		if entry2, ok2 := c.index[key]; ok2 && entry2.expiresAt.Equal(entry.expiresAt) {
-			delete(c.index, key)
+		if entry2, ok2 := c.index.meta(key); ok2 && entry2.expiresAt.Equal(entry.expiresAt) {
+			c.removeLocked(key)
 		}
 
 		c.mutex.Unlock()
 
-		return cacheEntry{}, ErrNotFound
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// objectLocked returns the structured value stored under key, which must
+// have content type ct. If newObj is non-nil, a missing key is created
+// holding newObj(). c.mutex must be held for writing.
+func objectLocked[T any](c *Cache, key string, ct ContentType, newObj func() T) (T, error) {
+	var zero T
+
+	e, ok := c.entryLocked(key)
+	if ok && e.expired(time.Now()) {
+		c.removeLocked(key)
+		ok = false
+	}
+
+	if !ok {
+		if newObj == nil {
+			return zero, ErrNotFound
+		}
+
+		obj := newObj()
+		c.storeLocked(key, cacheEntry{contentType: ct, object: obj})
+		return obj, nil
+	}
+
+	obj, isT := e.object.(T)
+	if e.contentType != ct || !isT {
+		return zero, ErrIncorrectType
+	}
+
+	c.usedLocked(key)
+	return obj, nil
+}
+
+// read runs fn on the live entry for key and counts a hit, or returns
+// ErrNotFound and counts a miss. A sliding expiry is extended first. fn runs
+// with c.mutex held and the value shares the store's memory, so fn must
+// copy what it keeps.
+func (c *Cache) read(key string, fn func(e cacheEntry) error) error {
+	now := time.Now()
+
+	c.mutex.RLock()
+	e, ok := c.index.view(key)
+	if ok && !e.expired(now) && e.sliding == 0 {
+		defer c.mutex.RUnlock()
+		c.usedLocked(key)
+		c.hits.Add(1)
+		return fn(e)
+	}
+	c.mutex.RUnlock()
+
+	if !ok && c.disk == nil {
+		c.misses.Add(1)
+		return ErrNotFound
+	}
+
+	// Expired, spilled and sliding entries need the write lock
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok = c.entryLocked(key)
+	if ok && e.expired(now) {
+		if !c.keepExpired {
+			c.removeLocked(key)
+		}
+		ok = false
+	}
+	if !ok {
+		c.misses.Add(1)
+		return ErrNotFound
+	}
+	c.usedLocked(key)
+	c.hits.Add(1)
+
+	if e.sliding > 0 {
+		e.expiresAt = now.Add(e.sliding)
+		c.index.touch(key, e.expiresAt, e.sliding)
+		c.expiryChangedLocked(key, e)
 	}
 
-	return entry, nil
+	return fn(e)
 }
 
 // GetBytes returns the raw byte slice for the given key.
 // If the key does not exist or is expired, ErrNotFound is returned.
+// If the key holds a structured type such as Hash, ErrIncorrectType is returned.
 func (c *Cache) GetBytes(key string) ([]byte, error) {
-	data, err := c.get(key)
+	var bytes []byte
+	err := c.read(key, func(e cacheEntry) error {
+		if e.object != nil {
+			return ErrIncorrectType
+		}
+
+		bytes = slices.Clone(e.value)
+		return nil
+	})
+
+	return bytes, err
+}
+
+// GetWithInfo returns a copy of the value for key together with its
+// metadata, both from the same lookup, so they always belong to the same
+// write. Like GetBytes, it extends a sliding expiry, which the returned
+// EntryInfo reflects.
+// If the key does not exist or is expired, ErrNotFound is returned.
+// If the key holds a structured type such as Hash, ErrIncorrectType is returned.
+func (c *Cache) GetWithInfo(key string) ([]byte, EntryInfo, error) {
+	var bytes []byte
+	var info EntryInfo
+	err := c.read(key, func(e cacheEntry) error {
+		if e.object != nil {
+			return ErrIncorrectType
+		}
+
+		bytes = slices.Clone(e.value)
+		info = e.info(key)
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, EntryInfo{}, err
 	}
 
-	bytes := make([]byte, len(data.value))
-	copy(bytes, data.value)
+	return bytes, info, nil
+}
+
+// View calls fn with the value for key, without copying it, and returns
+// fn's error. It counts as a read like GetBytes, extending a sliding expiry.
+//
+// The value must not be modified, nor used after fn returns. fn runs with
+// the cache locked: it must not block and must not call methods on the
+// Cache.
+// If the key does not exist or is expired, ErrNotFound is returned.
+// If the key holds a structured type such as Hash, ErrIncorrectType is returned.
+func (c *Cache) View(key string, fn func(value []byte) error) error {
+	return c.read(key, func(e cacheEntry) error {
+		if e.object != nil {
+			return ErrIncorrectType
+		}
 
-	return bytes, nil
+		return fn(e.value)
+	})
 }
 
 // GetString returns the string value for the given key.
 // If the entry is not of type Text, ErrIncorrectType is returned.
 func (c *Cache) GetString(key string) (string, error) {
-	data, err := c.get(key)
-	if err != nil {
-		return "", err
-	}
+	var s string
+	err := c.read(key, func(e cacheEntry) error {
+		if e.contentType != Text {
+			return ErrIncorrectType
+		}
 
-	if data.contentType != Text {
-		return "", ErrIncorrectType
-	}
+		s = string(e.value)
+		return nil
+	})
 
-	return string(data.value), nil
+	return s, err
 }
 
 // GetJSON unmarshals the JSON-encoded value into out, which must be a pointer.
 // If the entry is not of type JSON, ErrIncorrectType is returned.
 // If the key is missing or expired, ErrNotFound is returned.
 func (c *Cache) GetJSON(key string, out any) error {
-	item, err := c.get(key)
+	var data []byte
+	err := c.read(key, func(e cacheEntry) error {
+		if e.contentType != JSON {
+			return ErrIncorrectType
+		}
+
+		// Unmarshalling runs user code, so it is done unlocked
+		data = slices.Clone(e.value)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if item.contentType != JSON {
-		return ErrIncorrectType
+	return json.Unmarshal(data, out)
+}
+
+// GetEntry returns metadata for a single key (O(1)).
+// Unlike the value getters, it does not extend a sliding expiry, nor
+// promote an entry from the disk tier.
+func (c *Cache) GetEntry(key string) (EntryInfo, error) {
+	now := time.Now()
+
+	if c.disk != nil {
+		c.mutex.RLock()
+		_, inMemory := c.index.meta(key)
+		e, size, onDisk := c.disk.stat(key)
+		c.mutex.RUnlock()
+
+		if !inMemory && onDisk && !e.expired(now) {
+			return spilledInfo(key, e, size), nil
+		}
+	}
+
+	e, ok := c.lookup(key, now)
+	if !ok {
+		return EntryInfo{}, ErrNotFound
+	}
+
+	// Structured values are updated in place, so they are measured under the lock
+	if e.object != nil {
+		c.mutex.RLock()
+		defer c.mutex.RUnlock()
+	}
+
+	return e.info(key), nil
+}
+
+// Touch resets the expiry of the entry for key to ttl from now, without
+// rewriting its value. A sliding entry keeps sliding with the new ttl.
+// If ttl <= 0, the entry no longer expires (see Persist).
+// If the key does not exist or is expired, ErrNotFound is returned.
+func (c *Cache) Touch(key string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return c.Persist(key)
+	}
+
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entryLocked(key)
+	if !ok || e.expired(now) {
+		return ErrNotFound
 	}
 
-	jsonErr := json.Unmarshal(item.value, out)
-	if jsonErr != nil {
-		return jsonErr
+	e.expiresAt = now.Add(ttl)
+	if e.sliding > 0 {
+		e.sliding = ttl
 	}
+	c.index.touch(key, e.expiresAt, e.sliding)
+	c.expiryChangedLocked(key, e)
 
 	return nil
 }
 
-// GetEntry returns metadata for a single key (O(1)).
-func (c *Cache) GetEntry(key string) (EntryInfo, error) {
+// ExpireAt sets an absolute expiry on the entry for key, without rewriting
+// its value. A time in the past removes the entry. A sliding entry keeps its
+// window and starts sliding again on the next read.
+// If the key does not exist or is expired, ErrNotFound is returned.
+func (c *Cache) ExpireAt(key string, t time.Time) error {
+	if t.IsZero() {
+		return c.Persist(key)
+	}
+
 	now := time.Now()
 
-	c.mutex.RLock()
-	e, ok := c.index[key]
-	c.mutex.RUnlock()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entryLocked(key)
+	if !ok || e.expired(now) {
+		return ErrNotFound
+	}
+
+	if !t.After(now) {
+		c.removeLocked(key)
+		return nil
+	}
+
+	e.expiresAt = t
+	c.index.touch(key, e.expiresAt, e.sliding)
+	c.expiryChangedLocked(key, e)
+
+	return nil
+}
+
+// Persist removes the expiry from the entry for key, so it never expires.
+// If the key does not exist or is expired, ErrNotFound is returned.
+func (c *Cache) Persist(key string) error {
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entryLocked(key)
+	if !ok || e.expired(now) {
+		return ErrNotFound
+	}
+
+	e.expiresAt = time.Time{}
+	e.sliding = 0
+	c.index.touch(key, e.expiresAt, e.sliding)
+	c.expiryChangedLocked(key, e)
+
+	return nil
+}
+
+// TTL returns the time left before the entry for key expires.
+// It returns 0 if the entry never expires, matching Meta.TTL.
+// If the key does not exist or is expired, ErrNotFound is returned.
+func (c *Cache) TTL(key string) (time.Duration, error) {
+	now := time.Now()
+
+	e, ok := c.lookup(key, now)
 	if !ok {
-		return EntryInfo{}, ErrNotFound
+		return 0, ErrNotFound
 	}
-	if !e.expiresAt.IsZero() && e.expiresAt.Before(now) {
-		c.mutex.Lock()
-		if cur, ok2 := c.index[key]; ok2 && cur.expiresAt.Equal(e.expiresAt) {
-			delete(c.index, key)
+
+	if e.expiresAt.IsZero() {
+		return 0, nil
+	}
+
+	return e.expiresAt.Sub(now), nil
+}
+
+// SetMany stores every item while taking the lock once. The returned slice
+// reports the outcome of each item, with the same semantics as Set.
+func (c *Cache) SetMany(items []Item) []error {
+	now := time.Now()
+	errs := make([]error, len(items))
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, it := range items {
+		if it.Meta.ContentType.structured() {
+			errs[i] = ErrIncorrectType
+			continue
+		}
+
+		if e, live := newEntry(it.Value, it.Meta, now); live {
+			c.storeLocked(it.Key, e)
+		} else {
+			c.removeLocked(it.Key)
 		}
-		c.mutex.Unlock()
-		return EntryInfo{}, ErrNotFound
 	}
-	return EntryInfo{Key: key, ContentType: e.contentType, ExpiresAt: e.expiresAt, Size: len(e.value)}, nil
+
+	return errs
 }
 
 // Delete removes the entry for the given key, if present.
@@ -154,44 +588,183 @@ func (c *Cache) Delete(key string) (cacheEntry, bool) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	item, exists := c.index[key]
-	delete(c.index, key)
+	return c.removeLocked(key)
+}
+
+// DeleteMany removes the entries for the given keys while taking the lock
+// once. The returned slice reports whether each key existed.
+func (c *Cache) DeleteMany(keys ...string) []bool {
+	existed := make([]bool, len(keys))
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, k := range keys {
+		_, existed[i] = c.removeLocked(k)
+	}
+
+	return existed
+}
+
+// RemoveExpired removes every entry that has expired as of now and returns
+// how many there were. Reads remove expired entries as they come across
+// them, so it is only needed with Options.KeepExpired.
+func (c *Cache) RemoveExpired(now time.Time) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var expired []string
+	for k, e := range c.index.all() {
+		if e.expired(now) {
+			expired = append(expired, k)
+		}
+	}
+	if c.disk != nil {
+		c.disk.each(func(k string, e cacheEntry, _ int) {
+			if e.expired(now) {
+				expired = append(expired, k)
+			}
+		})
+	}
+
+	for _, k := range expired {
+		c.removeLocked(k)
+	}
+	return len(expired)
+}
+
+// Clear removes every entry from the cache and returns how many there were.
+func (c *Cache) Clear() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	n := c.lenLocked()
+	c.clearLocked()
 
-	return item, exists
+	return n
 }
 
-// Len returns the number of entries currently stored in the cache.
+// clearLocked empties the cache. c.mutex must be held for writing.
+func (c *Cache) clearLocked() {
+	c.index.clear()
+	c.tags = map[string]map[string]struct{}{}
+	if c.lru != nil {
+		c.lru.reset()
+	}
+	if c.disk != nil {
+		c.disk.clear()
+	}
+	c.emitLocked(Event{Op: OpClear})
+}
+
+// Len returns the number of entries currently stored in the cache, in
+// either tier.
 func (c *Cache) Len() int {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	return len(c.index)
+	return c.lenLocked()
+}
+
+func (c *Cache) lenLocked() int {
+	n := c.index.len()
+	if c.disk != nil {
+		disk, _ := c.disk.usage()
+		n += disk
+	}
+	return n
+}
+
+// Stats returns the number of entries, their total size and the read
+// hit and miss counts so far.
+func (c *Cache) Stats() Stats {
+	c.mutex.RLock()
+	st := Stats{Entries: c.index.len()}
+	for _, e := range c.index.all() {
+		st.Bytes += e.size()
+	}
+	if c.disk != nil {
+		st.DiskEntries, st.DiskBytes = c.disk.usage()
+		st.Entries += st.DiskEntries
+		st.Bytes += st.DiskBytes
+	}
+	c.mutex.RUnlock()
+
+	st.Hits = c.hits.Load()
+	st.Misses = c.misses.Load()
+	st.Evictions = c.evictions.Load()
+	return st
 }
 
-// Entries returns a snapshot of the current entries in the cache.
-// Each entry is described by its key, size, content type, and expiry.
+// Entries returns a snapshot of the current entries in the cache, in
+// either tier. Each entry is described by its key, size, content type,
+// and expiry.
 func (c *Cache) Entries() []EntryInfo {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
 	info := []EntryInfo{}
-	for k, v := range c.index {
-		info = append(info, EntryInfo{
-			Key:         k,
-			Size:        len(v.value),
-			ContentType: v.contentType,
-			ExpiresAt:   v.expiresAt,
+	for k, v := range c.index.all() {
+		info = append(info, v.info(k))
+	}
+	if c.disk != nil {
+		c.disk.each(func(k string, v cacheEntry, size int) {
+			info = append(info, spilledInfo(k, v, size))
 		})
 	}
 
 	return info
 }
 
+// Items returns a copy of every live entry whose key starts with prefix,
+// sorted by key, in a form SetMany restores faithfully: Meta.ExpiresAt holds
+// the absolute expiry and, for sliding entries, Meta.TTL the window.
+// Structured types such as Hash are not included.
+func (c *Cache) Items(prefix string) []Item {
+	now := time.Now()
+
+	c.mutex.RLock()
+	items := []Item{}
+	for k, v := range c.index.all() {
+		if v.object != nil || v.expired(now) || !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		items = append(items, entryItem(k, v))
+	}
+	for _, k := range c.spilledLocked(prefix, now) {
+		// An entry that cannot be read back is left out
+		if v, ok, err := c.disk.load(k); ok && err == nil {
+			items = append(items, entryItem(k, v))
+		}
+	}
+	c.mutex.RUnlock()
+
+	slices.SortFunc(items, func(a, b Item) int { return strings.Compare(a.Key, b.Key) })
+	return items
+}
+
 // String returns a summary string in the format `Cache(len={int})`.
 // It implements the fmt.Stringer interface.
 func (c *Cache) String() string {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	return fmt.Sprintf("Cache(len=%d)", len(c.index))
+	return fmt.Sprintf("Cache(len=%d)", c.lenLocked())
+}
+
+// spilledLocked returns the keys of the disk tier that start with prefix
+// and have not expired. c.mutex must be held, for reading at least.
+func (c *Cache) spilledLocked(prefix string, now time.Time) []string {
+	if c.disk == nil {
+		return nil
+	}
+
+	var keys []string
+	c.disk.each(func(k string, v cacheEntry, _ int) {
+		if !v.expired(now) && strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	})
+	return keys
 }
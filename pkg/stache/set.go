@@ -0,0 +1,254 @@
+package stache
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+type memberSet map[string]struct{}
+
+func newMemberSet() memberSet {
+	return memberSet{}
+}
+
+func (s memberSet) sorted() []string {
+	return append([]string{}, slices.Sorted(maps.Keys(s))...)
+}
+
+func (s memberSet) size() int {
+	n := 0
+	for m := range s {
+		n += len(m)
+	}
+	return n
+}
+
+// setRLocked returns the set stored under key for reading, or nil if the key
+// is missing or expired. The caller must hold c.mutex until done with it.
+func (c *Cache) setRLocked(key string) (memberSet, error) {
+	e, ok := c.peekLocked(key)
+	if !ok || e.expired(time.Now()) {
+		return nil, nil
+	}
+
+	s, isSet := e.object.(memberSet)
+	if e.contentType != Set || !isSet {
+		return nil, ErrIncorrectType
+	}
+
+	return s, nil
+}
+
+// SAdd adds members to the set at key, creating the set if needed,
+// and returns how many were not already present.
+// If the key holds a non-set value, ErrIncorrectType is returned.
+func (c *Cache) SAdd(key string, members ...string) (int, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	s, err := objectLocked(c, key, Set, newMemberSet)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, m := range members {
+		if _, ok := s[m]; !ok {
+			s[m] = struct{}{}
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// SRem removes members from the set at key and returns how many existed.
+// The key itself is removed once its last member is gone.
+// If the key holds a non-set value, ErrIncorrectType is returned.
+func (c *Cache) SRem(key string, members ...string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	s, err := objectLocked[memberSet](c, key, Set, nil)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, m := range members {
+		if _, ok := s[m]; ok {
+			delete(s, m)
+			n++
+		}
+	}
+
+	if len(s) == 0 {
+		c.removeLocked(key)
+	}
+
+	return n, nil
+}
+
+// SIsMember reports whether member belongs to the set at key.
+// A missing key is treated as an empty set.
+// If the key holds a non-set value, ErrIncorrectType is returned.
+func (c *Cache) SIsMember(key string, member string) (bool, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	s, err := c.setRLocked(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := s[member]
+	return ok, nil
+}
+
+// SMembers returns the members of the set at key in sorted order.
+// A missing key yields an empty slice.
+// If the key holds a non-set value, ErrIncorrectType is returned.
+func (c *Cache) SMembers(key string) ([]string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	s, err := c.setRLocked(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sorted(), nil
+}
+
+// SCard returns the number of members in the set at key, or 0 if it does not exist.
+// If the key holds a non-set value, ErrIncorrectType is returned.
+func (c *Cache) SCard(key string) (int, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	s, err := c.setRLocked(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(s), nil
+}
+
+// SetOp is a set algebra operation for SCombine and SCombineStore.
+type SetOp int
+
+const (
+	// Union keeps members present in any of the sets.
+	Union SetOp = iota
+	// Inter keeps members present in every set.
+	Inter
+	// Diff keeps members of the first set absent from all the others.
+	Diff
+)
+
+// combineLocked applies op across the sets at keys, treating missing keys as
+// empty sets. The caller must hold c.mutex.
+func (c *Cache) combineLocked(op SetOp, keys []string) (memberSet, error) {
+	sets := make([]memberSet, len(keys))
+	for i, k := range keys {
+		s, err := c.setRLocked(k)
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = s
+	}
+
+	out := memberSet{}
+	if len(sets) == 0 {
+		return out, nil
+	}
+
+	switch op {
+	case Union:
+		for _, s := range sets {
+			maps.Copy(out, s)
+		}
+	case Inter:
+		for m := range sets[0] {
+			in := true
+			for _, s := range sets[1:] {
+				if _, ok := s[m]; !ok {
+					in = false
+					break
+				}
+			}
+			if in {
+				out[m] = struct{}{}
+			}
+		}
+	case Diff:
+		maps.Copy(out, sets[0])
+		for _, s := range sets[1:] {
+			for m := range s {
+				delete(out, m)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// SCombine returns the sorted result of applying op across the sets at keys.
+// Missing keys are treated as empty sets.
+// If any key holds a non-set value, ErrIncorrectType is returned.
+func (c *Cache) SCombine(op SetOp, keys ...string) ([]string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	s, err := c.combineLocked(op, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sorted(), nil
+}
+
+// SCombineStore applies op across the sets at keys and stores the result as
+// a set under dst, replacing any value there. An empty result removes dst.
+// It returns the size of the stored set.
+// If any source key holds a non-set value, ErrIncorrectType is returned.
+func (c *Cache) SCombineStore(dst string, op SetOp, keys ...string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	s, err := c.combineLocked(op, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(s) == 0 {
+		c.removeLocked(dst)
+		return 0, nil
+	}
+
+	c.storeLocked(dst, cacheEntry{contentType: Set, object: s})
+	return len(s), nil
+}
+
+// SUnion returns the members present in any of the sets at keys.
+func (c *Cache) SUnion(keys ...string) ([]string, error) {
+	return c.SCombine(Union, keys...)
+}
+
+// SInter returns the members present in every set at keys.
+func (c *Cache) SInter(keys ...string) ([]string, error) {
+	return c.SCombine(Inter, keys...)
+}
+
+// SDiff returns the members of the first set at keys absent from all the others.
+func (c *Cache) SDiff(keys ...string) ([]string, error) {
+	return c.SCombine(Diff, keys...)
+}
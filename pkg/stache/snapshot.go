@@ -0,0 +1,128 @@
+package stache
+
+import (
+	"encoding/gob"
+	"io"
+	"time"
+)
+
+// snapshotHeader starts a snapshot; Entries snapshotEntry values follow.
+type snapshotHeader struct {
+	Version uint64
+	Entries int
+}
+
+type snapshotEntry struct {
+	Key         string
+	Value       []byte
+	ContentType ContentType
+	ExpiresAt   time.Time
+	Sliding     time.Duration
+	Tags        []string
+	Version     uint64
+}
+
+// WriteSnapshot writes the byte-valued entries to w, with their expiry
+// and versions, in a form ReadSnapshot restores exactly. Structured types
+// such as Hash are not included, nor are spilled entries that have expired
+// or cannot be read back from the disk tier.
+func (c *Cache) WriteSnapshot(w io.Writer) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var spilled []snapshotEntry
+	for _, k := range c.spilledLocked("", time.Now()) {
+		if e, ok, err := c.disk.load(k); ok && err == nil {
+			spilled = append(spilled, newSnapshotEntry(k, e))
+		}
+	}
+
+	n := len(spilled)
+	for _, e := range c.index.all() {
+		if e.object == nil {
+			n++
+		}
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(snapshotHeader{Version: c.version, Entries: n}); err != nil {
+		return err
+	}
+
+	for k, e := range c.index.all() {
+		if e.object != nil {
+			continue
+		}
+
+		if err := enc.Encode(newSnapshotEntry(k, e)); err != nil {
+			return err
+		}
+	}
+	for _, se := range spilled {
+		if err := enc.Encode(se); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newSnapshotEntry(key string, e cacheEntry) snapshotEntry {
+	return snapshotEntry{
+		Key:         key,
+		Value:       e.value,
+		ContentType: e.contentType,
+		ExpiresAt:   e.expiresAt,
+		Sliding:     e.sliding,
+		Tags:        e.tags,
+		Version:     e.version,
+	}
+}
+
+// ReadSnapshot replaces the contents of the cache with a snapshot written
+// by WriteSnapshot, versions included. The cache is left unchanged if the
+// snapshot cannot be read.
+func (c *Cache) ReadSnapshot(r io.Reader) error {
+	dec := gob.NewDecoder(r)
+
+	var h snapshotHeader
+	if err := dec.Decode(&h); err != nil {
+		return err
+	}
+
+	entries := make([]snapshotEntry, h.Entries)
+	for i := range entries {
+		if err := dec.Decode(&entries[i]); err != nil {
+			return err
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.clearLocked()
+	for _, se := range entries {
+		e := cacheEntry{
+			value:       se.Value,
+			contentType: se.ContentType,
+			expiresAt:   se.ExpiresAt,
+			sliding:     se.Sliding,
+			tags:        se.Tags,
+			version:     se.Version,
+		}
+		if e.value == nil {
+			e.value = []byte{}
+		}
+
+		c.index.set(se.Key, e)
+		c.tagLocked(se.Key, e.tags)
+		c.usedLocked(se.Key)
+		if len(c.subscribers) > 0 {
+			c.emitLocked(Event{Op: OpSet, Item: entryItem(se.Key, e)})
+		}
+	}
+	c.version = h.Version
+	c.evictLocked()
+
+	return nil
+}
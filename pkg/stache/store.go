@@ -0,0 +1,106 @@
+package stache
+
+import (
+	"iter"
+	"maps"
+	"time"
+)
+
+// Engine selects how a Cache stores its entries; see Options.Engine.
+type Engine int
+
+const (
+	// EngineMap keeps every entry in a Go map. It is the fastest to read
+	// and write, but the garbage collector scans every key and value on
+	// each cycle, so pauses grow with the cache.
+	EngineMap Engine = iota
+
+	// EngineArena packs the keys, values and metadata of byte-valued
+	// entries into large slabs indexed by a map without pointers, which
+	// the garbage collector skips. Reads copy the value out of its slab,
+	// and slabs left mostly empty by overwrites and deletes are compacted.
+	// Structured types such as Hash are kept as with EngineMap.
+	EngineArena
+)
+
+// store holds the entries of a Cache by key. Its methods are called with
+// c.mutex held, for writing unless noted.
+type store interface {
+	// get returns the entry for key. It may be called with c.mutex held
+	// for reading.
+	get(key string) (cacheEntry, bool)
+
+	// meta is get for callers that only need the metadata of an entry:
+	// the value may be left out. It may be called with c.mutex held for
+	// reading.
+	meta(key string) (cacheEntry, bool)
+
+	// view is get without copying the value, which then shares the store's
+	// memory and is only valid while c.mutex is held. It may be called with
+	// c.mutex held for reading.
+	view(key string) (cacheEntry, bool)
+
+	set(key string, e cacheEntry)
+
+	// touch sets the expiry of the entry for key, if any.
+	touch(key string, expiresAt time.Time, sliding time.Duration)
+
+	delete(key string) (cacheEntry, bool)
+	len() int
+
+	// all yields every entry. Values may share memory with the store, and
+	// must not be kept past the iteration. It may be called with c.mutex
+	// held for reading.
+	all() iter.Seq2[string, cacheEntry]
+
+	clear()
+}
+
+// mapStore is the store of EngineMap.
+type mapStore map[string]cacheEntry
+
+func (m mapStore) get(key string) (cacheEntry, bool) {
+	e, ok := m[key]
+	return e, ok
+}
+
+func (m mapStore) meta(key string) (cacheEntry, bool) {
+	e, ok := m[key]
+	return e, ok
+}
+
+func (m mapStore) view(key string) (cacheEntry, bool) {
+	e, ok := m[key]
+	return e, ok
+}
+
+func (m mapStore) set(key string, e cacheEntry) {
+	m[key] = e
+}
+
+func (m mapStore) touch(key string, expiresAt time.Time, sliding time.Duration) {
+	if e, ok := m[key]; ok {
+		e.expiresAt, e.sliding = expiresAt, sliding
+		m[key] = e
+	}
+}
+
+func (m mapStore) delete(key string) (cacheEntry, bool) {
+	e, ok := m[key]
+	if ok {
+		delete(m, key)
+	}
+	return e, ok
+}
+
+func (m mapStore) len() int {
+	return len(m)
+}
+
+func (m mapStore) all() iter.Seq2[string, cacheEntry] {
+	return maps.All(m)
+}
+
+func (m mapStore) clear() {
+	clear(m)
+}
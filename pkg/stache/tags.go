@@ -0,0 +1,57 @@
+package stache
+
+import (
+	"slices"
+	"time"
+)
+
+// normalizeTags returns a sorted copy of tags without duplicates or empty strings.
+func normalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := slices.DeleteFunc(slices.Clone(tags), func(t string) bool { return t == "" })
+	slices.Sort(out)
+
+	return slices.Clip(slices.Compact(out))
+}
+
+func (c *Cache) tagLocked(key string, tags []string) {
+	for _, t := range tags {
+		keys, ok := c.tags[t]
+		if !ok {
+			keys = map[string]struct{}{}
+			c.tags[t] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+func (c *Cache) untagLocked(key string, tags []string) {
+	for _, t := range tags {
+		keys := c.tags[t]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(c.tags, t)
+		}
+	}
+}
+
+// InvalidateTag removes every entry carrying the given tag.
+// It returns the number of live (unexpired) entries removed.
+func (c *Cache) InvalidateTag(tag string) int {
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	n := 0
+	for key := range c.tags[tag] {
+		if e, ok := c.removeLocked(key); ok && !e.expired(now) {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,147 @@
+package stache
+
+import "time"
+
+// Tx gives a Txn function read/write access to several keys at once.
+// Reads see the transaction's own writes; writes are buffered and only
+// applied when the function returns nil.
+type Tx struct {
+	c      *Cache
+	now    time.Time
+	writes map[string]txWrite
+	order  []string
+
+	// committed holds the versions assigned to written keys, once applied.
+	committed map[string]uint64
+}
+
+type txWrite struct {
+	entry cacheEntry
+	live  bool // false for a delete
+}
+
+// Txn runs fn with exclusive access to the cache and commits its writes
+// atomically if it returns nil. If fn returns an error, nothing is
+// applied and the error is returned.
+//
+// fn must not call methods on the Cache itself; doing so deadlocks.
+func (c *Cache) Txn(fn func(tx *Tx) error) error {
+	return c.TxnAt(time.Now(), fn)
+}
+
+// TxnAt is Txn with the clock stopped at now: expiry checks and TTLs are
+// evaluated against it rather than the current time, so caches replaying
+// the same transactions agree on the outcome.
+func (c *Cache) TxnAt(now time.Time, fn func(tx *Tx) error) error {
+	tx := &Tx{c: c, now: now, writes: map[string]txWrite{}}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	committed := make(map[string]uint64, len(tx.order))
+	for _, key := range tx.order {
+		w := tx.writes[key]
+		if w.live {
+			c.storeLocked(key, w.entry)
+			committed[key] = c.version
+		} else {
+			c.removeLocked(key)
+			committed[key] = 0
+		}
+	}
+	tx.committed = committed
+
+	return nil
+}
+
+func (tx *Tx) current(key string) (cacheEntry, bool) {
+	if w, ok := tx.writes[key]; ok {
+		return w.entry, w.live
+	}
+
+	e, ok := tx.c.entryLocked(key)
+	if !ok || e.expired(tx.now) {
+		return cacheEntry{}, false
+	}
+
+	return e, true
+}
+
+// Get returns a copy of the value and the metadata for key.
+// If the key does not exist, ErrNotFound is returned.
+// If the key holds a structured type such as Hash, ErrIncorrectType is returned.
+func (tx *Tx) Get(key string) ([]byte, EntryInfo, error) {
+	e, ok := tx.current(key)
+	if !ok {
+		return nil, EntryInfo{}, ErrNotFound
+	}
+
+	if e.object != nil {
+		return nil, EntryInfo{}, ErrIncorrectType
+	}
+
+	bytes := make([]byte, len(e.value))
+	copy(bytes, e.value)
+
+	return bytes, e.info(key), nil
+}
+
+// Set buffers a write of data under key, with the same semantics as Cache.Set.
+func (tx *Tx) Set(key string, data []byte, meta Meta) error {
+	if meta.ContentType.structured() {
+		return ErrIncorrectType
+	}
+
+	e, live := newEntry(data, meta, tx.now)
+	tx.write(key, txWrite{e, live})
+	return nil
+}
+
+// Delete buffers the removal of key and reports whether it currently exists.
+func (tx *Tx) Delete(key string) bool {
+	_, ok := tx.current(key)
+	tx.write(key, txWrite{})
+	return ok
+}
+
+func (tx *Tx) write(key string, w txWrite) {
+	if _, ok := tx.writes[key]; !ok {
+		tx.order = append(tx.order, key)
+	}
+	tx.writes[key] = w
+}
+
+// Version returns the version of key as of the start of the transaction,
+// or 0 if it does not exist. Once Txn has committed, it instead returns the
+// version the commit assigned to a key the transaction wrote (0 if deleted).
+func (tx *Tx) Version(key string) uint64 {
+	if tx.committed != nil {
+		if v, ok := tx.committed[key]; ok {
+			return v
+		}
+
+		// Txn has returned and released the lock
+		tx.c.mutex.RLock()
+		defer tx.c.mutex.RUnlock()
+	}
+
+	e, ok := tx.c.peekLocked(key)
+	if !ok || e.expired(tx.now) {
+		return 0
+	}
+
+	return e.version
+}
+
+// Expect returns ErrConflict unless key is at the given version as of the
+// start of the transaction. A version of 0 expects the key not to exist.
+func (tx *Tx) Expect(key string, version uint64) error {
+	if tx.Version(key) != version {
+		return ErrConflict
+	}
+	return nil
+}
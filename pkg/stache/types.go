@@ -1,7 +1,9 @@
 package stache
 
 import (
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -9,13 +11,92 @@ import (
 // It is safe for concurrent use by multiple goroutines.
 type Cache struct {
 	mutex sync.RWMutex
-	index map[string]cacheEntry
+	index store
+	tags  map[string]map[string]struct{}
+
+	// waiters holds, per list key, the BPop calls blocked on it.
+	waiters map[string]*waiter
+
+	// version is the last version handed out by storeLocked.
+	version uint64
+
+	// hits and misses count value reads, and evictions the entries
+	// dropped to stay within maxEntries, for Stats.
+	hits, misses, evictions atomic.Uint64
+
+	// maxEntries bounds a cache made with Options.MaxEntries, in which
+	// case lru tracks use; lru is nil for unbounded caches.
+	maxEntries int
+	lru        *lru
+
+	// keepExpired is Options.KeepExpired.
+	keepExpired bool
+
+	// disk holds the entries spilled from memory, if Options.Disk is set.
+	// A key is in at most one of index and disk.
+	disk *DiskTier
+
+	// subscribers are called with every change; see Subscribe.
+	subscribers    map[uint64]func(Event)
+	nextSubscriber uint64
+	seq            uint64
 }
 
 type cacheEntry struct {
 	value       []byte
 	contentType ContentType
 	expiresAt   time.Time
+	sliding     time.Duration
+	tags        []string
+	version     uint64
+
+	// object holds the value of structured types such as Hash and List;
+	// value is nil for those entries.
+	object any
+}
+
+func (e cacheEntry) size() int {
+	switch obj := e.object.(type) {
+	case map[string][]byte:
+		n := 0
+		for f, v := range obj {
+			n += len(f) + len(v)
+		}
+		return n
+	case *deque:
+		return obj.size()
+	case *sortedSet:
+		return obj.size()
+	case memberSet:
+		return obj.size()
+	default:
+		return len(e.value)
+	}
+}
+
+func (e cacheEntry) info(key string) EntryInfo {
+	return EntryInfo{
+		Key:         key,
+		Size:        e.size(),
+		ContentType: e.contentType,
+		ExpiresAt:   e.expiresAt,
+		Tags:        slices.Clone(e.tags),
+		Sliding:     e.sliding > 0,
+		Version:     e.version,
+	}
+}
+
+// spilledInfo describes e, an entry of the disk tier whose value has the
+// given size.
+func spilledInfo(key string, e cacheEntry, size int) EntryInfo {
+	info := e.info(key)
+	info.Size = size
+	info.Tier = TierDisk
+	return info
+}
+
+func (e cacheEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && e.expiresAt.Before(now)
 }
 
 // ContentType indicates the encoding format of a cache entry value.
@@ -24,8 +105,31 @@ type ContentType string
 const (
 	JSON ContentType = "application/json"
 	Text ContentType = "text/plain"
+
+	// Hash marks an entry holding a field map, managed with the H* methods.
+	Hash ContentType = "application/vnd.stache.hash"
+
+	// List marks an entry holding a list, managed with the L*, R* and BPop methods.
+	List ContentType = "application/vnd.stache.list"
+
+	// SortedSet marks an entry holding members ordered by score, managed with the Z* methods.
+	SortedSet ContentType = "application/vnd.stache.zset"
+
+	// Set marks an entry holding unordered unique members, managed with the S* methods.
+	Set ContentType = "application/vnd.stache.set"
 )
 
+// structured reports whether ct is one of the structured types, whose
+// values are managed by dedicated methods rather than Set.
+func (ct ContentType) structured() bool {
+	switch ct {
+	case Hash, List, SortedSet, Set:
+		return true
+	default:
+		return false
+	}
+}
+
 // Meta holds metadata for a cache entry, including its TTL and content type.
 type Meta struct {
 	// TTL specifies the time-to-live for a value in seconds.
@@ -33,8 +137,28 @@ type Meta struct {
 	TTL time.Duration
 
 	// ContentType describes the MIME content type of the cached value.
-	// Currently only supports `application/json` and `text/plain`
+	// The typed getters understand `application/json` and `text/plain`;
+	// structured types such as Hash have their own content types.
 	ContentType ContentType
+
+	// Sliding makes the entry's expiry slide: every read pushes it
+	// TTL further into the future. It has no effect if TTL <= 0.
+	Sliding bool
+
+	// ExpiresAt, if non-zero, is an absolute expiry that takes precedence
+	// over TTL. TTL then only sets the sliding window, if any.
+	ExpiresAt time.Time
+
+	// Tags groups the entry with others for bulk invalidation
+	// via InvalidateTag. Duplicates and empty tags are dropped.
+	Tags []string
+}
+
+// Item is a key with its value and metadata, as stored by SetMany.
+type Item struct {
+	Key   string
+	Value []byte
+	Meta  Meta
 }
 
 // EntryInfo describes a cached entry for introspection.
@@ -43,4 +167,55 @@ type EntryInfo struct {
 	Size        int
 	ContentType ContentType
 	ExpiresAt   time.Time
+	Tags        []string
+
+	// Sliding reports whether reads push ExpiresAt back, as set by
+	// Meta.Sliding.
+	Sliding bool
+
+	// Version changes every time the entry is rewritten, for use as a
+	// precondition in Txn. It is never 0 for an existing entry.
+	Version uint64
+
+	// Tier is where the entry lives in a cache with Options.Disk.
+	Tier Tier
+}
+
+// Tier is a storage tier of a cache, as reported by EntryInfo.
+type Tier int
+
+const (
+	TierMemory Tier = iota
+	TierDisk
+)
+
+func (t Tier) String() string {
+	switch t {
+	case TierMemory:
+		return "memory"
+	case TierDisk:
+		return "disk"
+	default:
+		return "unknown"
+	}
+}
+
+// Stats is a point-in-time summary of the cache, as returned by Cache.Stats.
+type Stats struct {
+	Entries int
+	Bytes   int
+
+	// Hits and Misses count reads through the value getters (GetBytes,
+	// GetString, GetJSON) that did and did not find a live entry.
+	Hits   uint64
+	Misses uint64
+
+	// Evictions counts entries dropped to stay within Options.MaxEntries,
+	// or within the disk tier's bound.
+	Evictions uint64
+
+	// DiskEntries and DiskBytes are the part of Entries and Bytes spilled
+	// to Options.Disk.
+	DiskEntries int
+	DiskBytes   int
 }
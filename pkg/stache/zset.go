@@ -0,0 +1,382 @@
+package stache
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	skiplistMaxLevel = 32
+	skiplistP        = 0.25
+)
+
+// ZMember is a member of a sorted set together with its score.
+type ZMember struct {
+	Member string
+	Score  float64
+}
+
+type skipLevel struct {
+	next *skipNode
+	// span is the number of nodes the link skips over, used for ranks.
+	span int
+}
+
+type skipNode struct {
+	member string
+	score  float64
+	prev   *skipNode
+	levels []skipLevel
+}
+
+// less orders nodes by score, breaking ties by member.
+func (n *skipNode) less(score float64, member string) bool {
+	return n.score < score || (n.score == score && n.member < member)
+}
+
+// skiplist keeps members ordered by (score, member) with O(log n) inserts,
+// deletes and rank lookups, after the Redis zskiplist.
+type skiplist struct {
+	head  *skipNode
+	tail  *skipNode
+	level int
+	n     int
+}
+
+func newSkiplist() *skiplist {
+	return &skiplist{
+		head:  &skipNode{levels: make([]skipLevel, skiplistMaxLevel)},
+		level: 1,
+	}
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skiplistMaxLevel && rand.Float64() < skiplistP {
+		level++
+	}
+	return level
+}
+
+func (sl *skiplist) insert(score float64, member string) {
+	var update [skiplistMaxLevel]*skipNode
+	var rank [skiplistMaxLevel]int
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.levels[i].next != nil && x.levels[i].next.less(score, member) {
+			rank[i] += x.levels[i].span
+			x = x.levels[i].next
+		}
+		update[i] = x
+	}
+
+	level := randomLevel()
+	if level > sl.level {
+		for i := sl.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = sl.head
+			update[i].levels[i].span = sl.n
+		}
+		sl.level = level
+	}
+
+	x = &skipNode{member: member, score: score, levels: make([]skipLevel, level)}
+	for i := range level {
+		x.levels[i].next = update[i].levels[i].next
+		update[i].levels[i].next = x
+
+		x.levels[i].span = update[i].levels[i].span - (rank[0] - rank[i])
+		update[i].levels[i].span = rank[0] - rank[i] + 1
+	}
+
+	for i := level; i < sl.level; i++ {
+		update[i].levels[i].span++
+	}
+
+	if update[0] != sl.head {
+		x.prev = update[0]
+	}
+	if x.levels[0].next != nil {
+		x.levels[0].next.prev = x
+	} else {
+		sl.tail = x
+	}
+
+	sl.n++
+}
+
+func (sl *skiplist) delete(score float64, member string) bool {
+	var update [skiplistMaxLevel]*skipNode
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && x.levels[i].next.less(score, member) {
+			x = x.levels[i].next
+		}
+		update[i] = x
+	}
+
+	x = x.levels[0].next
+	if x == nil || x.score != score || x.member != member {
+		return false
+	}
+
+	for i := range sl.level {
+		if update[i].levels[i].next == x {
+			update[i].levels[i].span += x.levels[i].span - 1
+			update[i].levels[i].next = x.levels[i].next
+		} else {
+			update[i].levels[i].span--
+		}
+	}
+
+	if x.levels[0].next != nil {
+		x.levels[0].next.prev = x.prev
+	} else {
+		sl.tail = x.prev
+	}
+
+	for sl.level > 1 && sl.head.levels[sl.level-1].next == nil {
+		sl.level--
+	}
+
+	sl.n--
+	return true
+}
+
+// rank returns the 0-based position of (score, member).
+func (sl *skiplist) rank(score float64, member string) int {
+	r := 0
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && !(score < x.levels[i].next.score ||
+			(score == x.levels[i].next.score && member < x.levels[i].next.member)) {
+			r += x.levels[i].span
+			x = x.levels[i].next
+		}
+		if x != sl.head && x.member == member {
+			return r - 1
+		}
+	}
+	return -1
+}
+
+// byRank returns the node at 0-based position r.
+func (sl *skiplist) byRank(r int) *skipNode {
+	traversed := 0
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && traversed+x.levels[i].span <= r+1 {
+			traversed += x.levels[i].span
+			x = x.levels[i].next
+		}
+		if traversed == r+1 {
+			return x
+		}
+	}
+	return nil
+}
+
+// firstFrom returns the first node with a score >= min.
+func (sl *skiplist) firstFrom(min float64) *skipNode {
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && x.levels[i].next.score < min {
+			x = x.levels[i].next
+		}
+	}
+	return x.levels[0].next
+}
+
+// sortedSet pairs a skiplist for ordering with a map for O(1) score lookups.
+type sortedSet struct {
+	scores map[string]float64
+	sl     *skiplist
+}
+
+func newSortedSet() *sortedSet {
+	return &sortedSet{scores: map[string]float64{}, sl: newSkiplist()}
+}
+
+func (z *sortedSet) size() int {
+	n := 0
+	for m := range z.scores {
+		n += len(m) + 8
+	}
+	return n
+}
+
+// zset returns the sorted set stored under key for reading, going through
+// lookup like the hash reads. The caller must hold c.mutex for reading
+// while using it.
+func (c *Cache) zset(key string) (*sortedSet, error) {
+	e, ok := c.lookup(key, time.Now())
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	z, isZSet := e.object.(*sortedSet)
+	if e.contentType != SortedSet || !isZSet {
+		return nil, ErrIncorrectType
+	}
+
+	return z, nil
+}
+
+// ZAdd sets the score of member in the sorted set at key, creating the set
+// if needed. It reports whether member is new.
+// If score is NaN, ErrNotFloat is returned.
+// If the key holds a non-sorted-set value, ErrIncorrectType is returned.
+func (c *Cache) ZAdd(key string, score float64, member string) (bool, error) {
+	if math.IsNaN(score) {
+		return false, ErrNotFloat
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	z, err := objectLocked(c, key, SortedSet, newSortedSet)
+	if err != nil {
+		return false, err
+	}
+
+	old, exists := z.scores[member]
+	if exists {
+		if old == score {
+			return false, nil
+		}
+		z.sl.delete(old, member)
+	}
+
+	z.scores[member] = score
+	z.sl.insert(score, member)
+
+	return !exists, nil
+}
+
+// ZRem removes members from the sorted set at key and returns how many
+// existed. The key itself is removed once its last member is gone.
+// If the key holds a non-sorted-set value, ErrIncorrectType is returned.
+func (c *Cache) ZRem(key string, members ...string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	z, err := objectLocked[*sortedSet](c, key, SortedSet, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, m := range members {
+		if score, ok := z.scores[m]; ok {
+			z.sl.delete(score, m)
+			delete(z.scores, m)
+			n++
+		}
+	}
+
+	if len(z.scores) == 0 {
+		c.removeLocked(key)
+	}
+
+	return n, nil
+}
+
+// ZScore returns the score of member in the sorted set at key.
+// If the key or member does not exist, ErrNotFound is returned.
+// If the key holds a non-sorted-set value, ErrIncorrectType is returned.
+func (c *Cache) ZScore(key string, member string) (float64, error) {
+	z, err := c.zset(key)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	score, ok := z.scores[member]
+	if !ok {
+		return 0, ErrNotFound
+	}
+
+	return score, nil
+}
+
+// ZRank returns the 0-based rank of member in the sorted set at key,
+// ordered by ascending score.
+// If the key or member does not exist, ErrNotFound is returned.
+// If the key holds a non-sorted-set value, ErrIncorrectType is returned.
+func (c *Cache) ZRank(key string, member string) (int, error) {
+	z, err := c.zset(key)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	score, ok := z.scores[member]
+	if !ok {
+		return 0, ErrNotFound
+	}
+
+	return z.sl.rank(score, member), nil
+}
+
+// ZRangeByScore returns the members of the sorted set at key with
+// min <= score <= max, in ascending order. A missing key yields an empty slice.
+// If the key holds a non-sorted-set value, ErrIncorrectType is returned.
+func (c *Cache) ZRangeByScore(key string, min, max float64) ([]ZMember, error) {
+	z, err := c.zset(key)
+	if err == ErrNotFound {
+		return []ZMember{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	out := []ZMember{}
+	for x := z.sl.firstFrom(min); x != nil && x.score <= max; x = x.levels[0].next {
+		out = append(out, ZMember{Member: x.member, Score: x.score})
+	}
+
+	return out, nil
+}
+
+// ZRangeByRank returns the members of the sorted set at key ranked between
+// start and stop, inclusive, in ascending order. Negative ranks count from
+// the highest score, so ZRangeByRank(k, 0, -1) returns the whole set.
+// A missing key yields an empty slice.
+// If the key holds a non-sorted-set value, ErrIncorrectType is returned.
+func (c *Cache) ZRangeByRank(key string, start, stop int) ([]ZMember, error) {
+	z, err := c.zset(key)
+	if err == ErrNotFound {
+		return []ZMember{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	start, stop, ok := clampRange(start, stop, z.sl.n)
+	if !ok {
+		return []ZMember{}, nil
+	}
+
+	out := make([]ZMember, 0, stop-start+1)
+	for x, i := z.sl.byRank(start), start; x != nil && i <= stop; x, i = x.levels[0].next, i+1 {
+		out = append(out, ZMember{Member: x.member, Score: x.score})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,189 @@
+package stacheclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 5 * time.Second
+	DefaultBreakerProbes    = 1
+)
+
+// BreakerPolicy configures the circuit breaker kept for each server.
+// The breaker opens after Threshold attempts in a row fail because the
+// server is unavailable or too slow, and while open, calls fail at once
+// with ErrUnavailable instead of waiting out their timeout. After Cooldown
+// it lets Probes calls through; if they succeed it closes, otherwise it
+// opens again. Errors the server returns, such as stache.ErrNotFound,
+// count as successes.
+type BreakerPolicy struct {
+	// Threshold is the number of failed attempts that opens the breaker.
+	// Negative disables the breaker.
+	Threshold int
+	Cooldown  time.Duration
+	Probes    int
+}
+
+// BreakerState is the state of a server's circuit breaker.
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// endpoint is one server, with its breaker.
+type endpoint struct {
+	addr    string
+	rpc     service
+	breaker breaker
+}
+
+// outcome is how an attempt counts towards the breaker.
+type outcome int
+
+const (
+	succeeded outcome = iota
+	failed
+	// ignored attempts were cut short by the caller.
+	ignored
+)
+
+// classify sorts the result of an attempt made under ctx.
+func classify(ctx context.Context, err error) outcome {
+	switch {
+	case err == nil:
+		return succeeded
+	case ctx.Err() != nil:
+		return ignored
+	}
+
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeDeadlineExceeded:
+		return failed
+	default:
+		return succeeded
+	}
+}
+
+type breaker struct {
+	policy BreakerPolicy
+
+	mutex    sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+
+	// probing counts the probes in flight, and passed those that
+	// succeeded, while half-open.
+	probing, passed int
+}
+
+// allow reports whether an attempt may be made, and whether it is a probe,
+// in which case its outcome decides the state.
+func (b *breaker) allow(now time.Time) (probe bool, ok bool) {
+	if b.policy.Threshold < 0 {
+		return false, true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == BreakerOpen && now.Sub(b.openedAt) >= b.policy.Cooldown {
+		b.state, b.probing, b.passed = BreakerHalfOpen, 0, 0
+	}
+
+	switch b.state {
+	case BreakerClosed:
+		return false, true
+	case BreakerHalfOpen:
+		if b.probing+b.passed < b.policy.Probes {
+			b.probing++
+			return true, true
+		}
+	}
+	return false, false
+}
+
+func (b *breaker) record(probe bool, o outcome) {
+	if b.policy.Threshold < 0 {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if probe {
+		if b.state != BreakerHalfOpen {
+			return
+		}
+		b.probing--
+		switch o {
+		case succeeded:
+			if b.passed++; b.passed >= b.policy.Probes {
+				b.state, b.failures = BreakerClosed, 0
+			}
+		case failed:
+			b.state, b.openedAt = BreakerOpen, time.Now()
+		}
+		return
+	}
+
+	if b.state != BreakerClosed {
+		return
+	}
+	switch o {
+	case succeeded:
+		b.failures = 0
+	case failed:
+		if b.failures++; b.failures >= b.policy.Threshold {
+			b.state, b.openedAt = BreakerOpen, time.Now()
+		}
+	}
+}
+
+func (b *breaker) current() BreakerState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state
+}
+
+// errCircuitOpen is returned in place of calling a server whose breaker is
+// open.
+func errCircuitOpen() error {
+	return &Error{Err: ErrUnavailable, Cause: connect.NewError(connect.CodeUnavailable, errors.New("circuit breaker open"))}
+}
+
+// Breakers returns the state of the circuit breaker of every server, by
+// address.
+func (c *Client) Breakers() map[string]BreakerState {
+	out := map[string]BreakerState{}
+	for _, sh := range c.shards {
+		out[sh.primary.addr] = sh.primary.breaker.current()
+		for _, ep := range sh.replicas {
+			out[ep.addr] = ep.breaker.current()
+		}
+	}
+	return out
+}
@@ -0,0 +1,370 @@
+// Package stacheclient is a Go client for stached. Its methods mirror
+// stache.Cache, taking a context first, and return stache's sentinel
+// errors (ErrNotFound, ErrIncorrectType, ...) for the failures the server
+// reports with them, so code can move between an embedded cache and a
+// remote one with few changes.
+//
+// Calls are bounded by a per-call timeout, and idempotent calls that fail
+// because the server is briefly unavailable are retried with backoff. A
+// circuit breaker per server makes calls fail fast with ErrUnavailable
+// while it is down, and an optional local cache can serve them instead;
+// see BreakerPolicy and Config.Fallback.
+// Reads can optionally be served from a local near cache that the server
+// keeps coherent; see NearCacheConfig.
+//
+// A client can also spread keys over several independent servers, and
+// hedge reads to their replicas; see Config.Shards and HedgePolicy.
+package stacheclient
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/cluster"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+const (
+	DefaultTimeout        = 10 * time.Second
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 50 * time.Millisecond
+	DefaultMaxBackoff     = time.Second
+
+	// maxIdleConnsPerHost is how many idle connections the default HTTP
+	// client keeps open to the server for reuse.
+	maxIdleConnsPerHost = 64
+)
+
+// Protocol is the wire protocol used to reach stached, which serves all
+// three on the same port.
+type Protocol int
+
+const (
+	Connect Protocol = iota
+	GRPC
+	GRPCWeb
+)
+
+func (p Protocol) String() string {
+	switch p {
+	case Connect:
+		return "connect"
+	case GRPC:
+		return "grpc"
+	case GRPCWeb:
+		return "grpcweb"
+	default:
+		return "unknown"
+	}
+}
+
+// RetryPolicy controls how idempotent calls are retried after failing
+// with connect.CodeUnavailable, which stached returns while a peer or
+// leader cannot be reached. Backoff doubles from InitialBackoff up to
+// MaxBackoff, with jitter.
+type RetryPolicy struct {
+	// MaxAttempts counts the first try; 1 disables retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Shard is one stached server holding a share of the keys.
+type Shard struct {
+	// Addr is the server's base URL, such as http://localhost:8080.
+	Addr string
+
+	// Replicas are read-only copies of the server (stached -replica-of)
+	// that hedged reads may go to. They can lag behind it.
+	Replicas []string
+}
+
+// Config describes the servers and how to call them. Zero fields take the
+// defaults.
+type Config struct {
+	// Addr is the server's base URL, such as http://localhost:8080.
+	// It is ignored if Shards is set.
+	Addr string
+
+	// Shards are independent servers to spread keys over by rendezvous
+	// hashing on their Addr. Every client must list the same addresses for
+	// keys to be found; the order does not matter. Calls on several keys,
+	// such as SUnion, need the keys to be on one shard, except BatchGet,
+	// which is split; Stats and InvalidateTag go to every shard.
+	Shards []Shard
+
+	Protocol Protocol
+
+	// Token, if set, is sent as a bearer token.
+	Token string
+
+	// Timeout bounds each attempt of a call, unless the context ends
+	// sooner. Negative means no timeout.
+	Timeout time.Duration
+
+	Retry RetryPolicy
+
+	// HTTPClient, if set, replaces the default client, which pools
+	// connections and speaks HTTP/2 without TLS for GRPC.
+	HTTPClient *http.Client
+
+	Breaker   BreakerPolicy
+	Hedge     HedgePolicy
+	NearCache NearCacheConfig
+
+	// Fallback, if set, lets the client keep working in a degraded mode
+	// while a server is unavailable. Values read and written through the
+	// client are copied into it, and when a call fails with ErrUnavailable,
+	// the key/value calls (Set, the Get family, BatchGet, Delete, Touch,
+	// ExpireAt, Persist, TTL and InvalidateTag) are served from it instead.
+	// Writes made meanwhile stay local: they are not sent to the server
+	// when it comes back. A cache made by stache.NewCacheWithOptions bounds
+	// the copy.
+	Fallback *stache.Cache
+}
+
+// service is the generated client for one server.
+type service = stachev1connect.CacheServiceClient
+
+// Client calls one stached server, or a set of shards. It is safe for
+// concurrent use.
+type Client struct {
+	cfg    Config
+	http   *http.Client
+	shards []*shard
+	owners *cluster.Rendezvous
+
+	// near is nil unless Config.NearCache is enabled.
+	near *nearCache
+}
+
+// New returns a client for cfg.Addr or cfg.Shards. No connection is made
+// until the first call, unless the near cache is enabled, in which case its
+// invalidation stream is opened in the background.
+func New(cfg Config) *Client {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Retry.MaxAttempts <= 0 {
+		cfg.Retry.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.Retry.InitialBackoff <= 0 {
+		cfg.Retry.InitialBackoff = DefaultInitialBackoff
+	}
+	if cfg.Retry.MaxBackoff <= 0 {
+		cfg.Retry.MaxBackoff = DefaultMaxBackoff
+	}
+	if cfg.Breaker.Threshold == 0 {
+		cfg.Breaker.Threshold = DefaultBreakerThreshold
+	}
+	if cfg.Breaker.Cooldown <= 0 {
+		cfg.Breaker.Cooldown = DefaultBreakerCooldown
+	}
+	if cfg.Breaker.Probes <= 0 {
+		cfg.Breaker.Probes = DefaultBreakerProbes
+	}
+	if cfg.NearCache.FallbackTTL <= 0 {
+		cfg.NearCache.FallbackTTL = DefaultFallbackTTL
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient(cfg.Protocol)
+	}
+
+	var opts []connect.ClientOption
+	switch cfg.Protocol {
+	case GRPC:
+		opts = append(opts, connect.WithGRPC())
+	case GRPCWeb:
+		opts = append(opts, connect.WithGRPCWeb())
+	}
+	if cfg.Token != "" {
+		opts = append(opts, connect.WithInterceptors(tokenInterceptor(cfg.Token)))
+	}
+
+	shards := cfg.Shards
+	if len(shards) == 0 {
+		shards = []Shard{{Addr: cfg.Addr}}
+	}
+
+	c := &Client{cfg: cfg, http: httpClient}
+	addrs := make([]string, 0, len(shards))
+	newEndpoint := func(addr string) *endpoint {
+		return &endpoint{
+			addr:    addr,
+			rpc:     stachev1connect.NewCacheServiceClient(httpClient, addr, opts...),
+			breaker: breaker{policy: cfg.Breaker},
+		}
+	}
+	for _, s := range shards {
+		sh := &shard{primary: newEndpoint(s.Addr)}
+		for _, addr := range s.Replicas {
+			sh.replicas = append(sh.replicas, newEndpoint(addr))
+		}
+		c.shards = append(c.shards, sh)
+		addrs = append(addrs, s.Addr)
+	}
+	c.owners = cluster.NewRendezvous(addrs...)
+
+	if cfg.NearCache.MaxEntries > 0 {
+		rpcs := make([]service, len(c.shards))
+		for i, sh := range c.shards {
+			rpcs[i] = sh.primary.rpc
+		}
+		c.near = newNearCache(cfg.NearCache, rpcs, c.owners.Index)
+	}
+	return c
+}
+
+func defaultHTTPClient(p Protocol) *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	if p == GRPC {
+		// gRPC needs HTTP/2, which stached serves without TLS as h2c.
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetHTTP2(true)
+		t.Protocols.SetUnencryptedHTTP2(true)
+	}
+	return &http.Client{Transport: t}
+}
+
+// RPC returns the underlying generated client, for the calls this package
+// does not wrap. It carries the token but not the timeout or retries.
+// With several shards, it calls the first one.
+func (c *Client) RPC() stachev1connect.CacheServiceClient {
+	return c.shards[0].primary.rpc
+}
+
+// Close stops the near cache's invalidation stream, if any, and releases
+// idle connections.
+func (c *Client) Close() {
+	if c.near != nil {
+		c.near.close()
+	}
+	c.http.CloseIdleConnections()
+}
+
+// tokenInterceptor sends a bearer token with unary and streaming calls.
+type tokenInterceptor string
+
+func (t tokenInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", "Bearer "+string(t))
+		return next(ctx, req)
+	}
+}
+
+func (t tokenInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", "Bearer "+string(t))
+		return conn
+	}
+}
+
+func (t tokenInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// method is an RPC not yet bound to a server, such as service.Get.
+type method[Req, Res any] func(service, context.Context, *connect.Request[Req]) (*connect.Response[Res], error)
+
+// call runs m on ep under the client's timeout, retrying it if idempotent.
+func call[Req, Res any](ctx context.Context, c *Client, ep *endpoint, idempotent bool, m method[Req, Res], msg *Req) (*Res, error) {
+	return callWithTimeout(ctx, c, ep, c.cfg.Timeout, idempotent, m, msg)
+}
+
+func callWithTimeout[Req, Res any](ctx context.Context, c *Client, ep *endpoint, timeout time.Duration, idempotent bool, m method[Req, Res], msg *Req) (*Res, error) {
+	backoff := c.cfg.Retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		probe, ok := ep.breaker.allow(time.Now())
+		if !ok {
+			return nil, errCircuitOpen()
+		}
+
+		actx, cancel := ctx, context.CancelFunc(func() {})
+		if timeout > 0 {
+			actx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		res, err := m(ep.rpc, actx, connect.NewRequest(msg))
+		cancel()
+		ep.breaker.record(probe, classify(ctx, err))
+		if err == nil {
+			return res.Msg, nil
+		}
+
+		if !idempotent || attempt >= c.cfg.Retry.MaxAttempts || connect.CodeOf(err) != connect.CodeUnavailable {
+			return nil, mapError(err)
+		}
+
+		t := time.NewTimer(backoff/2 + rand.N(backoff/2+1))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, mapError(err)
+		case <-t.C:
+		}
+		backoff = min(2*backoff, c.cfg.Retry.MaxBackoff)
+	}
+}
+
+// Error is a server error that matches a stache sentinel error. Both
+// errors.Is(err, stache.ErrNotFound) and connect.CodeOf(err) work on it.
+type Error struct {
+	// Err is the stache error the server reported.
+	Err error
+
+	// Cause is the error as received.
+	Cause *connect.Error
+}
+
+func (e *Error) Error() string   { return e.Cause.Error() }
+func (e *Error) Unwrap() []error { return []error{e.Err, e.Cause} }
+
+// ErrUnavailable is returned when a server cannot be reached: by calls that
+// still fail with connect.CodeUnavailable after their retries, and at once
+// by calls to a server whose circuit breaker is open.
+var ErrUnavailable = errors.New("server unavailable")
+
+// sentinels are the stache errors stached reports as FailedPrecondition,
+// told apart by their message.
+var sentinels = []error{stache.ErrIncorrectType, stache.ErrNotInteger, stache.ErrOverflow, stache.ErrConflict}
+
+// mapError turns a Connect error back into the stache or context error
+// the server reported, where there is one.
+func mapError(err error) error {
+	var ce *connect.Error
+	if !errors.As(err, &ce) {
+		return err
+	}
+
+	var target error
+	switch ce.Code() {
+	case connect.CodeNotFound:
+		target = stache.ErrNotFound
+	case connect.CodeUnavailable:
+		target = ErrUnavailable
+	case connect.CodeFailedPrecondition:
+		for _, s := range sentinels {
+			if ce.Message() == s.Error() {
+				target = s
+			}
+		}
+	case connect.CodeDeadlineExceeded:
+		target = context.DeadlineExceeded
+	case connect.CodeCanceled:
+		target = context.Canceled
+	}
+
+	if target == nil || errors.Is(ce, target) {
+		return err
+	}
+	return &Error{Err: target, Cause: ce}
+}
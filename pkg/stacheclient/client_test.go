@@ -0,0 +1,566 @@
+package stacheclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/api/stache/v1/stachev1connect"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// fakeServer serves a few RPCs from a local cache, mapping errors the way
+// stached does, and can fail calls on demand.
+type fakeServer struct {
+	cache *stache.Cache
+	token string
+
+	// unavailable is how many more calls fail with CodeUnavailable.
+	unavailable atomic.Int32
+	calls       atomic.Int32
+
+	// Invalidations streams are refused while refuse is set, and end when
+	// drop is sent to.
+	refuse atomic.Bool
+	drop   chan struct{}
+
+	// hang makes Gets wait for their context to end.
+	hang atomic.Bool
+	stachev1connect.UnimplementedCacheServiceHandler
+}
+
+func (s *fakeServer) check(h http.Header) error {
+	s.calls.Add(1)
+	if s.token != "" && h.Get("Authorization") != "Bearer "+s.token {
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("bad token"))
+	}
+	if s.unavailable.Add(-1) >= 0 {
+		return connect.NewError(connect.CodeUnavailable, errors.New("try again"))
+	}
+	return nil
+}
+
+func serverError(err error) error {
+	switch {
+	case errors.Is(err, stache.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, stache.ErrIncorrectType), errors.Is(err, stache.ErrNotInteger):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+func (s *fakeServer) Set(ctx context.Context, req *connect.Request[stachev1.SetRequest]) (*connect.Response[stachev1.SetResponse], error) {
+	if err := s.check(req.Header()); err != nil {
+		return nil, err
+	}
+
+	r := req.Msg
+	meta := stache.Meta{TTL: r.GetTtlDuration().AsDuration(), ContentType: stache.ContentType(r.GetContentType())}
+	if err := s.cache.Set(r.GetKey(), r.GetValue(), meta); err != nil {
+		return nil, serverError(err)
+	}
+	return connect.NewResponse(&stachev1.SetResponse{}), nil
+}
+
+func (s *fakeServer) Get(ctx context.Context, req *connect.Request[stachev1.GetRequest]) (*connect.Response[stachev1.GetResponse], error) {
+	if err := s.check(req.Header()); err != nil {
+		return nil, err
+	}
+
+	if req.Msg.GetKey() == "slow" || s.hang.Load() {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	b, err := s.cache.GetBytes(req.Msg.GetKey())
+	if err != nil {
+		return nil, serverError(err)
+	}
+	info, _ := s.cache.GetEntry(req.Msg.GetKey())
+	ct := string(info.ContentType)
+	var expMs int64
+	if !info.ExpiresAt.IsZero() {
+		expMs = info.ExpiresAt.UnixMilli()
+	}
+	tracked := req.Msg.GetTrackingId() != 0
+	return connect.NewResponse(&stachev1.GetResponse{Value: b, ContentType: &ct, ExpiresAtMs: &expMs, Tracked: &tracked}), nil
+}
+
+// Invalidations reports every change to every stream, which is coarser
+// than stached's tracking but keeps the same promise.
+func (s *fakeServer) Invalidations(ctx context.Context, req *connect.Request[stachev1.InvalidationsRequest], stream *connect.ServerStream[stachev1.InvalidationEvent]) error {
+	if s.refuse.Load() {
+		return connect.NewError(connect.CodeUnavailable, errors.New("no streams"))
+	}
+
+	keys := make(chan string, 64)
+	cancel := s.cache.Subscribe(func(ev stache.Event) { keys <- ev.Item.Key })
+	defer cancel()
+
+	id := uint64(1)
+	if err := stream.Send(&stachev1.InvalidationEvent{TrackingId: &id}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.drop:
+			return connect.NewError(connect.CodeUnavailable, errors.New("dropped"))
+		case key := <-keys:
+			if err := stream.Send(&stachev1.InvalidationEvent{Keys: []string{key}}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *fakeServer) Delete(ctx context.Context, req *connect.Request[stachev1.DeleteRequest]) (*connect.Response[stachev1.DeleteResponse], error) {
+	if err := s.check(req.Header()); err != nil {
+		return nil, err
+	}
+
+	_, deleted := s.cache.Delete(req.Msg.GetKey())
+	return connect.NewResponse(&stachev1.DeleteResponse{Deleted: &deleted}), nil
+}
+
+func (s *fakeServer) BatchGet(ctx context.Context, req *connect.Request[stachev1.BatchGetRequest]) (*connect.Response[stachev1.BatchGetResponse], error) {
+	if err := s.check(req.Header()); err != nil {
+		return nil, err
+	}
+
+	var items []*stachev1.GetResponseItem
+	for _, key := range req.Msg.GetKeys() {
+		b, err := s.cache.GetBytes(key)
+		found := err == nil
+		items = append(items, &stachev1.GetResponseItem{Key: &key, Value: b, Found: &found})
+	}
+	return connect.NewResponse(&stachev1.BatchGetResponse{Items: items}), nil
+}
+
+func (s *fakeServer) Stats(ctx context.Context, req *connect.Request[stachev1.StatsRequest]) (*connect.Response[stachev1.StatsResponse], error) {
+	if err := s.check(req.Header()); err != nil {
+		return nil, err
+	}
+
+	entries := uint64(s.cache.Len())
+	return connect.NewResponse(&stachev1.StatsResponse{Entries: &entries}), nil
+}
+
+func (s *fakeServer) HIncrBy(ctx context.Context, req *connect.Request[stachev1.HIncrByRequest]) (*connect.Response[stachev1.HIncrByResponse], error) {
+	if err := s.check(req.Header()); err != nil {
+		return nil, err
+	}
+
+	n, err := s.cache.HIncrBy(req.Msg.GetKey(), req.Msg.GetField(), req.Msg.GetDelta())
+	if err != nil {
+		return nil, serverError(err)
+	}
+	return connect.NewResponse(&stachev1.HIncrByResponse{Value: &n}), nil
+}
+
+func startServer(t *testing.T, token string) (*fakeServer, string) {
+	t.Helper()
+
+	s := &fakeServer{cache: stache.NewCache(), token: token, drop: make(chan struct{})}
+	mux := http.NewServeMux()
+	mux.Handle(stachev1connect.NewCacheServiceHandler(s))
+
+	ts := httptest.NewUnstartedServer(mux)
+	ts.Config.Protocols = new(http.Protocols)
+	ts.Config.Protocols.SetHTTP1(true)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	return s, ts.URL
+}
+
+func TestProtocols(t *testing.T) {
+	ctx := context.Background()
+	_, url := startServer(t, "secret")
+
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	for _, p := range []Protocol{Connect, GRPC, GRPCWeb} {
+		t.Run(p.String(), func(t *testing.T) {
+			c := New(Config{Addr: url, Protocol: p, Token: "secret"})
+			defer c.Close()
+
+			if err := c.SetJSON(ctx, "user", user{42, "DaBaby"}, time.Minute); err != nil {
+				t.Fatalf("SetJSON: %v", err)
+			}
+			var got user
+			if err := c.GetJSON(ctx, "user", &got); err != nil || got != (user{42, "DaBaby"}) {
+				t.Fatalf("GetJSON: got=%v err=%v", got, err)
+			}
+
+			if _, err := c.GetString(ctx, "user"); !errors.Is(err, stache.ErrIncorrectType) {
+				t.Fatalf("GetString on JSON: got=%v want ErrIncorrectType", err)
+			}
+
+			_, err := c.GetBytes(ctx, "missing")
+			if !errors.Is(err, stache.ErrNotFound) || connect.CodeOf(err) != connect.CodeNotFound {
+				t.Fatalf("GetBytes on a missing key: got=%v want ErrNotFound", err)
+			}
+		})
+	}
+
+	c := New(Config{Addr: url, Token: "wrong"})
+	if _, err := c.GetBytes(ctx, "user"); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("wrong token: got=%v want Unauthenticated", err)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	ctx := context.Background()
+	_, url := startServer(t, "")
+	c := New(Config{Addr: url})
+
+	if err := c.SetString(ctx, "name", "dababy", 0); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+	if _, err := c.HIncrBy(ctx, "name", "visits", 1); !errors.Is(err, stache.ErrIncorrectType) {
+		t.Fatalf("HIncrBy on a string: got=%v want ErrIncorrectType", err)
+	}
+
+	var cerr *Error
+	if _, err := c.HIncrBy(ctx, "name", "visits", 1); !errors.As(err, &cerr) || cerr.Cause.Code() != connect.CodeFailedPrecondition {
+		t.Fatalf("HIncrBy error: got=%#v want *Error with FailedPrecondition", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+	s, url := startServer(t, "")
+	c := New(Config{Addr: url, Retry: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}})
+
+	if err := c.SetString(ctx, "k", "v", 0); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+
+	// An idempotent call is retried through brief unavailability
+	s.unavailable.Store(2)
+	s.calls.Store(0)
+	if v, err := c.GetString(ctx, "k"); err != nil || v != "v" {
+		t.Fatalf("GetString after retries: got=%q err=%v", v, err)
+	}
+	if n := s.calls.Load(); n != 3 {
+		t.Fatalf("attempts: got=%d want=3", n)
+	}
+
+	// but gives up after MaxAttempts
+	s.unavailable.Store(3)
+	s.calls.Store(0)
+	if _, err := c.GetString(ctx, "k"); connect.CodeOf(err) != connect.CodeUnavailable || s.calls.Load() != 3 {
+		t.Fatalf("GetString while unavailable: err=%v attempts=%d", err, s.calls.Load())
+	}
+
+	// and a call that is not idempotent is tried once
+	s.unavailable.Store(1)
+	s.calls.Store(0)
+	if _, err := c.HIncrBy(ctx, "h", "f", 1); connect.CodeOf(err) != connect.CodeUnavailable || s.calls.Load() != 1 {
+		t.Fatalf("HIncrBy while unavailable: err=%v attempts=%d", err, s.calls.Load())
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	_, url := startServer(t, "")
+	c := New(Config{Addr: url, Timeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	_, err := c.GetBytes(context.Background(), "slow")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetBytes past the timeout: got=%v want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("timeout took %v", elapsed)
+	}
+
+	// A shorter deadline on the context wins.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c = New(Config{Addr: url, Timeout: time.Minute})
+	if _, err := c.GetBytes(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetBytes past the context deadline: got=%v want DeadlineExceeded", err)
+	}
+}
+
+func TestNearCache(t *testing.T) {
+	ctx := context.Background()
+	s, url := startServer(t, "")
+	for _, key := range []string{"k", "a", "b", "c"} {
+		_ = s.cache.SetString(key, "v1", 0)
+	}
+	c := New(Config{Addr: url, NearCache: NearCacheConfig{MaxEntries: 2, FallbackTTL: 100 * time.Millisecond}})
+	defer c.Close()
+
+	tracking := func() bool {
+		c.near.mutex.RLock()
+		defer c.near.mutex.RUnlock()
+		return c.near.trackingIDs[0] != 0
+	}
+	eventually(t, "invalidation stream", tracking)
+
+	s.calls.Store(0)
+	for range 3 {
+		if v, err := c.GetString(ctx, "k"); err != nil || v != "v1" {
+			t.Fatalf("GetString: got=%q err=%v", v, err)
+		}
+	}
+	if n := s.calls.Load(); n != 1 {
+		t.Fatalf("server calls for repeated reads: got=%d want=1", n)
+	}
+
+	// A change on the server is pushed to the client
+	_ = s.cache.SetString("k", "v2", 0)
+	eventually(t, "invalidation", func() bool {
+		v, _ := c.GetString(ctx, "k")
+		return v == "v2"
+	})
+
+	// and a write through the client is seen at once
+	if err := c.SetString(ctx, "k", "v3", 0); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+	if v, _ := c.GetString(ctx, "k"); v != "v3" {
+		t.Fatalf("GetString after own write: got=%q want=v3", v)
+	}
+
+	// The near cache is bounded
+	for _, key := range []string{"a", "b", "c"} {
+		_, _ = c.GetString(ctx, key)
+	}
+	if st := c.NearCacheStats(); st.Entries != 2 || st.Evictions == 0 {
+		t.Fatalf("NearCacheStats: %+v", st)
+	}
+
+	// Without the stream, entries live at most FallbackTTL
+	s.refuse.Store(true)
+	s.drop <- struct{}{}
+	eventually(t, "disconnect", func() bool { return !tracking() })
+	_, _ = c.GetString(ctx, "a")
+	_ = s.cache.SetString("a", "v2", 0)
+	eventually(t, "fallback expiry", func() bool {
+		v, _ := c.GetString(ctx, "a")
+		return v == "v2"
+	})
+
+	s.refuse.Store(false)
+	eventually(t, "reconnect", tracking)
+}
+
+func TestShards(t *testing.T) {
+	ctx := context.Background()
+	s1, url1 := startServer(t, "")
+	s2, url2 := startServer(t, "")
+	c := New(Config{Shards: []Shard{{Addr: url1}, {Addr: url2}}})
+
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = "key:" + strconv.Itoa(i)
+		if err := c.SetString(ctx, keys[i], keys[i], 0); err != nil {
+			t.Fatalf("SetString: %v", err)
+		}
+	}
+
+	// Each key lives on exactly one shard, and a client listing the shards
+	// in another order finds it
+	if n1, n2 := s1.cache.Len(), s2.cache.Len(); n1+n2 != len(keys) || n1 < 30 || n2 < 30 {
+		t.Fatalf("keys per shard: %d and %d", n1, n2)
+	}
+	other := New(Config{Shards: []Shard{{Addr: url2}, {Addr: url1}}})
+	for _, key := range keys {
+		if v, err := other.GetString(ctx, key); err != nil || v != key {
+			t.Fatalf("GetString(%q) with reordered shards: got=%q err=%v", key, v, err)
+		}
+	}
+
+	got, err := c.BatchGet(ctx, append(keys, "missing")...)
+	if err != nil || len(got) != len(keys) {
+		t.Fatalf("BatchGet: got %d items, err=%v", len(got), err)
+	}
+	for _, key := range keys {
+		if string(got[key].Value) != key {
+			t.Fatalf("BatchGet[%q]: got=%q", key, got[key].Value)
+		}
+	}
+
+	if st, err := c.Stats(ctx); err != nil || st.Entries != len(keys) {
+		t.Fatalf("Stats: got=%+v err=%v", st, err)
+	}
+
+	if _, err := c.SUnion(ctx, keys...); !errors.Is(err, ErrCrossShard) {
+		t.Fatalf("SUnion across shards: got=%v want ErrCrossShard", err)
+	}
+}
+
+func TestHedging(t *testing.T) {
+	ctx := context.Background()
+	primary, url1 := startServer(t, "")
+	replica, url2 := startServer(t, "")
+	_ = primary.cache.SetString("k", "v", 0)
+	_ = replica.cache.SetString("k", "v", 0)
+
+	c := New(Config{
+		Shards: []Shard{{Addr: url1, Replicas: []string{url2}}},
+		Hedge:  HedgePolicy{Percentile: 0.9, MinDelay: time.Millisecond},
+	})
+
+	for range latencyMinSamples {
+		if _, err := c.GetString(ctx, "k"); err != nil {
+			t.Fatalf("GetString: %v", err)
+		}
+	}
+	if n := replica.calls.Load(); n != 0 {
+		t.Fatalf("replica calls before hedging starts: got=%d want=0", n)
+	}
+
+	// A stuck primary is hedged around
+	primary.hang.Store(true)
+	start := time.Now()
+	if v, err := c.GetString(ctx, "k"); err != nil || v != "v" {
+		t.Fatalf("GetString with a stuck primary: got=%q err=%v", v, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second || replica.calls.Load() != 1 {
+		t.Fatalf("hedged read took %v with %d replica calls", elapsed, replica.calls.Load())
+	}
+
+	// and so is an unavailable one, at once
+	primary.hang.Store(false)
+	primary.unavailable.Store(DefaultMaxAttempts)
+	if v, err := c.GetString(ctx, "k"); err != nil || v != "v" {
+		t.Fatalf("GetString with an unavailable primary: got=%q err=%v", v, err)
+	}
+
+	// Writes are not hedged
+	primary.unavailable.Store(DefaultMaxAttempts)
+	if err := c.SetString(ctx, "k", "v2", 0); connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Fatalf("SetString with an unavailable primary: got=%v want Unavailable", err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	s, url := startServer(t, "")
+	_ = s.cache.SetString("k", "v", 0)
+	c := New(Config{
+		Addr:    url,
+		Retry:   RetryPolicy{MaxAttempts: 1},
+		Breaker: BreakerPolicy{Threshold: 3, Cooldown: 50 * time.Millisecond},
+	})
+
+	// Server errors are answers, not failures
+	for range 5 {
+		if _, err := c.GetString(ctx, "missing"); !errors.Is(err, stache.ErrNotFound) {
+			t.Fatalf("GetString(missing): %v", err)
+		}
+	}
+	if st := c.Breakers()[url]; st != BreakerClosed {
+		t.Fatalf("breaker after NotFound: got=%v want=closed", st)
+	}
+
+	s.unavailable.Store(3)
+	for range 3 {
+		if _, err := c.GetString(ctx, "k"); !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("GetString while unavailable: got=%v want ErrUnavailable", err)
+		}
+	}
+	if st := c.Breakers()[url]; st != BreakerOpen {
+		t.Fatalf("breaker after failures: got=%v want=open", st)
+	}
+
+	// An open breaker fails fast without calling the server
+	s.calls.Store(0)
+	if _, err := c.GetString(ctx, "k"); !errors.Is(err, ErrUnavailable) || connect.CodeOf(err) != connect.CodeUnavailable || s.calls.Load() != 0 {
+		t.Fatalf("GetString with the breaker open: err=%v calls=%d", err, s.calls.Load())
+	}
+
+	// A failed probe opens it again
+	time.Sleep(60 * time.Millisecond)
+	s.unavailable.Store(1)
+	if _, err := c.GetString(ctx, "k"); !errors.Is(err, ErrUnavailable) || s.calls.Load() != 1 {
+		t.Fatalf("failed probe: err=%v calls=%d", err, s.calls.Load())
+	}
+	if _, err := c.GetString(ctx, "k"); s.calls.Load() != 1 {
+		t.Fatalf("GetString after a failed probe: err=%v calls=%d", err, s.calls.Load())
+	}
+
+	// and a successful one closes it
+	time.Sleep(60 * time.Millisecond)
+	if v, err := c.GetString(ctx, "k"); err != nil || v != "v" {
+		t.Fatalf("probe: got=%q err=%v", v, err)
+	}
+	if st := c.Breakers()[url]; st != BreakerClosed {
+		t.Fatalf("breaker after a successful probe: got=%v want=closed", st)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	ctx := context.Background()
+	s, url := startServer(t, "")
+	fallback := stache.NewCache()
+	c := New(Config{Addr: url, Retry: RetryPolicy{MaxAttempts: 1}, Fallback: fallback})
+
+	_ = s.cache.SetString("read", "r", 0)
+	if err := c.SetString(ctx, "written", "w", 0); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+	if v, err := c.GetString(ctx, "read"); err != nil || v != "r" {
+		t.Fatalf("GetString: got=%q err=%v", v, err)
+	}
+
+	// While the server is down, reads and writes go to the fallback
+	s.unavailable.Store(1 << 20)
+	for key, want := range map[string]string{"read": "r", "written": "w"} {
+		if v, err := c.GetString(ctx, key); err != nil || v != want {
+			t.Fatalf("degraded GetString(%q): got=%q err=%v", key, v, err)
+		}
+	}
+	if err := c.SetString(ctx, "offline", "o", 0); err != nil {
+		t.Fatalf("degraded SetString: %v", err)
+	}
+	if v, err := c.GetString(ctx, "offline"); err != nil || v != "o" {
+		t.Fatalf("GetString after a degraded write: got=%q err=%v", v, err)
+	}
+	if ok, err := c.Delete(ctx, "read"); err != nil || !ok {
+		t.Fatalf("degraded Delete: ok=%v err=%v", ok, err)
+	}
+	if got, err := c.BatchGet(ctx, "read", "written", "offline"); err != nil || len(got) != 2 {
+		t.Fatalf("degraded BatchGet: got=%v err=%v", got, err)
+	}
+
+	// Keys the fallback never saw still report the outage
+	if _, err := c.GetString(ctx, "unknown"); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("degraded GetString(unknown): got=%v want ErrUnavailable", err)
+	}
+	if _, err := c.HGetAll(ctx, "h"); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("degraded HGetAll: got=%v want ErrUnavailable", err)
+	}
+}
+
+// eventually polls cond until it holds or a few seconds pass.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
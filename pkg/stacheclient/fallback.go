@@ -0,0 +1,80 @@
+package stacheclient
+
+import (
+	"errors"
+	"time"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// degraded reports whether a call that failed with err should be served
+// from Config.Fallback.
+func (c *Client) degraded(err error) bool {
+	return c.cfg.Fallback != nil && errors.Is(err, ErrUnavailable)
+}
+
+// mirror applies a write to Config.Fallback after the server call that
+// returned err, and returns the error to report: nil if the server was
+// unavailable and the write was applied locally instead.
+func (c *Client) mirror(err error, apply func(f *stache.Cache) error) error {
+	switch {
+	case c.cfg.Fallback == nil:
+		return err
+	case err == nil:
+		_ = apply(c.cfg.Fallback)
+		return nil
+	case c.degraded(err):
+		if apply(c.cfg.Fallback) != nil {
+			return err
+		}
+		return nil
+	default:
+		return err
+	}
+}
+
+// remember copies a value read from a server into Config.Fallback.
+func (c *Client) remember(key string, value []byte, ct string, expMs int64) {
+	if c.cfg.Fallback == nil {
+		return
+	}
+
+	meta := stache.Meta{ContentType: stache.ContentType(ct)}
+	if expMs != 0 {
+		meta.ExpiresAt = time.UnixMilli(expMs)
+		if !meta.ExpiresAt.After(time.Now()) {
+			return
+		}
+	}
+	_ = c.cfg.Fallback.Set(key, value, meta)
+}
+
+// fallbackGet serves a Get from Config.Fallback, returning err, the
+// server's error, if the key is not there.
+func (c *Client) fallbackGet(key string, err error) (*stachev1.GetResponse, error) {
+	b, info, ferr := c.cfg.Fallback.GetWithInfo(key)
+	if ferr != nil {
+		return nil, err
+	}
+
+	ct := string(info.ContentType)
+	return &stachev1.GetResponse{Value: b, ContentType: &ct}, nil
+}
+
+// fallbackItems returns the entries of Config.Fallback stored under keys.
+func (c *Client) fallbackItems(keys []string) []stache.Item {
+	var items []stache.Item
+	for _, key := range keys {
+		b, info, err := c.cfg.Fallback.GetWithInfo(key)
+		if err != nil {
+			continue
+		}
+		items = append(items, stache.Item{
+			Key:   key,
+			Value: b,
+			Meta:  stache.Meta{ContentType: info.ContentType, ExpiresAt: info.ExpiresAt},
+		})
+	}
+	return items
+}
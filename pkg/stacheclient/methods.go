@@ -0,0 +1,293 @@
+package stacheclient
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// Set stores data under key with the given metadata, like stache.Cache.Set.
+func (c *Client) Set(ctx context.Context, key string, data []byte, meta stache.Meta) error {
+	ct := string(meta.ContentType)
+	if ct == "" {
+		ct = string(stache.Text)
+	}
+
+	req := &stachev1.SetRequest{
+		Key:         &key,
+		Value:       data,
+		ContentType: &ct,
+		Sliding:     &meta.Sliding,
+		Tags:        meta.Tags,
+	}
+	if meta.TTL > 0 {
+		req.TtlDuration = durationpb.New(meta.TTL)
+	}
+	if !meta.ExpiresAt.IsZero() {
+		req.ExpiresAt = timestamppb.New(meta.ExpiresAt)
+	}
+
+	_, err := call(ctx, c, c.shard(key).primary, true, service.Set, req)
+	c.invalidate(key)
+	return c.mirror(err, func(f *stache.Cache) error { return f.Set(key, data, meta) })
+}
+
+// SetJSON marshals data to JSON and stores it under key.
+// The entry will expire after ttl, unless ttl <= 0 (no expiry).
+func (c *Client) SetJSON(ctx context.Context, key string, data any, ttl time.Duration) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return c.Set(ctx, key, b, stache.Meta{TTL: ttl, ContentType: stache.JSON})
+}
+
+// SetString stores a string under key.
+// The entry will expire after ttl, unless ttl <= 0 (no expiry).
+func (c *Client) SetString(ctx context.Context, key string, data string, ttl time.Duration) error {
+	return c.Set(ctx, key, []byte(data), stache.Meta{TTL: ttl, ContentType: stache.Text})
+}
+
+// get reads key through the near cache, if enabled, and falls back to
+// Config.Fallback while the server is unavailable.
+func (c *Client) get(ctx context.Context, key string) (*stachev1.GetResponse, error) {
+	if c.near != nil {
+		if res, ok := c.near.lookup(key); ok {
+			return res, nil
+		}
+	}
+
+	res, err := c.fetch(ctx, key)
+	if err == nil {
+		c.remember(key, res.GetValue(), res.GetContentType(), res.GetExpiresAtMs())
+	} else if c.degraded(err) {
+		return c.fallbackGet(key, err)
+	}
+	return res, err
+}
+
+// fetch reads key from its shard, tracking it for the near cache.
+func (c *Client) fetch(ctx context.Context, key string) (*stachev1.GetResponse, error) {
+	sh := c.shard(key)
+	if c.near == nil {
+		return read(ctx, c, sh, service.Get, &stachev1.GetRequest{Key: &key})
+	}
+
+	// The tracking id is only known to sh's server, so a hedged read
+	// goes out without it.
+	id, gen := c.near.begin(key)
+	req, plain := &stachev1.GetRequest{Key: &key}, &stachev1.GetRequest{Key: &key}
+	if id != 0 {
+		req.TrackingId = &id
+	}
+	res, err := hedged(ctx, c, sh, service.Get, req, plain)
+	c.near.end(key, id, gen, res)
+
+	return res, err
+}
+
+// GetBytes returns the value stored under key.
+// If the key does not exist or is expired, stache.ErrNotFound is returned.
+func (c *Client) GetBytes(ctx context.Context, key string) ([]byte, error) {
+	res, err := c.get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetValue(), nil
+}
+
+// GetString returns the string stored under key.
+// If the entry is not of type Text, stache.ErrIncorrectType is returned.
+func (c *Client) GetString(ctx context.Context, key string) (string, error) {
+	res, err := c.get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	if stache.ContentType(res.GetContentType()) != stache.Text {
+		return "", stache.ErrIncorrectType
+	}
+	return string(res.GetValue()), nil
+}
+
+// GetJSON unmarshals the JSON value stored under key into out, which must
+// be a pointer. If the entry is not of type JSON, stache.ErrIncorrectType
+// is returned.
+func (c *Client) GetJSON(ctx context.Context, key string, out any) error {
+	res, err := c.get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if stache.ContentType(res.GetContentType()) != stache.JSON {
+		return stache.ErrIncorrectType
+	}
+	return json.Unmarshal(res.GetValue(), out)
+}
+
+// BatchGet returns the entries stored under keys, by key; missing and
+// expired keys are left out. With several shards, the keys are split by
+// shard and fetched at once. It does not use the near cache, but the keys
+// of an unavailable shard are looked up in Config.Fallback.
+func (c *Client) BatchGet(ctx context.Context, keys ...string) (map[string]stache.Item, error) {
+	byShard := make([][]string, len(c.shards))
+	for _, key := range keys {
+		i := c.owners.Index(key)
+		byShard[i] = append(byShard[i], key)
+	}
+
+	results := make([][]stache.Item, len(c.shards))
+	err := c.eachShard(func(i int, sh *shard) error {
+		if len(byShard[i]) == 0 {
+			return nil
+		}
+
+		res, err := read(ctx, c, sh, service.BatchGet, &stachev1.BatchGetRequest{Keys: byShard[i]})
+		if c.degraded(err) {
+			results[i] = c.fallbackItems(byShard[i])
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for _, it := range res.GetItems() {
+			if !it.GetFound() {
+				continue
+			}
+			c.remember(it.GetKey(), it.GetValue(), it.GetContentType(), it.GetExpiresAtMs())
+			item := stache.Item{Key: it.GetKey(), Value: it.GetValue()}
+			item.Meta.ContentType = stache.ContentType(it.GetContentType())
+			if ms := it.GetExpiresAtMs(); ms != 0 {
+				item.Meta.ExpiresAt = time.UnixMilli(ms)
+			}
+			results[i] = append(results[i], item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]stache.Item, len(keys))
+	for _, items := range results {
+		for _, it := range items {
+			out[it.Key] = it
+		}
+	}
+	return out, nil
+}
+
+// Delete removes key and reports whether it existed.
+func (c *Client) Delete(ctx context.Context, key string) (bool, error) {
+	res, err := call(ctx, c, c.shard(key).primary, true, service.Delete, &stachev1.DeleteRequest{Key: &key})
+	c.invalidate(key)
+
+	if c.cfg.Fallback != nil && (err == nil || c.degraded(err)) {
+		_, ok := c.cfg.Fallback.Delete(key)
+		if err != nil {
+			return ok, nil
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	return res.GetDeleted(), nil
+}
+
+// Touch resets the expiry of key to ttl from now, without rewriting its
+// value. If ttl <= 0, the entry no longer expires.
+func (c *Client) Touch(ctx context.Context, key string, ttl time.Duration) error {
+	req := &stachev1.TouchRequest{Key: &key, TtlDuration: durationpb.New(max(ttl, 0))}
+	_, err := call(ctx, c, c.shard(key).primary, true, service.Touch, req)
+	c.invalidate(key)
+	return c.mirror(err, func(f *stache.Cache) error { return f.Touch(key, ttl) })
+}
+
+// ExpireAt sets an absolute expiry for key.
+func (c *Client) ExpireAt(ctx context.Context, key string, t time.Time) error {
+	req := &stachev1.TouchRequest{Key: &key, ExpiresAt: timestamppb.New(t)}
+	_, err := call(ctx, c, c.shard(key).primary, true, service.Touch, req)
+	c.invalidate(key)
+	return c.mirror(err, func(f *stache.Cache) error { return f.ExpireAt(key, t) })
+}
+
+// Persist removes the expiry of key.
+func (c *Client) Persist(ctx context.Context, key string) error {
+	return c.Touch(ctx, key, 0)
+}
+
+// TTL returns the time left before key expires, or 0 if it never does.
+func (c *Client) TTL(ctx context.Context, key string) (time.Duration, error) {
+	res, err := read(ctx, c, c.shard(key), service.GetTTL, &stachev1.GetTTLRequest{Key: &key})
+	if c.degraded(err) {
+		if ttl, ferr := c.cfg.Fallback.TTL(key); ferr == nil {
+			return ttl, nil
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(res.GetTtlMs()) * time.Millisecond, nil
+}
+
+// InvalidateTag removes every entry tagged with any of tags, on every
+// shard, and returns how many were removed.
+func (c *Client) InvalidateTag(ctx context.Context, tags ...string) (int, error) {
+	removed := make([]int, len(c.shards))
+	err := c.eachShard(func(i int, sh *shard) error {
+		res, err := call(ctx, c, sh.primary, true, service.InvalidateTags, &stachev1.InvalidateTagsRequest{Tags: tags})
+		removed[i] = int(res.GetRemoved())
+		return err
+	})
+	if c.near != nil {
+		// The near cache does not know the tags of its entries.
+		c.near.flush(-1)
+	}
+	if c.cfg.Fallback != nil && (err == nil || c.degraded(err)) {
+		local := 0
+		for _, tag := range tags {
+			local += c.cfg.Fallback.InvalidateTag(tag)
+		}
+		if err != nil {
+			return local, nil
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, n := range removed {
+		total += n
+	}
+	return total, nil
+}
+
+// Stats returns a summary of the server's cache, summed over the shards.
+func (c *Client) Stats(ctx context.Context) (stache.Stats, error) {
+	stats := make([]*stachev1.StatsResponse, len(c.shards))
+	err := c.eachShard(func(i int, sh *shard) error {
+		var err error
+		stats[i], err = call(ctx, c, sh.primary, true, service.Stats, &stachev1.StatsRequest{})
+		return err
+	})
+	if err != nil {
+		return stache.Stats{}, err
+	}
+
+	var st stache.Stats
+	for _, res := range stats {
+		st.Entries += int(res.GetEntries())
+		st.Bytes += int(res.GetBytes())
+		st.Hits += res.GetHits()
+		st.Misses += res.GetMisses()
+	}
+	return st, nil
+}
@@ -0,0 +1,281 @@
+package stacheclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+const (
+	DefaultFallbackTTL = time.Second
+
+	// nearCacheWatchdog is how long the invalidation stream may stay
+	// silent, heartbeats included, before it is taken to be lost.
+	nearCacheWatchdog = 5 * time.Second
+
+	nearCacheMinBackoff = 100 * time.Millisecond
+	nearCacheMaxBackoff = 5 * time.Second
+)
+
+// NearCacheConfig enables a bounded local cache of the values read with
+// GetBytes, GetString and GetJSON. The client keeps it coherent through
+// each server's Invalidations stream: keys read while the stream is up are
+// tracked, and dropped locally as soon as the server reports a change.
+// Writes made through the client drop the key at once.
+//
+// Values that are not tracked are kept for at most FallbackTTL: those read
+// while the stream is down, through another node in cluster mode, or with
+// sliding expiry. When the stream drops, every local entry is cut to
+// FallbackTTL as well, since changes made meanwhile go unreported.
+type NearCacheConfig struct {
+	// MaxEntries bounds the near cache, which is disabled if it is 0.
+	MaxEntries int
+
+	FallbackTTL time.Duration
+}
+
+type nearCache struct {
+	cfg   NearCacheConfig
+	local *stache.Cache
+
+	// mutex orders stores against invalidations; lookups share it.
+	mutex sync.RWMutex
+
+	// trackingIDs are from each shard's current Invalidations stream, or
+	// 0 while it is down; shardOf gives the shard of a key.
+	trackingIDs []uint64
+	shardOf     func(key string) int
+	fetches     map[string]*fetch
+
+	stop context.CancelFunc
+	done sync.WaitGroup
+}
+
+// fetch counts the Gets of a key in flight. gen changes when the key is
+// invalidated, so that a value read before the change is not stored.
+type fetch struct {
+	refs int
+	gen  uint64
+}
+
+// newNearCache starts following the Invalidations stream of each of rpcs,
+// the shards' servers.
+func newNearCache(cfg NearCacheConfig, rpcs []service, shardOf func(string) int) *nearCache {
+	ctx, stop := context.WithCancel(context.Background())
+	n := &nearCache{
+		cfg:         cfg,
+		local:       stache.NewCacheWithOptions(stache.Options{MaxEntries: cfg.MaxEntries}),
+		trackingIDs: make([]uint64, len(rpcs)),
+		shardOf:     shardOf,
+		fetches:     map[string]*fetch{},
+		stop:        stop,
+	}
+	for i, rpc := range rpcs {
+		n.done.Go(func() { n.run(ctx, i, rpc) })
+	}
+
+	return n
+}
+
+func (n *nearCache) close() {
+	n.stop()
+	n.done.Wait()
+}
+
+// lookup returns the local copy of key, if any.
+func (n *nearCache) lookup(key string) (*stachev1.GetResponse, bool) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	b, info, err := n.local.GetWithInfo(key)
+	if err != nil {
+		return nil, false
+	}
+
+	ct := string(info.ContentType)
+	return &stachev1.GetResponse{Value: b, ContentType: &ct}, true
+}
+
+// begin registers a Get of key, to be sent with the returned tracking id,
+// and must be followed by end.
+func (n *nearCache) begin(key string) (id uint64, gen uint64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	f, ok := n.fetches[key]
+	if !ok {
+		f = &fetch{}
+		n.fetches[key] = f
+	}
+	f.refs++
+
+	return n.trackingIDs[n.shardOf(key)], f.gen
+}
+
+// end stores res, the result of the Get begun with id and gen, unless the
+// key was invalidated meanwhile. res is nil if the Get failed.
+func (n *nearCache) end(key string, id uint64, gen uint64, res *stachev1.GetResponse) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	f := n.fetches[key]
+	if f.refs--; f.refs == 0 {
+		delete(n.fetches, key)
+	}
+	if res == nil || f.gen != gen {
+		return
+	}
+
+	now := time.Now()
+	meta := stache.Meta{ContentType: stache.ContentType(res.GetContentType())}
+	if ms := res.GetExpiresAtMs(); ms != 0 {
+		meta.ExpiresAt = time.UnixMilli(ms)
+	}
+	if !res.GetTracked() || id == 0 || id != n.trackingIDs[n.shardOf(key)] {
+		limit := now.Add(n.cfg.FallbackTTL)
+		if meta.ExpiresAt.IsZero() || meta.ExpiresAt.After(limit) {
+			meta.ExpiresAt = limit
+		}
+	}
+	if !meta.ExpiresAt.IsZero() && !meta.ExpiresAt.After(now) {
+		return
+	}
+
+	_ = n.local.Set(key, res.GetValue(), meta)
+}
+
+func (n *nearCache) invalidate(keys ...string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, key := range keys {
+		n.local.Delete(key)
+		if f, ok := n.fetches[key]; ok {
+			f.gen++
+		}
+	}
+}
+
+// flush drops every key of the shard, or of all shards if shard < 0.
+func (n *nearCache) flush(shard int) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if shard < 0 {
+		n.local.Clear()
+	} else {
+		for _, e := range n.local.Entries() {
+			if n.shardOf(e.Key) == shard {
+				n.local.Delete(e.Key)
+			}
+		}
+	}
+	for key, f := range n.fetches {
+		if shard < 0 || n.shardOf(key) == shard {
+			f.gen++
+		}
+	}
+}
+
+func (n *nearCache) connected(shard int, id uint64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.trackingIDs[shard] = id
+}
+
+// disconnected stops tracking the shard's keys and cuts their local
+// entries to FallbackTTL.
+func (n *nearCache) disconnected(shard int) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.trackingIDs[shard] = 0
+	limit := time.Now().Add(n.cfg.FallbackTTL)
+	for _, e := range n.local.Entries() {
+		if n.shardOf(e.Key) != shard {
+			continue
+		}
+		if e.ExpiresAt.IsZero() || e.ExpiresAt.After(limit) {
+			_ = n.local.ExpireAt(e.Key, limit)
+		}
+	}
+}
+
+// run keeps the shard's Invalidations stream open until ctx ends,
+// reconnecting with backoff.
+func (n *nearCache) run(ctx context.Context, shard int, rpc service) {
+	backoff := nearCacheMinBackoff
+	for {
+		if n.listen(ctx, shard, rpc) {
+			backoff = nearCacheMinBackoff
+		}
+		n.disconnected(shard)
+
+		t := time.NewTimer(backoff/2 + rand.N(backoff/2+1))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		backoff = min(2*backoff, nearCacheMaxBackoff)
+	}
+}
+
+// listen follows one Invalidations stream until it fails or goes quiet,
+// and reports whether it was given a tracking id.
+func (n *nearCache) listen(ctx context.Context, shard int, rpc service) bool {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	watchdog := time.AfterFunc(nearCacheWatchdog, cancel)
+	defer watchdog.Stop()
+
+	stream, err := rpc.Invalidations(ctx, connect.NewRequest(&stachev1.InvalidationsRequest{}))
+	if err != nil {
+		return false
+	}
+	defer stream.Close()
+
+	ok := false
+	for stream.Receive() {
+		watchdog.Reset(nearCacheWatchdog)
+
+		msg := stream.Msg()
+		if id := msg.GetTrackingId(); id != 0 {
+			n.connected(shard, id)
+			ok = true
+		}
+		if msg.GetFlush() {
+			n.flush(shard)
+		}
+		if keys := msg.GetKeys(); len(keys) > 0 {
+			n.invalidate(keys...)
+		}
+	}
+
+	return ok
+}
+
+// NearCacheStats returns a summary of the near cache, or the zero Stats if
+// it is disabled. Hits count Gets served locally.
+func (c *Client) NearCacheStats() stache.Stats {
+	if c.near == nil {
+		return stache.Stats{}
+	}
+	return c.near.local.Stats()
+}
+
+// invalidate drops keys from the near cache after a write through this
+// client, so that the client reads its own writes.
+func (c *Client) invalidate(keys ...string) {
+	if c.near != nil {
+		c.near.invalidate(keys...)
+	}
+}
@@ -0,0 +1,194 @@
+package stacheclient
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+const (
+	// latencyWindow is how many recent read latencies a shard keeps to
+	// place the hedging delay, and latencyMinSamples how many it needs
+	// before reads are hedged. The delay is recomputed every
+	// latencyMinSamples reads.
+	latencyWindow     = 256
+	latencyMinSamples = 32
+)
+
+// ErrCrossShard is returned by calls on several keys that are not all on
+// the same shard.
+var ErrCrossShard = errors.New("keys belong to different shards")
+
+// HedgePolicy controls hedged reads. When a read has gone unanswered for
+// longer than the given percentile of the shard's recent read latencies,
+// the same read is sent to one of the shard's replicas, and the first
+// answer wins. A read that fails with connect.CodeUnavailable is hedged at
+// once. Writes are never hedged.
+type HedgePolicy struct {
+	// Percentile, such as 0.95, enables hedging if it is between 0 and 1.
+	Percentile float64
+
+	// MinDelay is the least time a read waits before it is hedged.
+	MinDelay time.Duration
+}
+
+type shard struct {
+	primary  *endpoint
+	replicas []*endpoint
+	latency  latencies
+}
+
+// replica picks a replica at random, preferring those whose breaker is
+// not open.
+func (sh *shard) replica() *endpoint {
+	start := rand.IntN(len(sh.replicas))
+	for i := range sh.replicas {
+		ep := sh.replicas[(start+i)%len(sh.replicas)]
+		if ep.breaker.current() != BreakerOpen {
+			return ep
+		}
+	}
+	return sh.replicas[start]
+}
+
+// latencies keeps a window of recent read latencies.
+type latencies struct {
+	mutex   sync.Mutex
+	samples [latencyWindow]time.Duration
+	n       int
+	delay   time.Duration
+}
+
+func (l *latencies) observe(d time.Duration, percentile float64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.samples[l.n%latencyWindow] = d
+	l.n++
+	if l.n%latencyMinSamples == 0 {
+		s := slices.Clone(l.samples[:min(l.n, latencyWindow)])
+		slices.Sort(s)
+		l.delay = s[int(percentile*float64(len(s)-1))]
+	}
+}
+
+// hedgeDelay returns the latency percentile, once there are enough
+// samples.
+func (l *latencies) hedgeDelay() (time.Duration, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.delay, l.n >= latencyMinSamples
+}
+
+// shard returns the shard owning key.
+func (c *Client) shard(key string) *shard {
+	return c.shards[c.owners.Index(key)]
+}
+
+// shardOf returns the shard owning all of keys, or ErrCrossShard.
+func (c *Client) shardOf(keys []string) (*shard, error) {
+	if len(c.shards) == 1 || len(keys) == 0 {
+		return c.shards[0], nil
+	}
+
+	i := c.owners.Index(keys[0])
+	for _, key := range keys[1:] {
+		if c.owners.Index(key) != i {
+			return nil, ErrCrossShard
+		}
+	}
+	return c.shards[i], nil
+}
+
+// eachShard runs fn on every shard at once, and joins the errors.
+func (c *Client) eachShard(fn func(i int, sh *shard) error) error {
+	if len(c.shards) == 1 {
+		return fn(0, c.shards[0])
+	}
+
+	errs := make([]error, len(c.shards))
+	var wg sync.WaitGroup
+	for i, sh := range c.shards {
+		wg.Go(func() { errs[i] = fn(i, sh) })
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// read runs an idempotent read on sh, hedging it if enabled.
+func read[Req, Res any](ctx context.Context, c *Client, sh *shard, m method[Req, Res], msg *Req) (*Res, error) {
+	return hedged(ctx, c, sh, m, msg, msg)
+}
+
+// hedged is read with a separate message for replicas, which must not
+// carry anything specific to sh's server.
+func hedged[Req, Res any](ctx context.Context, c *Client, sh *shard, m method[Req, Res], msg, replicaMsg *Req) (*Res, error) {
+	p := c.cfg.Hedge.Percentile
+	if p <= 0 || p >= 1 || len(sh.replicas) == 0 {
+		return call(ctx, c, sh.primary, true, m, msg)
+	}
+
+	start := time.Now()
+	delay, ok := sh.latency.hedgeDelay()
+	if !ok {
+		res, err := call(ctx, c, sh.primary, true, m, msg)
+		if err == nil {
+			sh.latency.observe(time.Since(start), p)
+		}
+		return res, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		res *Res
+		err error
+	}
+	results := make(chan result, 2)
+	go func() {
+		res, err := call(ctx, c, sh.primary, true, m, msg)
+		results <- result{res, err}
+	}()
+
+	timer := time.NewTimer(max(delay, c.cfg.Hedge.MinDelay))
+	defer timer.Stop()
+	hedge, pending := timer.C, 1
+
+	for {
+		select {
+		case <-hedge:
+			hedge = nil
+			pending++
+			replica := sh.replica()
+			go func() {
+				res, err := call(ctx, c, replica, true, m, replicaMsg)
+				results <- result{res, err}
+			}()
+
+		case r := <-results:
+			pending--
+			unavailable := connect.CodeOf(r.err) == connect.CodeUnavailable
+			if !unavailable || (pending == 0 && hedge == nil) {
+				// When the replica wins, the time so far is a lower bound
+				// on the server's latency.
+				if r.err == nil || errors.Is(r.err, stache.ErrNotFound) {
+					sh.latency.observe(time.Since(start), p)
+				}
+				return r.res, r.err
+			}
+			if hedge != nil {
+				timer.Reset(0)
+			}
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package stacheclient
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	stachev1 "github.com/byytelope/stache/api/stache/v1"
+	"github.com/byytelope/stache/pkg/stache"
+)
+
+// HSet sets field in the hash at key and reports whether it was created.
+func (c *Client) HSet(ctx context.Context, key string, field string, value []byte) (bool, error) {
+	res, err := call(ctx, c, c.shard(key).primary, true, service.HSet, &stachev1.HSetRequest{Key: &key, Field: &field, Value: value})
+	if err != nil {
+		return false, err
+	}
+	return res.GetCreated(), nil
+}
+
+func (c *Client) HGet(ctx context.Context, key string, field string) ([]byte, error) {
+	res, err := read(ctx, c, c.shard(key), service.HGet, &stachev1.HGetRequest{Key: &key, Field: &field})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetValue(), nil
+}
+
+// HDel removes fields from the hash at key and returns how many existed.
+func (c *Client) HDel(ctx context.Context, key string, fields ...string) (int, error) {
+	res, err := call(ctx, c, c.shard(key).primary, true, service.HDel, &stachev1.HDelRequest{Key: &key, Fields: fields})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetRemoved()), nil
+}
+
+func (c *Client) HGetAll(ctx context.Context, key string) (map[string][]byte, error) {
+	res, err := read(ctx, c, c.shard(key), service.HGetAll, &stachev1.HGetAllRequest{Key: &key})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]byte, len(res.GetFields()))
+	for _, f := range res.GetFields() {
+		out[f.GetField()] = f.GetValue()
+	}
+	return out, nil
+}
+
+// HIncrBy adds delta to the integer field and returns the new value. It
+// is not retried, since a retry could apply delta twice.
+func (c *Client) HIncrBy(ctx context.Context, key string, field string, delta int64) (int64, error) {
+	res, err := call(ctx, c, c.shard(key).primary, false, service.HIncrBy, &stachev1.HIncrByRequest{Key: &key, Field: &field, Delta: &delta})
+	if err != nil {
+		return 0, err
+	}
+	return res.GetValue(), nil
+}
+
+// LPush prepends values to the list at key and returns its new length.
+// Like the other list updates, it is not retried.
+func (c *Client) LPush(ctx context.Context, key string, values ...[]byte) (int, error) {
+	res, err := call(ctx, c, c.shard(key).primary, false, service.LPush, &stachev1.LPushRequest{Key: &key, Values: values})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetLength()), nil
+}
+
+func (c *Client) RPush(ctx context.Context, key string, values ...[]byte) (int, error) {
+	res, err := call(ctx, c, c.shard(key).primary, false, service.RPush, &stachev1.RPushRequest{Key: &key, Values: values})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetLength()), nil
+}
+
+func (c *Client) LPop(ctx context.Context, key string) ([]byte, error) {
+	res, err := call(ctx, c, c.shard(key).primary, false, service.LPop, &stachev1.LPopRequest{Key: &key})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetValue(), nil
+}
+
+func (c *Client) RPop(ctx context.Context, key string) ([]byte, error) {
+	res, err := call(ctx, c, c.shard(key).primary, false, service.RPop, &stachev1.RPopRequest{Key: &key})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetValue(), nil
+}
+
+// BPop removes and returns the head of the list at key, waiting up to
+// timeout for a value to be pushed; if timeout <= 0, only ctx bounds the
+// wait. The client's timeout is added on top of the wait.
+func (c *Client) BPop(ctx context.Context, key string, timeout time.Duration) ([]byte, error) {
+	req := &stachev1.BPopRequest{Key: &key}
+	callTimeout := time.Duration(-1)
+	if timeout > 0 {
+		req.Timeout = durationpb.New(timeout)
+		callTimeout = timeout + max(c.cfg.Timeout, 0)
+	}
+
+	res, err := callWithTimeout(ctx, c, c.shard(key).primary, callTimeout, false, service.BPop, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetValue(), nil
+}
+
+func (c *Client) LRange(ctx context.Context, key string, start, stop int) ([][]byte, error) {
+	s, e := int64(start), int64(stop)
+	res, err := read(ctx, c, c.shard(key), service.LRange, &stachev1.LRangeRequest{Key: &key, Start: &s, Stop: &e})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetValues(), nil
+}
+
+func (c *Client) LLen(ctx context.Context, key string) (int, error) {
+	res, err := read(ctx, c, c.shard(key), service.LLen, &stachev1.LLenRequest{Key: &key})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetLength()), nil
+}
+
+// SAdd adds members to the set at key and returns how many were new.
+func (c *Client) SAdd(ctx context.Context, key string, members ...string) (int, error) {
+	res, err := call(ctx, c, c.shard(key).primary, true, service.SAdd, &stachev1.SAddRequest{Key: &key, Members: members})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetAdded()), nil
+}
+
+func (c *Client) SRem(ctx context.Context, key string, members ...string) (int, error) {
+	res, err := call(ctx, c, c.shard(key).primary, true, service.SRem, &stachev1.SRemRequest{Key: &key, Members: members})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetRemoved()), nil
+}
+
+func (c *Client) SIsMember(ctx context.Context, key string, member string) (bool, error) {
+	res, err := read(ctx, c, c.shard(key), service.SIsMember, &stachev1.SIsMemberRequest{Key: &key, Member: &member})
+	if err != nil {
+		return false, err
+	}
+	return res.GetIsMember(), nil
+}
+
+func (c *Client) SMembers(ctx context.Context, key string) ([]string, error) {
+	res, err := read(ctx, c, c.shard(key), service.SMembers, &stachev1.SMembersRequest{Key: &key})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetMembers(), nil
+}
+
+func (c *Client) SCard(ctx context.Context, key string) (int, error) {
+	res, err := read(ctx, c, c.shard(key), service.SCard, &stachev1.SCardRequest{Key: &key})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetCount()), nil
+}
+
+func (c *Client) SUnion(ctx context.Context, keys ...string) ([]string, error) {
+	return c.setAlgebra(ctx, service.SUnion, keys)
+}
+
+func (c *Client) SInter(ctx context.Context, keys ...string) ([]string, error) {
+	return c.setAlgebra(ctx, service.SInter, keys)
+}
+
+func (c *Client) SDiff(ctx context.Context, keys ...string) ([]string, error) {
+	return c.setAlgebra(ctx, service.SDiff, keys)
+}
+
+// setAlgebra runs m on the shard holding keys, which must all be on one
+// shard.
+func (c *Client) setAlgebra(ctx context.Context, m method[stachev1.SetAlgebraRequest, stachev1.SetAlgebraResponse], keys []string) ([]string, error) {
+	sh, err := c.shardOf(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := read(ctx, c, sh, m, &stachev1.SetAlgebraRequest{Keys: keys})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetMembers(), nil
+}
+
+// ZAdd sets member's score in the sorted set at key and reports whether
+// it was added.
+func (c *Client) ZAdd(ctx context.Context, key string, score float64, member string) (bool, error) {
+	res, err := call(ctx, c, c.shard(key).primary, true, service.ZAdd, &stachev1.ZAddRequest{Key: &key, Member: &member, Score: &score})
+	if err != nil {
+		return false, err
+	}
+	return res.GetAdded(), nil
+}
+
+func (c *Client) ZRem(ctx context.Context, key string, members ...string) (int, error) {
+	res, err := call(ctx, c, c.shard(key).primary, true, service.ZRem, &stachev1.ZRemRequest{Key: &key, Members: members})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetRemoved()), nil
+}
+
+func (c *Client) ZScore(ctx context.Context, key string, member string) (float64, error) {
+	res, err := read(ctx, c, c.shard(key), service.ZScore, &stachev1.ZScoreRequest{Key: &key, Member: &member})
+	if err != nil {
+		return 0, err
+	}
+	return res.GetScore(), nil
+}
+
+func (c *Client) ZRank(ctx context.Context, key string, member string) (int, error) {
+	res, err := read(ctx, c, c.shard(key), service.ZRank, &stachev1.ZRankRequest{Key: &key, Member: &member})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.GetRank()), nil
+}
+
+func (c *Client) ZRangeByScore(ctx context.Context, key string, min, max float64) ([]stache.ZMember, error) {
+	res, err := read(ctx, c, c.shard(key), service.ZRangeByScore, &stachev1.ZRangeByScoreRequest{Key: &key, Min: &min, Max: &max})
+	if err != nil {
+		return nil, err
+	}
+	return fromZMembers(res.GetMembers()), nil
+}
+
+func (c *Client) ZRangeByRank(ctx context.Context, key string, start, stop int) ([]stache.ZMember, error) {
+	s, e := int64(start), int64(stop)
+	res, err := read(ctx, c, c.shard(key), service.ZRangeByRank, &stachev1.ZRangeByRankRequest{Key: &key, Start: &s, Stop: &e})
+	if err != nil {
+		return nil, err
+	}
+	return fromZMembers(res.GetMembers()), nil
+}
+
+func fromZMembers(members []*stachev1.ZMember) []stache.ZMember {
+	out := make([]stache.ZMember, 0, len(members))
+	for _, m := range members {
+		out = append(out, stache.ZMember{Member: m.GetMember(), Score: m.GetScore()})
+	}
+	return out
+}